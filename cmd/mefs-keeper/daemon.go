@@ -8,6 +8,7 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"sync"
@@ -26,6 +27,7 @@ import (
 	"github.com/memoio/go-mefs/manageNode/keeper"
 	mpb "github.com/memoio/go-mefs/pb"
 	"github.com/memoio/go-mefs/repo/fsrepo"
+	"github.com/memoio/go-mefs/role"
 	"github.com/memoio/go-mefs/utils"
 	"github.com/memoio/go-mefs/utils/address"
 	"github.com/memoio/go-mefs/utils/metainfo"
@@ -258,6 +260,13 @@ func daemonFunc(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment
 
 	contracts.EndPoint = cfg.Eth
 
+	if cfg.ChalPolicy != "" {
+		err = keeper.SetChalPolicy(cfg.ChalPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
 	// start logger
 	utils.StartLogger()
 
@@ -338,6 +347,15 @@ func daemonFunc(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment
 		utils.MLogger.Error("Put role key to local falied: ", err)
 	}
 
+	// record billing of local accounts
+	role.InitBilling(node.Context(), node.Data)
+
+	// send transactions with gas strategy, and keep pending ones in journal
+	err = contracts.InitTxManager(node.Context(), contracts.NewTxConfig(cfg.Gas), filepath.Join(cctx.ConfigRoot, "txjournal"))
+	if err != nil {
+		utils.MLogger.Error("Init transaction manager falied: ", err)
+	}
+
 	defer func() { //关闭daemon时进行的操作
 		// We wait for the node to close first, as the node has children
 		// that it will wait for before closing, such as the API server.
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
@@ -25,6 +26,7 @@ import (
 	"github.com/memoio/go-mefs/crypto/pdp"
 	mpb "github.com/memoio/go-mefs/pb"
 	"github.com/memoio/go-mefs/repo/fsrepo"
+	"github.com/memoio/go-mefs/role"
 	"github.com/memoio/go-mefs/storageNode/commands"
 	"github.com/memoio/go-mefs/storageNode/corehttp"
 	"github.com/memoio/go-mefs/storageNode/provider"
@@ -63,6 +65,7 @@ const (
 	netKeyKwd                 = "netKey"
 	postKwd                    = "post"
 	gcKwd                     = "cleanPost"
+	closeMarginKwd            = "channelCloseMargin"
 )
 
 var (
@@ -152,6 +155,7 @@ environment variable:
 		cmds.StringOption(depositKwd, "deCap", "provider deposits how capacity of storage, such as 900MB, 10GB or 2TB").WithDefault(""),
 		cmds.BoolOption(postKwd, "Post feature for provider to flushing data").WithDefault(false),
 		cmds.BoolOption(gcKwd, "gc", "used for provider to clean post data").WithDefault(false),
+		cmds.Int64Option(closeMarginKwd, "provider closes read-payment channels how many minutes before timeout").WithDefault(int64(60)),
 		cmds.StringOption("extAddress", "extAddr", "provider external address when using ddns or port mapping, tcp protocol only, such as: 239v39e500.zicp.vip:50272 or 123.123.123.123:50272").WithDefault(""),
 	},
 	Subcommands: map[string]*cmds.Command{},
@@ -351,6 +355,15 @@ func daemonFunc(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment
 		utils.MLogger.Error("Put role key to local falied: ", err)
 	}
 
+	// record billing of local accounts
+	role.InitBilling(node.Context(), node.Data)
+
+	// send transactions with gas strategy, and keep pending ones in journal
+	err = contracts.InitTxManager(node.Context(), contracts.NewTxConfig(cfg.Gas), filepath.Join(cctx.ConfigRoot, "txjournal"))
+	if err != nil {
+		utils.MLogger.Error("Init transaction manager falied: ", err)
+	}
+
 	defer func() { //关闭daemon时进行的操作
 		// We wait for the node to close first, as the node has children
 		// that it will wait for before closing, such as the API server.
@@ -484,6 +497,11 @@ func daemonFunc(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment
 
 		post, _ := req.Options[postKwd].(bool)
 		gc, _ := req.Options[gcKwd].(bool)
+		closeMargin, _ := req.Options[closeMarginKwd].(int64)
+		err = provider.SetChannelCloseMargin(closeMargin)
+		if err != nil {
+			return err
+		}
 
 		ins, err := provider.New(node.Context(), node.Identity.Pretty(), node.PrivateKey, node.Data, node.Routing, capacity, duration*24*60*60, decapacity, price, rdo, post, gc, exAddr)
 		if err != nil {
@@ -8,10 +8,12 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"sync"
 
+	"github.com/memoio/go-mefs/role"
 	"github.com/memoio/go-mefs/utils/address"
 
 	cmds "github.com/ipfs/go-ipfs-cmds"
@@ -50,6 +52,7 @@ const (
 	writableKwd               = "writable"
 	enableMultiplexKwd        = "enable-mplex-experiment"
 	enableTendermintKwd       = "tendermint"
+	gracePeriodKwd            = "gracePeriod"
 	capacityKwd               = "storageCapacity"
 	durationKwd               = "storageDuration"
 	priceKwd                  = "storagePrice"
@@ -141,6 +144,7 @@ environment variable:
 		cmds.StringOption(netKeyKwd, "the netKey is used to setup private network").WithDefault("dev"),
 		cmds.StringOption(passwordKwd, "pwd", "the password is used to decrypt the PrivateKey").WithDefault(""),
 		cmds.StringOption(secretKeyKwd, "sk", "the stored PrivateKey").WithDefault(""),
+		cmds.Int64Option(gracePeriodKwd, "Days of keeping data after upkeeping ends by keepers, which is sent to keepers of each lfs").WithDefault(utils.DefaultGracePeriod),
 	},
 	Subcommands: map[string]*cmds.Command{},
 	Run:         daemonFunc,
@@ -328,6 +332,15 @@ func daemonFunc(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment
 		utils.MLogger.Error("Put role key falied: ", err)
 	}
 
+	// record billing of local accounts
+	role.InitBilling(node.Context(), node.Data)
+
+	// send transactions with gas strategy, and keep pending ones in journal
+	err = contracts.InitTxManager(node.Context(), contracts.NewTxConfig(cfg.Gas), filepath.Join(cctx.ConfigRoot, "txjournal"))
+	if err != nil {
+		utils.MLogger.Error("Init transaction manager falied: ", err)
+	}
+
 	defer func() { //关闭daemon时进行的操作
 		// We wait for the node to close first, as the node has children
 		// that it will wait for before closing, such as the API server.
@@ -372,6 +385,11 @@ func daemonFunc(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment
 	switch cfg.Role {
 	case metainfo.RoleUser:
 		fmt.Println("Starting as a user")
+		gracePeriod, _ := req.Options[gracePeriodKwd].(int64)
+		err = role.SetGracePeriod(gracePeriod)
+		if err != nil {
+			return err
+		}
 		ins, err := user.New(node.Context(), node.Identity.Pretty(), node.Data, node.Routing)
 		if err != nil {
 			fmt.Println("Start user daemon fails:", err)
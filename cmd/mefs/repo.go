@@ -0,0 +1,86 @@
+package mefs
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+	oldcmds "github.com/memoio/go-mefs/commands"
+	config "github.com/memoio/go-mefs/config"
+	fsrepo "github.com/memoio/go-mefs/repo/fsrepo"
+)
+
+// RepoCmd manipulates the repo offline
+var RepoCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Manipulate the mefs repo.",
+		ShortDescription: `
+'mefs repo' is a plumbing command used to manipulate the repo.
+`,
+	},
+
+	Subcommands: map[string]*cmds.Command{
+		"migrate-datastore": repoMigrateDatastoreCmd,
+	},
+}
+
+var repoMigrateDatastoreCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Convert the datastore to the configuration of a profile.",
+		ShortDescription: `
+'mefs repo migrate-datastore' applies a datastore profile, such as pebbleds
+or default-datastore, to the config and copies all entries of changed
+datastores, e.g. levelds to pebbleds. It must be run when daemon is stopped.
+Old datastores are kept and can be removed after migration.
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("profile", true, false, "The datastore profile to migrate to"),
+	},
+	PreRun: func(req *cmds.Request, env cmds.Environment) error {
+		cctx := env.(*oldcmds.Context)
+		daemonLocked, err := fsrepo.LockedByOtherProcess(cctx.ConfigRoot)
+		if err != nil {
+			return err
+		}
+
+		log.Println("checking if daemon is running...")
+		if daemonLocked {
+			e := "daemon is running. please stop it to run this command"
+			return cmds.ClientError(e)
+		}
+
+		return nil
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		cctx := env.(*oldcmds.Context)
+		if cctx.Online {
+			return cmds.Error{Message: "migrate-datastore must be run offline only"}
+		}
+
+		name := req.Arguments[0]
+		profile, ok := config.Profiles[name]
+		if !ok {
+			return fmt.Errorf("%s is not a profile", name)
+		}
+
+		conf, err := fsrepo.ConfigAt(cctx.ConfigRoot)
+		if err != nil {
+			return err
+		}
+
+		err = profile.Transform(conf)
+		if err != nil {
+			return err
+		}
+
+		err = fsrepo.MigrateDatastore(cctx.ConfigRoot, conf.Datastore.Spec, os.Stdout)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("datastore is migrated to", name)
+		return nil
+	},
+}
@@ -14,19 +14,22 @@ import (
 
 // Config is used to load mefs config files.
 type Config struct {
-	Role      string    // local node's role:user provider keeper
-	PeerID    string    //local node's peer id
-	Datastore Datastore // local node's storage
-	Addresses Addresses // local node's addresses
-	Discovery Discovery // local node's discovery mechanisms
-	Routing   Routing   // local node's routing settings
-	Bootstrap []string  // local nodes's bootstrap peer addresses
-	Gateway   Gateway   // local node's gateway server options
-	API       API       // local node's API settings
-	Swarm     SwarmConfig
-	IsInit    bool   //local node's status:init or not
-	Eth       string //ethereum private chain, default is "http://119.147.213.220:8191"
-	Test      bool   //if Test is true, run for testing
+	Role       string    // local node's role:user provider keeper
+	PeerID     string    //local node's peer id
+	Datastore  Datastore // local node's storage
+	Addresses  Addresses // local node's addresses
+	Discovery  Discovery // local node's discovery mechanisms
+	Routing    Routing   // local node's routing settings
+	Bootstrap  []string  // local nodes's bootstrap peer addresses
+	Gateway    Gateway   // local node's gateway server options
+	API        API       // local node's API settings
+	Swarm      SwarmConfig
+	IsInit     bool      //local node's status:init or not
+	Eth        string    //ethereum private chain, default is "http://119.147.213.220:8191"
+	Gas        Gas       //gas strategy of contract transactions
+	ChalPolicy string    //challenge policy of keeper, default is "adaptive"
+	ReadCache  ReadCache //cache of hot data read from disks or network
+	Test       bool      //if Test is true, run for testing
 
 	Experimental Experiments
 }
@@ -0,0 +1,11 @@
+package config
+
+// Gas is the gas strategy of contract transactions; zero values are defaults
+type Gas struct {
+	MinPrice     int64  // wei, gas price is at least it
+	MaxPrice     int64  // wei, gas price, including replacement, is at most it
+	MaxLimit     uint64 // gas limit is at most it
+	Estimate     bool   // estimate gas limit instead of using fixed one
+	StuckTimeout int64  // second, pending transaction is replaced after it
+	PriceBump    int64  // percent, gas price is raised by it when replacing
+}
@@ -1,6 +1,9 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Transformer is a function which takes configuration and applies some filter to it
 type Transformer func(c *Config) error
@@ -126,17 +129,96 @@ Make sure to backup your data frequently.`,
 			return nil
 		},
 	},
+	"pebbleds": {
+		Description: `Replaces leveldb of metadata in default datastore
+configuration with pebble datastore, which has better write throughput
+for millions of keys. Blocks are still stored in flatfs.
+
+If you apply this profile after mefs init, you will need
+to convert your datastore to the new configuration.
+You can do this offline using
+$ mefs repo migrate-datastore pebbleds`,
+
+		Transform: func(c *Config) error {
+			c.Datastore.Spec = map[string]interface{}{
+				"type": "mount",
+				"mounts": []interface{}{
+					map[string]interface{}{
+						"mountpoint": "/data",
+						"type":       "measure",
+						"prefix":     "flatfs.datastore",
+						"child": map[string]interface{}{
+							"type":      "flatfs",
+							"path":      "data",
+							"sync":      true,
+							"shardFunc": "/repo/flatfs/shard/v1/next-to-last/2",
+						},
+					},
+					map[string]interface{}{
+						"mountpoint": "/",
+						"type":       "measure",
+						"prefix":     "pebble.datastore",
+						"child": map[string]interface{}{
+							"type":        "pebbleds",
+							"path":        "pebbleds",
+							"compression": "none",
+						},
+					},
+				},
+			}
+			return nil
+		},
+	},
+	"multidisk": {
+		Description: `Replaces flatfs of blocks in datastore configuration with
+multidisk datastore, which places blocks on many disks weighted by their
+capacity and reports blocks lost on failed disks to keepers for repair.
+Disks are added by appending {"path", "capacity"} to "disks" of multidisk
+in config, capacity is in GB and the whole disk is used without it.
+
+If you apply this profile after mefs init, you will need
+to convert your datastore to the new configuration.
+You can do this offline using
+$ mefs repo migrate-datastore multidisk`,
+
+		Transform: func(c *Config) error {
+			mounts, ok := c.Datastore.Spec["mounts"].([]interface{})
+			if !ok {
+				return fmt.Errorf("datastore is not a mount")
+			}
+
+			for _, iface := range mounts {
+				m, ok := iface.(map[string]interface{})
+				if !ok || m["mountpoint"] != "/data" {
+					continue
+				}
+				if m["type"] != "measure" {
+					return fmt.Errorf("datastore at /data is not measured")
+				}
+
+				m["prefix"] = "multidisk.datastore"
+				m["child"] = map[string]interface{}{
+					"type":      "multidisk",
+					"sync":      true,
+					"shardFunc": "/repo/flatfs/shard/v1/next-to-last/2",
+					"disks": []interface{}{
+						map[string]interface{}{
+							"path": "blocks",
+						},
+					},
+				}
+				return nil
+			}
+			return fmt.Errorf("no datastore is mounted at /data")
+		},
+	},
 	"default-datastore": {
 		Description: `Restores default datastore configuration.
 
 If you apply this profile after mefs init, you will need
 to convert your datastore to the new configuration.
-You can do this using ipfs-ds-convert.
-
-For more on ipfs-ds-convert see
-$ ipfs-ds-convert --help
-and
-$ ipfs-ds-convert convert --help
+You can do this offline using
+$ mefs repo migrate-datastore default-datastore
 `,
 
 		Transform: func(c *Config) error {
@@ -0,0 +1,9 @@
+package config
+
+// ReadCache is the cache of hot data, raw fields of blocks on providers and
+// decoded segments on users; zero values are defaults, so the cache is on
+// by default on every node and takes up to 256MB of memory
+type ReadCache struct {
+	Size   int64  // MB, default is 256; cache is disabled if it is negative
+	Policy string // admission of new data: "lfu" or "lru", default is "lfu"
+}
@@ -0,0 +1,92 @@
+package contracts
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Backend is the chain which contracts are deployed on and called through;
+// it is an ethclient dialed to EndPoint, unless one is set by SetBackend;
+// transactions are sent through the tx manager
+type Backend interface {
+	bind.ContractBackend
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+var (
+	backendLk    sync.RWMutex
+	chainBackend Backend
+	// indexerAddr is the well known indexer, it is changed on a simulated chain
+	indexerAddr = common.HexToAddress(indexerHex)
+	txRecorder  TxRecorder
+)
+
+// TxRecorder is called with sender, transaction and its receipt after
+// the transaction is mined, e.g. for recording gas cost
+type TxRecorder func(from common.Address, tx *types.Transaction, receipt *types.Receipt)
+
+// SetBackend makes all contract calls go through b instead of EndPoint;
+// nil restores the default
+func SetBackend(b Backend) {
+	backendLk.Lock()
+	defer backendLk.Unlock()
+	chainBackend = b
+}
+
+// GetBackend returns the backend set by SetBackend
+func GetBackend() Backend {
+	backendLk.RLock()
+	defer backendLk.RUnlock()
+	return chainBackend
+}
+
+// SetTxRecorder sets r to be called for every mined transaction; nil disables it
+func SetTxRecorder(r TxRecorder) {
+	backendLk.Lock()
+	defer backendLk.Unlock()
+	txRecorder = r
+}
+
+func getTxRecorder() TxRecorder {
+	backendLk.RLock()
+	defer backendLk.RUnlock()
+	return txRecorder
+}
+
+// SetIndexerAddr sets address of the indexer which all resolvers are added to
+func SetIndexerAddr(addr common.Address) {
+	backendLk.Lock()
+	defer backendLk.Unlock()
+	indexerAddr = addr
+}
+
+func getIndexerAddr() common.Address {
+	backendLk.RLock()
+	defer backendLk.RUnlock()
+	return indexerAddr
+}
+
+func getClient(endPoint string) Backend {
+	b := GetBackend()
+	if b != nil {
+		return &txBackend{b}
+	}
+
+	client, err := rpc.Dial(endPoint)
+	if err != nil {
+		log.Println(err)
+	}
+	return &txBackend{ethclient.NewClient(client)}
+}
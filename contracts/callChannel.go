@@ -64,7 +64,7 @@ func (ch *ChannelNodeInfo) DeployChannelContract(queryAddress, providerAddress c
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -75,7 +75,7 @@ func (ch *ChannelNodeInfo) DeployChannelContract(queryAddress, providerAddress c
 		if err != nil {
 			retryCount++
 			log.Println("deploy Channel Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -188,7 +188,7 @@ func (ch *ChannelNodeInfo) ChannelTimeout(channelAddress common.Address) (err er
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -196,7 +196,7 @@ func (ch *ChannelNodeInfo) ChannelTimeout(channelAddress common.Address) (err er
 		if err != nil {
 			retryCount++
 			log.Println("channelTimeout Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -248,7 +248,7 @@ func (ch *ChannelNodeInfo) CloseChannel(channelAddress common.Address, sig []byt
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -256,7 +256,7 @@ func (ch *ChannelNodeInfo) CloseChannel(channelAddress common.Address, sig []byt
 		if err != nil {
 			retryCount++
 			log.Println("closeChannel Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -302,7 +302,7 @@ func (ch *ChannelNodeInfo) ExtendChannelTime(channelAddress common.Address, addT
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -310,7 +310,7 @@ func (ch *ChannelNodeInfo) ExtendChannelTime(channelAddress common.Address, addT
 		if err != nil {
 			retryCount++
 			log.Println("extendChannelTime Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -51,7 +51,7 @@ func (m *ContractManageInfo) DeployIndexer() (common.Address, *indexer.Indexer,
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -63,7 +63,7 @@ func (m *ContractManageInfo) DeployIndexer() (common.Address, *indexer.Indexer,
 		if err != nil {
 			retryCount++
 			log.Println("deploy Indexer Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -138,7 +138,7 @@ func (m *ContractManageInfo) AddToIndexer(addAddr common.Address, key string, ad
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -147,7 +147,7 @@ func (m *ContractManageInfo) AddToIndexer(addAddr common.Address, key string, ad
 		if err != nil {
 			retryCount++
 			log.Println("add addr to indexer err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -209,7 +209,7 @@ func (m *ContractManageInfo) AlterAddrInIndexer(addAddr common.Address, key stri
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -217,7 +217,7 @@ func (m *ContractManageInfo) AlterAddrInIndexer(addAddr common.Address, key stri
 		if err != nil {
 			retryCount++
 			log.Println("alter addr in indexer err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -248,7 +248,7 @@ func (m *ContractManageInfo) GetResolverAddr(key string) (common.Address, common
 	var resAddr common.Address
 
 	client := getClient(EndPoint)
-	adminIndexerAddr := common.HexToAddress(indexerHex)
+	adminIndexerAddr := getIndexerAddr()
 	adminIndexer, err := indexer.NewIndexer(adminIndexerAddr, client)
 	if err != nil {
 		log.Println("new admin Indexer err: ", err)
@@ -310,7 +310,7 @@ func (m *ContractManageInfo) DeployResolver() (common.Address, *resolver.Resolve
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -322,7 +322,7 @@ func (m *ContractManageInfo) DeployResolver() (common.Address, *resolver.Resolve
 		if err != nil {
 			retryCount++
 			log.Println("deploy resolver err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -366,7 +366,7 @@ func (m *ContractManageInfo) AddToResolver(addAddr common.Address, resolverInsta
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -374,7 +374,7 @@ func (m *ContractManageInfo) AddToResolver(addAddr common.Address, resolverInsta
 		if err != nil {
 			retryCount++
 			log.Println("add addr to resolver err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -465,7 +465,7 @@ func (m *ContractManageInfo) DeployMapper() (common.Address, *mapper.Mapper, err
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -477,7 +477,7 @@ func (m *ContractManageInfo) DeployMapper() (common.Address, *mapper.Mapper, err
 		if err != nil {
 			retryCount++
 			log.Println("deploy mapper err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -518,7 +518,7 @@ func (m *ContractManageInfo) AddToMapper(addr common.Address, mapperInstance *ma
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -526,7 +526,7 @@ func (m *ContractManageInfo) AddToMapper(addr common.Address, mapperInstance *ma
 		if err != nil {
 			retryCount++
 			log.Println("add addr to MapperContract err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -712,7 +712,7 @@ func (m *ContractManageInfo) GetResolverFromAdmin(key string, flag bool) (common
 			return resolverAddr, nil, err
 		}
 		client := getClient(EndPoint)
-		adminIndexerAddr := common.HexToAddress(indexerHex)
+		adminIndexerAddr := getIndexerAddr()
 		adminIndexer, err := indexer.NewIndexer(adminIndexerAddr, client)
 		if err != nil {
 			log.Println("New Admin Indexer Err: ", err)
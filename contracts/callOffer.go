@@ -70,7 +70,7 @@ func (m *MarketInfo) DeployOffer(capacity, duration int64, price *big.Int, redo
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -81,7 +81,7 @@ func (m *MarketInfo) DeployOffer(capacity, duration int64, price *big.Int, redo
 		if err != nil {
 			retryCount++
 			log.Println("deploy Offer Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -147,7 +147,7 @@ func (m *MarketInfo) ExtendOfferTime(offerAddress common.Address, addTime *big.I
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -155,7 +155,7 @@ func (m *MarketInfo) ExtendOfferTime(offerAddress common.Address, addTime *big.I
 		if err != nil {
 			retryCount++
 			log.Println("extend Offer time Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -86,7 +86,7 @@ func (m *MarketInfo) DeployQuery(capacity, storeDays int64, price *big.Int, ks i
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -97,7 +97,7 @@ func (m *MarketInfo) DeployQuery(capacity, storeDays int64, price *big.Int, ks i
 		if err != nil {
 			retryCount++
 			log.Println("deploy Query Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -163,7 +163,7 @@ func (m *MarketInfo) SetQueryCompleted(queryAddress common.Address) error {
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -171,7 +171,7 @@ func (m *MarketInfo) SetQueryCompleted(queryAddress common.Address) error {
 		if err != nil {
 			retryCount++
 			log.Println("set query completed Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
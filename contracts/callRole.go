@@ -47,7 +47,7 @@ func (r *RoleInfo) DeployKeeperAdmin() (err error) {
 	}
 	log.Println("keeperContractAddr:", keeperContractAddr.String())
 
-	indexerAddr := common.HexToAddress(indexerHex)
+	indexerAddr := getIndexerAddr()
 	indexer, err := indexer.NewIndexer(indexerAddr, getClient(EndPoint))
 	if err != nil {
 		log.Println("newIndexerErr:", err)
@@ -101,7 +101,7 @@ func (r *RoleInfo) SetKeeper(accountAddress common.Address, isKeeper bool) (err
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -175,7 +175,7 @@ func (r *RoleInfo) SetKeeperBanned(accountAddress common.Address, isBanned bool)
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -222,7 +222,7 @@ func (r *RoleInfo) SetKeeperPrice(price *big.Int) (err error) {
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -285,7 +285,7 @@ func (r *RoleInfo) PledgeKeeper(amount *big.Int) (err error) {
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -293,7 +293,7 @@ func (r *RoleInfo) PledgeKeeper(amount *big.Int) (err error) {
 		if err != nil {
 			retryCount++
 			log.Println("keeper pledge error:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -380,7 +380,7 @@ func (r *RoleInfo) DeployProviderAdmin() (err error) {
 	}
 	log.Println("providerContractAddr:", providerContractAddr.String())
 
-	indexerAddr := common.HexToAddress(indexerHex)
+	indexerAddr := getIndexerAddr()
 	indexer, err := indexer.NewIndexer(indexerAddr, getClient(EndPoint))
 	if err != nil {
 		log.Println("newIndexerErr:", err)
@@ -433,7 +433,7 @@ func (r *RoleInfo) SetProvider(accountAddress common.Address, isProvider bool) (
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -507,7 +507,7 @@ func (r *RoleInfo) SetProviderBanned(accountAddress common.Address, isBanned boo
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -570,7 +570,7 @@ func (r *RoleInfo) SetProviderPrice(price *big.Int) (err error) {
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -628,7 +628,7 @@ func (r *RoleInfo) PledgeProvider(size *big.Int) (err error) {
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -636,7 +636,7 @@ func (r *RoleInfo) PledgeProvider(size *big.Int) (err error) {
 		if err != nil {
 			retryCount++
 			log.Println("provider pledge error:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -722,7 +722,7 @@ func (r *RoleInfo) DeployKPMap() error {
 		return err
 	}
 
-	indexerAddr := common.HexToAddress(indexerHex)
+	indexerAddr := getIndexerAddr()
 	indexer, err := indexer.NewIndexer(indexerAddr, getClient(EndPoint))
 	if err != nil {
 		log.Println("newIndexerErr:", err)
@@ -786,7 +786,7 @@ func (r *RoleInfo) AddKeeperProvidersToKPMap(keeperAddress common.Address, provi
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -841,7 +841,7 @@ func (r *RoleInfo) DeleteKeeperFromKPMap(keeperAddress common.Address) error {
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -897,7 +897,7 @@ func (r *RoleInfo) DeleteProviderFromKPMap(keeperAddress common.Address, provide
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
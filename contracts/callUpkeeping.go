@@ -58,7 +58,7 @@ func (u *UpkeepingInfo) DeployUpkeeping(queryAddress common.Address, keeperAddre
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -70,7 +70,7 @@ func (u *UpkeepingInfo) DeployUpkeeping(queryAddress common.Address, keeperAddre
 		if err != nil {
 			retryCount++
 			log.Println("deploy UpKeeping Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -192,7 +192,7 @@ func (u *UpkeepingInfo) SpaceTimePay(ukAddr, providerAddr common.Address, stStar
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -201,7 +201,7 @@ func (u *UpkeepingInfo) SpaceTimePay(ukAddr, providerAddr common.Address, stStar
 		if err != nil {
 			retryCount++
 			log.Println("spaceTimePay Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -248,7 +248,7 @@ func (u *UpkeepingInfo) AddProvider(ukAddr common.Address, providerAddress []com
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -257,7 +257,7 @@ func (u *UpkeepingInfo) AddProvider(ukAddr common.Address, providerAddress []com
 		if err != nil {
 			retryCount++
 			log.Println("addProvider Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -335,7 +335,7 @@ func (u *UpkeepingInfo) ExtendTime(userAddress common.Address, key string, addTi
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -344,7 +344,7 @@ func (u *UpkeepingInfo) ExtendTime(userAddress common.Address, key string, addTi
 		if err != nil {
 			retryCount++
 			log.Println("extendUKTime Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -371,6 +371,60 @@ func (u *UpkeepingInfo) ExtendTime(userAddress common.Address, key string, addTi
 	return nil
 }
 
+//TopUp transfers value to the upKeeping contract, e.g. before extending its time
+func (u *UpkeepingInfo) TopUp(userAddress common.Address, key string, value *big.Int) error {
+	_, uk, err := u.GetUpkeeping(userAddress, key)
+	if err != nil {
+		return err
+	}
+
+	log.Println("begin top up upkeeping...")
+	tx := &types.Transaction{}
+	retryCount := 0
+	checkRetryCount := 0
+	for {
+		auth, errMA := makeAuth(u.hexSk, value, nil, big.NewInt(defaultGasPrice), defaultGasLimit)
+		if errMA != nil {
+			return errMA
+		}
+
+		if err == ErrTxFail && tx != nil {
+			auth.Nonce = big.NewInt(int64(tx.Nonce()))
+			auth.GasPrice = replaceGasPrice(tx)
+			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
+		}
+
+		tx, err = uk.Receive(auth)
+		if err != nil {
+			retryCount++
+			log.Println("topUpUK Err:", err)
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
+				log.Println("previously pending transaction has successfully executed")
+				break
+			}
+			if retryCount > sendTransactionRetryCount {
+				return err
+			}
+			time.Sleep(retryTxSleepTime)
+			continue
+		}
+
+		err = checkTx(tx)
+		if err != nil {
+			checkRetryCount++
+			log.Println("topUpUK transaction fails", err)
+			if checkRetryCount > checkTxRetryCount {
+				return err
+			}
+			continue
+		}
+		break
+	}
+
+	log.Println("UK has been successfully topped up!")
+	return nil
+}
+
 //DestructUpKeeping destruct the upKeeping contract and transfer the balance of contract to user, anyone can call
 func (u *UpkeepingInfo) DestructUpKeeping(userAddress common.Address, key string) error {
 	_, uk, err := u.GetUpkeeping(userAddress, key)
@@ -390,7 +444,7 @@ func (u *UpkeepingInfo) DestructUpKeeping(userAddress common.Address, key string
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -398,7 +452,7 @@ func (u *UpkeepingInfo) DestructUpKeeping(userAddress common.Address, key string
 		if err != nil {
 			retryCount++
 			log.Println("destruct UK Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -444,7 +498,7 @@ func (u *UpkeepingInfo) SetKeeperStop(userAddress, keeperAddr common.Address, ke
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -452,7 +506,7 @@ func (u *UpkeepingInfo) SetKeeperStop(userAddress, keeperAddr common.Address, ke
 		if err != nil {
 			retryCount++
 			log.Println("set keeper stop Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
@@ -508,7 +562,7 @@ func (u *UpkeepingInfo) SetProviderStop(userAddress, providerAddr, ukAddr common
 
 		if err == ErrTxFail && tx != nil {
 			auth.Nonce = big.NewInt(int64(tx.Nonce()))
-			auth.GasPrice = new(big.Int).Add(tx.GasPrice(), big.NewInt(defaultGasPrice))
+			auth.GasPrice = replaceGasPrice(tx)
 			log.Println("rebuild transaction... nonce is ", auth.Nonce, " gasPrice is ", auth.GasPrice)
 		}
 
@@ -516,7 +570,7 @@ func (u *UpkeepingInfo) SetProviderStop(userAddress, providerAddr, ukAddr common
 		if err != nil {
 			retryCount++
 			log.Println("set provider stop Err:", err)
-			if err.Error() == core.ErrNonceTooLow.Error() && auth.GasPrice.Cmp(big.NewInt(defaultGasPrice)) > 0 {
+			if err.Error() == core.ErrNonceTooLow.Error() && auth.Nonce != nil {
 				log.Println("previously pending transaction has successfully executed")
 				break
 			}
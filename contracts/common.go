@@ -14,8 +14,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/memoio/go-mefs/contracts/channel"
 	"github.com/memoio/go-mefs/contracts/upKeeping"
 	"github.com/memoio/go-mefs/utils"
@@ -99,14 +97,6 @@ func init() {
 }
 
 //GetClient get rpc-client based the endPoint
-func getClient(endPoint string) *ethclient.Client {
-	client, err := rpc.Dial(endPoint)
-	if err != nil {
-		log.Println(err)
-	}
-	return ethclient.NewClient(client)
-}
-
 //MakeAuth make the transactOpts to call contract
 func makeAuth(hexSk string, moneyToContract, nonce, gasPrice *big.Int, gasLimit uint64) (*bind.TransactOpts, error) {
 	auth := &bind.TransactOpts{}
@@ -117,29 +107,24 @@ func makeAuth(hexSk string, moneyToContract, nonce, gasPrice *big.Int, gasLimit
 	}
 
 	auth = bind.NewKeyedTransactor(sk)
-	auth.GasPrice = gasPrice
+	auth.GasPrice = txm.gasPrice(gasPrice)
 	auth.Value = moneyToContract //放进合约里的钱
 	auth.Nonce = nonce
-	auth.GasLimit = gasLimit
+	auth.GasLimit = txm.gasLimit(gasLimit)
+	// for replacing stuck transactions
+	txm.addSigner(auth.From, auth.Signer)
 	return auth, nil
 }
 
 //QueryBalance query the balance of account
 func (a *AdminOwnedInfo) QueryBalance(account string) (*big.Int, error) {
-	var result string
-
-	client, err := rpc.Dial(EndPoint)
-	if err != nil {
-		log.Println("rpc.dial err:", err)
-		return big.NewInt(0), err
-	}
+	client := getClient(EndPoint)
 
 	retryCount := 0
 	for {
 		retryCount++
 
-		err = client.Call(&result, "eth_getBalance", account, "latest")
-
+		balance, err := client.BalanceAt(context.Background(), common.HexToAddress(account), nil)
 		if err != nil {
 			if retryCount > sendTransactionRetryCount {
 				return big.NewInt(0), err
@@ -147,19 +132,13 @@ func (a *AdminOwnedInfo) QueryBalance(account string) (*big.Int, error) {
 			time.Sleep(retryGetInfoSleepTime)
 			continue
 		}
-		balance := utils.HexToBigInt(result)
 		return balance, nil
 	}
 }
 
 //GetLatestBlock get latest block from chain
 func (a *AdminOwnedInfo) GetLatestBlock() (*types.Block, error) {
-	client, err := ethclient.Dial(EndPoint)
-	if err != nil {
-		log.Println("rpc.Dial err", err)
-		return nil, err
-	}
-
+	client := getClient(EndPoint)
 	b, err := client.BlockByNumber(context.Background(), nil)
 	if err != nil {
 		log.Println("client.call err:", err)
@@ -178,20 +157,41 @@ func GetMemoPrice() *big.Float {
 func checkTx(tx *types.Transaction) error {
 	log.Println("Check Tx hash:", tx.Hash().Hex(), "nonce:", tx.Nonce(), "gasPrice:", tx.GasPrice())
 
+	from, err := types.Sender(types.HomesteadSigner{}, tx)
+	if err != nil {
+		return err
+	}
+
+	txm.wait(from, tx.Nonce(), 1)
+	defer txm.wait(from, tx.Nonce(), -1)
+
 	var receipt *types.Receipt
+	mined := tx
 	for i := 0; i < 20; i++ {
-		receipt = getTransactionReceipt(tx.Hash())
+		// tx may be replaced when it is stuck
+		receipt, mined = txm.receipt(from, tx)
 		if receipt != nil {
 			break
 		}
+		txm.replaceStuck(from, tx.Nonce())
 		t := checkTxSleepTime * (i + 1)
 		time.Sleep(time.Duration(t) * time.Second)
 	}
 
 	if receipt == nil { //245s获取不到交易信息，判定交易失败
+		txm.Lock()
+		txm.metrics.timeout.Inc()
+		txm.Unlock()
 		return ErrTxFail
 	}
 
+	txm.done(from, tx.Nonce(), receipt)
+
+	// gas is paid even if execution fails
+	if r := getTxRecorder(); r != nil {
+		r(from, mined, receipt)
+	}
+
 	if receipt.Status == 0 { //等于0表示交易失败，等于1表示成功
 		log.Println("Transaction mined but execution failed")
 		txReceipt, err := receipt.MarshalJSON()
@@ -209,11 +209,8 @@ func checkTx(tx *types.Transaction) error {
 
 //GetTransactionReceipt 通过交易hash获得交易详情
 func getTransactionReceipt(hash common.Hash) *types.Receipt {
-	client, err := ethclient.Dial(EndPoint)
-	if err != nil {
-		log.Fatal("rpc.Dial err", err)
-	}
-	receipt, err := client.TransactionReceipt(context.Background(), hash)
+	client := getClient(EndPoint)
+	receipt, _ := client.TransactionReceipt(context.Background(), hash)
 	return receipt
 }
 
@@ -221,11 +218,7 @@ func getTransactionReceipt(hash common.Hash) *types.Receipt {
 func getLogs(restrictAddress []common.Address, fromBlock, toBlock *big.Int) ([]types.Log, error) {
 	log.Println("begin to filter logs in chain...")
 
-	client, err := ethclient.Dial(EndPoint)
-	if err != nil {
-		log.Println("rpc.Dial err", err)
-		return nil, err
-	}
+	client := getClient(EndPoint)
 
 	query := ethereum.FilterQuery{
 		FromBlock: fromBlock,
@@ -320,12 +313,11 @@ func (a *AdminOwnedInfo) GetReadIncome(restrictAddress []common.Address, provide
 
 //getBlockTime get block's timeStamp
 func getBlockTime(blockHash common.Hash) (uint64, error) {
-	client, err := ethclient.Dial(EndPoint)
+	client := getClient(EndPoint)
+	blockHeader, err := client.HeaderByHash(context.Background(), blockHash)
 	if err != nil {
 		return 0, err
 	}
-
-	blockHeader, err := client.HeaderByHash(context.Background(), blockHash)
 	time := blockHeader.Time
 	return time, nil
 }
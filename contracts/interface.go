@@ -65,6 +65,7 @@ type ContractUpkeeping interface {
 	AddProvider(ukAddr common.Address, providerAddress []common.Address, sign [][]byte) error
 	GetOrder(ukAddr common.Address) (common.Address, []upKeeping.UpKeepingKPInfo, []upKeeping.UpKeepingKPInfo, *big.Int, *big.Int, *big.Int, *big.Int, *big.Int, *big.Int, *big.Int, []upKeeping.UpKeepingProof, error)
 	ExtendTime(userAddress common.Address, key string, addTime int64) error
+	TopUp(userAddress common.Address, key string, value *big.Int) error
 	DestructUpKeeping(userAddress common.Address, key string) error
 	SetKeeperStop(userAddress, keeperAddr common.Address, key string, sign [][]byte) error
 	SetProviderStop(userAddress, providerAddr, ukAddr common.Address, key string, sign [][]byte) error
@@ -0,0 +1,296 @@
+// +build simchain
+
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/memoio/go-mefs/contracts/adminOwned"
+	recoverContract "github.com/memoio/go-mefs/contracts/recover"
+	id "github.com/memoio/go-mefs/crypto/identity"
+)
+
+const (
+	simGasLimit = uint64(80000000)
+	// adminOwnedHex is the adminOwned contract whose address is compiled into other contracts
+	adminOwnedHex = "0x8026796Fd7cE63EAe824314AA5bacF55643e893d"
+	// recoverHex is the recover contract whose address is compiled into upKeeping and channel
+	recoverHex = "0x5eF5fD065210CBd62Dfe86cC68a0E1AB2C5CBA50"
+)
+
+// SimulatedChain is an in-process chain for offline tests; every transaction
+// is mined into its own block as soon as it is sent, so receipts are ready
+// when checkTx asks for them. It is built with tag simchain only, so it is
+// not linked into binaries; run its tests by "go test -tags simchain".
+type SimulatedChain struct {
+	*backends.SimulatedBackend
+	AdminSk string
+}
+
+// SendTransaction sends tx and mines it at once
+func (s *SimulatedChain) SendTransaction(ctx context.Context, tx *types.Transaction) (err error) {
+	// simulated backend panics on invalid tx, e.g. nonce is used by a concurrent sender
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	err = s.SimulatedBackend.SendTransaction(ctx, tx)
+	if err != nil {
+		return err
+	}
+	s.Commit()
+	return nil
+}
+
+// NewSimulatedChain creates an in-process chain, funds adminSk and accounts
+// with balance, and uses it as the backend of contracts. As admin, it deploys
+// the indexer and the keeper, provider and kpMap contracts in indexer; contracts
+// of users, keepers and providers are deployed by DeployGroup.
+func NewSimulatedChain(adminSk string, accounts []common.Address, balance *big.Int) (*SimulatedChain, error) {
+	adminAddr, err := id.GetAdressFromSk(adminSk)
+	if err != nil {
+		return nil, err
+	}
+
+	// contracts at compiled-in addresses are put in genesis
+	alloc := core.GenesisAlloc{
+		adminAddr: {Balance: balance},
+	}
+
+	alloc[common.HexToAddress(adminOwnedHex)], err = genContract(adminSk, alloc, func(auth *bind.TransactOpts, b bind.ContractBackend) (common.Address, error) {
+		addr, _, _, err := adminOwned.DeployAdminOwned(auth, b)
+		return addr, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	alloc[common.HexToAddress(recoverHex)], err = genContract(adminSk, alloc, func(auth *bind.TransactOpts, b bind.ContractBackend) (common.Address, error) {
+		addr, _, _, err := recoverContract.DeployRecover(auth, b)
+		return addr, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range accounts {
+		alloc[addr] = core.GenesisAccount{Balance: balance}
+	}
+
+	sc := &SimulatedChain{
+		SimulatedBackend: backends.NewSimulatedBackend(alloc, simGasLimit),
+		AdminSk:          adminSk,
+	}
+	SetBackend(sc)
+
+	ma := NewCManage(adminAddr, adminSk)
+	indexerAddr, _, err := ma.DeployIndexer()
+	if err != nil {
+		sc.Close()
+		return nil, err
+	}
+	SetIndexerAddr(indexerAddr)
+
+	cr := NewCR(adminAddr, adminSk)
+	err = cr.DeployKeeperAdmin()
+	if err != nil {
+		sc.Close()
+		return nil, err
+	}
+
+	err = cr.DeployProviderAdmin()
+	if err != nil {
+		sc.Close()
+		return nil, err
+	}
+
+	err = cr.DeployKPMap()
+	if err != nil {
+		sc.Close()
+		return nil, err
+	}
+
+	log.Println("simulated chain is ready, indexer:", indexerAddr.String())
+	return sc, nil
+}
+
+// SimGroup is contracts of a storage group on a simulated chain
+type SimGroup struct {
+	Query     common.Address
+	UpKeeping common.Address
+	Root      common.Address
+	Offers    []common.Address // by index of providers
+	Channels  []common.Address // from user to providers, by index of providers
+}
+
+// DeployGroup deploys contracts of a group as it is created on a real chain:
+// admin sets keepers and providers, each provider deploys its offer, and user
+// deploys its query, upKeeping, root and a channel to each provider; resolvers
+// and mappers are deployed along with them. Accounts should have balance.
+func (s *SimulatedChain) DeployGroup(userSk string, keeperSks, proSks []string, size, days int64, price *big.Int) (*SimGroup, error) {
+	g := new(SimGroup)
+
+	adminAddr, err := id.GetAdressFromSk(s.AdminSk)
+	if err != nil {
+		return nil, err
+	}
+	cr := NewCR(adminAddr, s.AdminSk)
+
+	var keepers, providers []common.Address
+	for _, sk := range keeperSks {
+		addr, err := id.GetAdressFromSk(sk)
+		if err != nil {
+			return nil, err
+		}
+
+		err = cr.SetKeeper(addr, true)
+		if err != nil {
+			return nil, err
+		}
+		keepers = append(keepers, addr)
+	}
+
+	duration := days * 24 * 60 * 60
+	for _, sk := range proSks {
+		addr, err := id.GetAdressFromSk(sk)
+		if err != nil {
+			return nil, err
+		}
+
+		err = cr.SetProvider(addr, true)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, addr)
+
+		offerAddr, err := NewCM(addr, sk).DeployOffer(size, duration, price, false)
+		if err != nil {
+			return nil, err
+		}
+		g.Offers = append(g.Offers, offerAddr)
+	}
+
+	userAddr, err := id.GetAdressFromSk(userSk)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := NewCM(userAddr, userSk)
+	g.Query, err = cm.DeployQuery(size, days, price, len(keepers), len(providers), false)
+	if err != nil {
+		return nil, err
+	}
+
+	money := new(big.Int).Mul(price, big.NewInt(size*days))
+	g.UpKeeping, err = NewCU(userAddr, userSk).DeployUpkeeping(g.Query, keepers, providers, duration, size, price, duration, money, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cm.SetQueryCompleted(g.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Root, err = NewCRoot(userAddr, userSk).DeployRoot(g.Query, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := NewCH(userAddr, userSk)
+	for _, pro := range providers {
+		chanAddr, err := ch.DeployChannelContract(g.Query, pro, big.NewInt(duration), money, false)
+		if err != nil {
+			return nil, err
+		}
+		g.Channels = append(g.Channels, chanAddr)
+	}
+
+	return g, nil
+}
+
+// genContract deploys a contract by adminSk on a scratch chain with alloc, and
+// returns its code and storage, which are put at its compiled-in address in genesis
+func genContract(adminSk string, alloc core.GenesisAlloc, deploy func(*bind.TransactOpts, bind.ContractBackend) (common.Address, error)) (core.GenesisAccount, error) {
+	var ga core.GenesisAccount
+
+	sb := backends.NewSimulatedBackend(alloc, simGasLimit)
+	defer sb.Close()
+
+	auth, err := makeAuth(adminSk, nil, nil, big.NewInt(defaultGasPrice), defaultGasLimit)
+	if err != nil {
+		return ga, err
+	}
+
+	addr, err := deploy(auth, sb)
+	if err != nil {
+		return ga, err
+	}
+	sb.Commit()
+
+	code, err := sb.CodeAt(context.Background(), addr, nil)
+	if err != nil {
+		return ga, err
+	}
+
+	statedb, err := sb.Blockchain().State()
+	if err != nil {
+		return ga, err
+	}
+
+	ga.Code = code
+	ga.Balance = big.NewInt(0)
+	ga.Storage = make(map[common.Hash]common.Hash)
+	err = statedb.ForEachStorage(addr, func(key, value common.Hash) bool {
+		ga.Storage[key] = value
+		return true
+	})
+	return ga, err
+}
+
+// Close stops the chain and restores the default backend
+func (s *SimulatedChain) Close() error {
+	SetBackend(nil)
+	SetIndexerAddr(common.HexToAddress(indexerHex))
+	return s.SimulatedBackend.Close()
+}
+
+// Transfer sends value from admin to addr
+func (s *SimulatedChain) Transfer(addr common.Address, value *big.Int) error {
+	adminAddr, err := id.GetAdressFromSk(s.AdminSk)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := s.PendingNonceAt(context.Background(), adminAddr)
+	if err != nil {
+		return err
+	}
+
+	auth, err := makeAuth(s.AdminSk, nil, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	tx := types.NewTransaction(nonce, addr, value, 21000, big.NewInt(defaultGasPrice), nil)
+	signedTx, err := auth.Signer(types.HomesteadSigner{}, adminAddr, tx)
+	if err != nil {
+		return err
+	}
+
+	err = s.SendTransaction(context.Background(), signedTx)
+	if err != nil {
+		return err
+	}
+	return checkTx(signedTx)
+}
@@ -0,0 +1,245 @@
+// +build simchain
+
+package contracts
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	id "github.com/memoio/go-mefs/crypto/identity"
+	"github.com/memoio/go-mefs/utils"
+	"golang.org/x/crypto/sha3"
+)
+
+func genAccount(t *testing.T) (string, string) {
+	sk, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(crypto.FromECDSA(sk)), crypto.PubkeyToAddress(sk.PublicKey).Hex()
+}
+
+func TestSimulatedChain(t *testing.T) {
+	utils.StartLogger()
+
+	adminSk, _ := genAccount(t)
+	userSk, userHex := genAccount(t)
+	_, keeperHex := genAccount(t)
+
+	balance := new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+	userAddr := common.HexToAddress(userHex)
+	keeperAddr := common.HexToAddress(keeperHex)
+
+	sc, err := NewSimulatedChain(adminSk, nil, balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sc.Close()
+
+	money := new(big.Int).Div(balance, big.NewInt(2))
+	err = sc.Transfer(userAddr, money)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewCA(userAddr, userSk)
+	ba, err := a.QueryBalance(userHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ba.Cmp(money) != 0 {
+		t.Fatal("wrong balance: ", ba, ", expected: ", money)
+	}
+
+	adminAddr, err := id.GetAdressFromSk(adminSk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cr := NewCR(adminAddr, adminSk)
+	err = cr.SetKeeper(keeperAddr, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isKeeper, err := cr.IsKeeper(keeperAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isKeeper {
+		t.Fatal(keeperHex, " is not set as keeper")
+	}
+
+	// query is deployed through resolver and mapper of user
+	cm := NewCM(userAddr, userSk)
+	queryAddr, err := cm.DeployQuery(1000, 10, big.NewInt(100), 3, 5, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	querys, err := cm.GetQueryAddrs(userAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(querys) != 1 || querys[0] != queryAddr {
+		t.Fatal("got querys: ", querys, ", expected: ", queryAddr.Hex())
+	}
+}
+
+// TestSimulatedGroup runs contract flows of a group: creating it, setting
+// merkle root, paying spacetime to a provider and closing a read channel
+func TestSimulatedGroup(t *testing.T) {
+	utils.StartLogger()
+
+	adminSk, _ := genAccount(t)
+	userSk, userHex := genAccount(t)
+	userAddr := common.HexToAddress(userHex)
+
+	var accounts []common.Address
+	var keeperSks, proSks []string
+	for i := 0; i < 2; i++ {
+		sk, addr := genAccount(t)
+		keeperSks = append(keeperSks, sk)
+		accounts = append(accounts, common.HexToAddress(addr))
+
+		sk, addr = genAccount(t)
+		proSks = append(proSks, sk)
+		accounts = append(accounts, common.HexToAddress(addr))
+	}
+	accounts = append(accounts, userAddr)
+
+	balance := new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+	sc, err := NewSimulatedChain(adminSk, accounts, balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sc.Close()
+
+	price := big.NewInt(100)
+	g, err := sc.DeployGroup(userSk, keeperSks, proSks, 1000, 10, price)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proAddr, err := id.GetAdressFromSk(proSks[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cm := NewCM(proAddr, proSks[0])
+	capacity, _, _, _, err := cm.GetOfferInfo(g.Offers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capacity != 1000 {
+		t.Fatal("got offer capacity: ", capacity, ", expected: 1000")
+	}
+
+	_, _, _, ks, ps, completed, err := cm.GetQueryInfo(g.Query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ks != 2 || ps != 2 || !completed {
+		t.Fatal("got query with keepers: ", ks, ", providers: ", ps, ", completed: ", completed)
+	}
+
+	cu := NewCU(userAddr, userSk)
+	queryAddr, keepers, providers, _, _, _, createDate, _, _, _, _, err := cu.GetOrder(g.UpKeeping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queryAddr != g.Query || len(keepers) != 2 || len(providers) != 2 {
+		t.Fatal("got upkeeping of query: ", queryAddr.Hex(), ", keepers: ", len(keepers), ", providers: ", len(providers))
+	}
+
+	// user sets merkle root of its buckets
+	croot := NewCRoot(userAddr, userSk)
+	mroot := [32]byte{1, 2, 3}
+	err = croot.SetMerkleRoot(g.Root, 100, mroot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, value, err := croot.GetLatestMerkleRoot(g.Root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != 100 || value != mroot {
+		t.Fatal("got merkle root: ", key, value)
+	}
+
+	// keepers sign for spacetime of provider, and one of them pays it
+	stStart := createDate
+	stLength := big.NewInt(10)
+	stValue := big.NewInt(1000)
+	// shares of keepers, followed by their sum
+	share := []int64{50, 50, 100}
+	d := sha3.NewLegacyKeccak256()
+	d.Write(g.UpKeeping.Bytes())
+	d.Write(proAddr.Bytes())
+	d.Write(common.LeftPadBytes(stStart.Bytes(), 32))
+	d.Write(common.LeftPadBytes(stLength.Bytes(), 32))
+	d.Write(common.LeftPadBytes(stValue.Bytes(), 32))
+	d.Write(mroot[:])
+	for _, s := range share {
+		d.Write(common.LeftPadBytes(big.NewInt(s).Bytes(), 32))
+	}
+	hash := d.Sum(nil)
+
+	var signs [][]byte
+	for _, sk := range keeperSks {
+		sig, err := id.Sign(sk, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signs = append(signs, sig)
+	}
+
+	keeperAddr, err := id.GetAdressFromSk(keeperSks[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewCU(keeperAddr, keeperSks[0]).SpaceTimePay(g.UpKeeping, proAddr, stStart, stLength, stValue, mroot, share, signs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, _, _, _, _, _, _, _, needPay, proofs, err := cu.GetOrder(g.UpKeeping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if needPay.Cmp(stValue) != 0 || len(proofs) != 1 {
+		t.Fatal("got paid: ", needPay, ", proofs: ", len(proofs), ", expected: ", stValue)
+	}
+
+	// provider closes channel with read value signed by user
+	chanAddr := g.Channels[0]
+	readValue := big.NewInt(100)
+	skECDSA, err := id.ECDSAStringToSk(userSk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := crypto.Sign(crypto.Keccak256(chanAddr.Bytes(), common.LeftPadBytes(readValue.Bytes(), 32)), skECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewCH(proAddr, proSks[0]).CloseChannel(chanAddr, sig, readValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chanBalance, err := sc.BalanceAt(context.Background(), chanAddr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chanBalance.Sign() != 0 {
+		t.Fatal("closed channel has balance: ", chanBalance)
+	}
+}
@@ -0,0 +1,660 @@
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	metrics "github.com/ipfs/go-metrics-interface"
+	"github.com/memoio/go-mefs/config"
+)
+
+const (
+	nonceLockTimeout = 30 * time.Second
+	txWatchInterval  = time.Minute
+	// gasLimitMargin is percent added to estimated gas
+	gasLimitMargin = 20
+)
+
+// TxConfig is the gas strategy of transactions; zero values are replaced
+// by defaults
+type TxConfig struct {
+	MinGasPrice  *big.Int      // gas price is at least it
+	MaxGasPrice  *big.Int      // gas price, including replacement, is at most it
+	MaxGasLimit  uint64        // gas limit is at most it
+	EstimateGas  bool          // estimate gas limit instead of using fixed one
+	StuckTimeout time.Duration // pending transaction is replaced after it
+	PriceBump    int64         // percent of gas price raised in replacement
+}
+
+// NewTxConfig converts gas config of node to TxConfig
+func NewTxConfig(g config.Gas) TxConfig {
+	tc := TxConfig{
+		MaxGasLimit:  g.MaxLimit,
+		EstimateGas:  g.Estimate,
+		StuckTimeout: time.Duration(g.StuckTimeout) * time.Second,
+		PriceBump:    g.PriceBump,
+	}
+	if g.MinPrice > 0 {
+		tc.MinGasPrice = big.NewInt(g.MinPrice)
+	}
+	if g.MaxPrice > 0 {
+		tc.MaxGasPrice = big.NewInt(g.MaxPrice)
+	}
+	return tc
+}
+
+func (c TxConfig) withDefaults() TxConfig {
+	if c.MinGasPrice == nil || c.MinGasPrice.Sign() <= 0 {
+		c.MinGasPrice = big.NewInt(defaultGasPrice)
+	}
+	if c.MaxGasPrice == nil || c.MaxGasPrice.Cmp(c.MinGasPrice) < 0 {
+		c.MaxGasPrice = new(big.Int).Mul(c.MinGasPrice, big.NewInt(100))
+	}
+	if c.MaxGasLimit == 0 {
+		c.MaxGasLimit = defaultGasLimit
+	}
+	if c.StuckTimeout <= 0 {
+		c.StuckTimeout = 5 * time.Minute
+	}
+	if c.PriceBump <= 0 {
+		c.PriceBump = 20
+	}
+	return c
+}
+
+type txMetrics struct {
+	sent      metrics.Counter
+	sendErr   metrics.Counter
+	replaced  metrics.Counter
+	confirmed metrics.Counter
+	failed    metrics.Counter
+	timeout   metrics.Counter
+	pending   metrics.Gauge
+	latency   metrics.Histogram
+}
+
+func newTxMetrics() *txMetrics {
+	return &txMetrics{
+		sent:      metrics.New("mefs.contracts.tx.sent_total", "Number of transactions sent").Counter(),
+		sendErr:   metrics.New("mefs.contracts.tx.send.errors_total", "Number of transactions failed to send").Counter(),
+		replaced:  metrics.New("mefs.contracts.tx.replaced_total", "Number of stuck transactions replaced with higher gas price").Counter(),
+		confirmed: metrics.New("mefs.contracts.tx.confirmed_total", "Number of transactions mined and executed").Counter(),
+		failed:    metrics.New("mefs.contracts.tx.failed_total", "Number of transactions mined but execution failed").Counter(),
+		timeout:   metrics.New("mefs.contracts.tx.timeout_total", "Number of transactions not mined when check ends").Counter(),
+		pending:   metrics.New("mefs.contracts.tx.pending", "Number of pending transactions").Gauge(),
+		latency: metrics.New("mefs.contracts.tx.confirm.latency_seconds", "Latency from first sending to mining of transactions").
+			Histogram([]float64{5, 15, 30, 60, 120, 300, 600, 1200, 3600}),
+	}
+}
+
+// pendingTx is transactions sent with the same nonce; the last one is the latest
+type pendingTx struct {
+	from     common.Address
+	nonce    uint64
+	txs      []*types.Transaction
+	sent     time.Time // first sent
+	lastSent time.Time
+	waiting  int // number of checkTx waiting for it
+}
+
+func (p *pendingTx) latest() *types.Transaction {
+	return p.txs[len(p.txs)-1]
+}
+
+// txAccount serializes getting nonce and sending transaction of an account
+type txAccount struct {
+	next   uint64 // nonce after the latest transaction sent by this node
+	lock   chan struct{}
+	held   bool
+	gen    uint64
+	locked uint64 // nonce given under lock
+}
+
+// txManager gives nonces to concurrent transactions of the same account,
+// applies gas strategy, tracks pending transactions in journal, and
+// replaces them with higher gas price when they are stuck
+type txManager struct {
+	sync.Mutex
+	cfg      TxConfig
+	journal  string
+	accounts map[common.Address]*txAccount
+	pending  map[string]*pendingTx
+	signers  map[common.Address]bind.SignerFn
+	metrics  *txMetrics
+	once     sync.Once
+}
+
+var txm = &txManager{
+	cfg:      TxConfig{}.withDefaults(),
+	accounts: make(map[common.Address]*txAccount),
+	pending:  make(map[string]*pendingTx),
+	signers:  make(map[common.Address]bind.SignerFn),
+	metrics:  newTxMetrics(),
+}
+
+// SetTxConfig sets gas strategy of transactions
+func SetTxConfig(cfg TxConfig) {
+	txm.Lock()
+	defer txm.Unlock()
+	txm.cfg = cfg.withDefaults()
+}
+
+// InitTxManager sets gas strategy, loads pending transactions from journal
+// and re-sends them, and watches pending transactions until ctx is done;
+// journal is not used if it is empty
+func InitTxManager(ctx context.Context, cfg TxConfig, journal string) error {
+	SetTxConfig(cfg)
+
+	// metrics are injected by daemon now
+	txm.once.Do(func() {
+		txm.Lock()
+		txm.metrics = newTxMetrics()
+		txm.Unlock()
+	})
+
+	err := txm.load(journal)
+	if err != nil {
+		return err
+	}
+
+	go txm.watch(ctx)
+	return nil
+}
+
+func txKey(from common.Address, nonce uint64) string {
+	return from.Hex() + "/" + strconv.FormatUint(nonce, 10)
+}
+
+func (m *txManager) getAccount(addr common.Address) *txAccount {
+	m.Lock()
+	defer m.Unlock()
+	acc, ok := m.accounts[addr]
+	if !ok {
+		acc = &txAccount{
+			lock: make(chan struct{}, 1),
+		}
+		m.accounts[addr] = acc
+	}
+	return acc
+}
+
+func (m *txManager) addSigner(addr common.Address, signer bind.SignerFn) {
+	m.Lock()
+	defer m.Unlock()
+	m.signers[addr] = signer
+}
+
+// gasPrice applies gas strategy to price, nil is the minimum
+func (m *txManager) gasPrice(price *big.Int) *big.Int {
+	m.Lock()
+	defer m.Unlock()
+	if price == nil || price.Cmp(m.cfg.MinGasPrice) < 0 {
+		return new(big.Int).Set(m.cfg.MinGasPrice)
+	}
+	if price.Cmp(m.cfg.MaxGasPrice) > 0 {
+		return new(big.Int).Set(m.cfg.MaxGasPrice)
+	}
+	return new(big.Int).Set(price)
+}
+
+// bumpGasPrice raises price by PriceBump percent for replacing a transaction;
+// it is not more than MaxGasPrice
+func bumpGasPrice(price *big.Int) *big.Int {
+	txm.Lock()
+	bump := txm.cfg.PriceBump
+	txm.Unlock()
+
+	res := new(big.Int).Mul(price, big.NewInt(100+bump))
+	res.Quo(res, big.NewInt(100))
+	if res.Cmp(price) <= 0 {
+		res.Add(price, big.NewInt(1))
+	}
+	return txm.gasPrice(res)
+}
+
+// replaceGasPrice returns gas price for replacing tx, which is higher than
+// all transactions sent with the same nonce
+func replaceGasPrice(tx *types.Transaction) *big.Int {
+	price := tx.GasPrice()
+	from, err := types.Sender(types.HomesteadSigner{}, tx)
+	if err == nil {
+		txm.Lock()
+		p, ok := txm.pending[txKey(from, tx.Nonce())]
+		if ok && p.latest().GasPrice().Cmp(price) > 0 {
+			price = p.latest().GasPrice()
+		}
+		txm.Unlock()
+	}
+	return bumpGasPrice(price)
+}
+
+// gasLimit applies gas strategy to limit; 0 means estimating
+func (m *txManager) gasLimit(limit uint64) uint64 {
+	m.Lock()
+	defer m.Unlock()
+	if m.cfg.EstimateGas || limit == 0 {
+		return 0
+	}
+	if limit > m.cfg.MaxGasLimit {
+		return m.cfg.MaxGasLimit
+	}
+	return limit
+}
+
+// estimateLimit adds margin to estimated gas; if estimation fails, e.g.
+// execution will fail, the max limit is used as before
+func (m *txManager) estimateLimit(gas uint64, err error) uint64 {
+	m.Lock()
+	defer m.Unlock()
+	if err != nil {
+		log.Println("estimate gas err: ", err)
+		return m.cfg.MaxGasLimit
+	}
+	gas += gas * gasLimitMargin / 100
+	if gas > m.cfg.MaxGasLimit {
+		return m.cfg.MaxGasLimit
+	}
+	return gas
+}
+
+// nonceAt locks account until the transaction with the returned nonce is
+// sent, or nonceLockTimeout passes
+func (m *txManager) nonceAt(ctx context.Context, b Backend, addr common.Address) (uint64, error) {
+	acc := m.getAccount(addr)
+	select {
+	case acc.lock <- struct{}{}:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	nonce, err := b.PendingNonceAt(ctx, addr)
+
+	m.Lock()
+	acc.held = true
+	acc.gen++
+	gen := acc.gen
+	if err == nil && acc.next > nonce {
+		// node has not seen all transactions sent by us
+		nonce = acc.next
+	}
+	acc.locked = nonce
+	m.Unlock()
+
+	if err != nil {
+		m.unlockNonce(acc, gen)
+		return 0, err
+	}
+
+	time.AfterFunc(nonceLockTimeout, func() {
+		m.unlockNonce(acc, gen)
+	})
+	return nonce, nil
+}
+
+func (m *txManager) unlockNonce(acc *txAccount, gen uint64) {
+	m.Lock()
+	defer m.Unlock()
+	if acc.held && acc.gen == gen {
+		acc.held = false
+		<-acc.lock
+	}
+}
+
+// sent tracks tx after it is sent, and unlocks its account
+func (m *txManager) sent(from common.Address, tx *types.Transaction, err error) {
+	acc := m.getAccount(from)
+
+	m.Lock()
+	defer m.Unlock()
+
+	if acc.held && acc.locked == tx.Nonce() {
+		acc.held = false
+		<-acc.lock
+	}
+
+	if err != nil {
+		m.metrics.sendErr.Inc()
+		return
+	}
+
+	m.metrics.sent.Inc()
+	if tx.Nonce()+1 > acc.next {
+		acc.next = tx.Nonce() + 1
+	}
+
+	now := time.Now()
+	key := txKey(from, tx.Nonce())
+	p, ok := m.pending[key]
+	if !ok {
+		p = &pendingTx{
+			from:  from,
+			nonce: tx.Nonce(),
+			sent:  now,
+		}
+		m.pending[key] = p
+		m.metrics.pending.Inc()
+	}
+	p.lastSent = now
+	for _, ptx := range p.txs {
+		if ptx.Hash() == tx.Hash() {
+			return
+		}
+	}
+	p.txs = append(p.txs, tx)
+	m.save()
+}
+
+// receipt returns receipt of any transaction sent with the same nonce as tx,
+// and the mined one
+func (m *txManager) receipt(from common.Address, tx *types.Transaction) (*types.Receipt, *types.Transaction) {
+	txs := []*types.Transaction{tx}
+	m.Lock()
+	p, ok := m.pending[txKey(from, tx.Nonce())]
+	if ok {
+		txs = append([]*types.Transaction{}, p.txs...)
+	}
+	m.Unlock()
+
+	client := getClient(EndPoint)
+	for i := len(txs) - 1; i >= 0; i-- {
+		receipt, _ := client.TransactionReceipt(context.Background(), txs[i].Hash())
+		if receipt != nil {
+			return receipt, txs[i]
+		}
+	}
+	return nil, nil
+}
+
+func (m *txManager) wait(from common.Address, nonce uint64, add int) {
+	m.Lock()
+	defer m.Unlock()
+	p, ok := m.pending[txKey(from, nonce)]
+	if ok {
+		p.waiting += add
+	}
+}
+
+// done removes transactions of nonce from pending; receipt is nil if
+// they are dropped
+func (m *txManager) done(from common.Address, nonce uint64, receipt *types.Receipt) {
+	m.Lock()
+	defer m.Unlock()
+
+	key := txKey(from, nonce)
+	p, ok := m.pending[key]
+	if ok {
+		delete(m.pending, key)
+		m.metrics.pending.Dec()
+		m.save()
+	}
+
+	if receipt == nil {
+		return
+	}
+
+	if ok {
+		m.metrics.latency.Observe(time.Since(p.sent).Seconds())
+	}
+	if receipt.Status == 0 {
+		m.metrics.failed.Inc()
+	} else {
+		m.metrics.confirmed.Inc()
+	}
+}
+
+// replaceStuck re-sends the latest transaction of nonce with higher gas
+// price, if it is pending for StuckTimeout
+func (m *txManager) replaceStuck(from common.Address, nonce uint64) {
+	m.Lock()
+	p, ok := m.pending[txKey(from, nonce)]
+	signer, has := m.signers[from]
+	if !ok || time.Since(p.lastSent) < m.cfg.StuckTimeout {
+		m.Unlock()
+		return
+	}
+	latest := p.latest()
+	p.lastSent = time.Now()
+	m.Unlock()
+
+	client := getClient(EndPoint)
+	if !has {
+		// re-send it in case it is dropped
+		client.SendTransaction(context.Background(), latest)
+		return
+	}
+
+	price := bumpGasPrice(latest.GasPrice())
+	if price.Cmp(latest.GasPrice()) <= 0 {
+		log.Println("transaction", latest.Hash().Hex(), "is stuck, but gas price reaches max:", price)
+		client.SendTransaction(context.Background(), latest)
+		return
+	}
+
+	var ntx *types.Transaction
+	if latest.To() == nil {
+		ntx = types.NewContractCreation(nonce, latest.Value(), latest.Gas(), price, latest.Data())
+	} else {
+		ntx = types.NewTransaction(nonce, *latest.To(), latest.Value(), latest.Gas(), price, latest.Data())
+	}
+
+	signedTx, err := signer(types.HomesteadSigner{}, from, ntx)
+	if err != nil {
+		log.Println("sign replacement err: ", err)
+		return
+	}
+
+	log.Println("replace stuck transaction", latest.Hash().Hex(), "with", signedTx.Hash().Hex(), "nonce:", nonce, "gasPrice:", price)
+	err = client.SendTransaction(context.Background(), signedTx)
+	if err != nil {
+		log.Println("send replacement err: ", err)
+		return
+	}
+
+	m.Lock()
+	m.metrics.replaced.Inc()
+	m.Unlock()
+}
+
+// watch handles pending transactions which are not checked by callers,
+// e.g. they are loaded from journal or callers give up
+func (m *txManager) watch(ctx context.Context) {
+	ticker := time.NewTicker(txWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkPending()
+		}
+	}
+}
+
+func (m *txManager) checkPending() {
+	var ps []*pendingTx
+	m.Lock()
+	for _, p := range m.pending {
+		if p.waiting == 0 {
+			ps = append(ps, p)
+		}
+	}
+	m.Unlock()
+
+	client := getClient(EndPoint)
+	for _, p := range ps {
+		receipt, tx := m.receipt(p.from, p.latest())
+		if receipt != nil {
+			m.done(p.from, p.nonce, receipt)
+			if r := getTxRecorder(); r != nil {
+				r(p.from, tx, receipt)
+			}
+			continue
+		}
+
+		nonce, err := client.NonceAt(context.Background(), p.from, nil)
+		if err == nil && nonce > p.nonce {
+			// another transaction with the same nonce is mined
+			log.Println("pending transaction", p.latest().Hash().Hex(), "is replaced by others")
+			m.done(p.from, p.nonce, nil)
+			continue
+		}
+
+		m.replaceStuck(p.from, p.nonce)
+	}
+}
+
+type journalTx struct {
+	From  common.Address
+	Nonce uint64
+	Sent  int64
+	Txs   [][]byte // rlp encoded
+}
+
+// save writes pending transactions to journal; m is locked
+func (m *txManager) save() {
+	if m.journal == "" {
+		return
+	}
+
+	jts := make([]journalTx, 0, len(m.pending))
+	for _, p := range m.pending {
+		jt := journalTx{
+			From:  p.from,
+			Nonce: p.nonce,
+			Sent:  p.sent.Unix(),
+		}
+		for _, tx := range p.txs {
+			b, err := rlp.EncodeToBytes(tx)
+			if err != nil {
+				continue
+			}
+			jt.Txs = append(jt.Txs, b)
+		}
+		jts = append(jts, jt)
+	}
+
+	data, err := json.Marshal(jts)
+	if err != nil {
+		log.Println("marshal tx journal err: ", err)
+		return
+	}
+
+	tmp := m.journal + ".tmp"
+	err = ioutil.WriteFile(tmp, data, 0600)
+	if err == nil {
+		err = os.Rename(tmp, m.journal)
+	}
+	if err != nil {
+		log.Println("write tx journal err: ", err)
+	}
+}
+
+// load reads pending transactions from journal, and re-sends them
+func (m *txManager) load(journal string) error {
+	m.Lock()
+	m.journal = journal
+	m.Unlock()
+
+	if journal == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(journal)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var jts []journalTx
+	err = json.Unmarshal(data, &jts)
+	if err != nil {
+		return err
+	}
+
+	var latest []*types.Transaction
+	m.Lock()
+	for _, jt := range jts {
+		p := &pendingTx{
+			from:     jt.From,
+			nonce:    jt.Nonce,
+			sent:     time.Unix(jt.Sent, 0),
+			lastSent: time.Now(),
+		}
+		for _, b := range jt.Txs {
+			tx := new(types.Transaction)
+			if rlp.DecodeBytes(b, tx) == nil {
+				p.txs = append(p.txs, tx)
+			}
+		}
+		if len(p.txs) == 0 {
+			continue
+		}
+
+		key := txKey(p.from, p.nonce)
+		if _, ok := m.pending[key]; !ok {
+			m.metrics.pending.Inc()
+		}
+		m.pending[key] = p
+		latest = append(latest, p.latest())
+	}
+	m.Unlock()
+
+	log.Println("load", len(latest), "pending transactions from journal")
+
+	client := getClient(EndPoint)
+	for _, tx := range latest {
+		// error is ok, e.g. it is known or mined
+		client.SendTransaction(context.Background(), tx)
+	}
+	return nil
+}
+
+// txBackend sends transactions through the tx manager
+type txBackend struct {
+	Backend
+}
+
+// PendingNonceAt returns next nonce of account, considering transactions
+// sent by this node; account is locked until the transaction is sent
+func (b *txBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return txm.nonceAt(ctx, b.Backend, account)
+}
+
+// SuggestGasPrice applies gas strategy to price suggested by chain
+func (b *txBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	price, err := b.Backend.SuggestGasPrice(ctx)
+	if err != nil {
+		price = nil
+	}
+	return txm.gasPrice(price), nil
+}
+
+// EstimateGas applies gas strategy to gas estimated by chain
+func (b *txBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return txm.estimateLimit(b.Backend.EstimateGas(ctx, call)), nil
+}
+
+// SendTransaction sends tx and tracks it until it is mined
+func (b *txBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	err := b.Backend.SendTransaction(ctx, tx)
+	from, serr := types.Sender(types.HomesteadSigner{}, tx)
+	if serr == nil {
+		txm.sent(from, tx, err)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+// +build simchain
+
+package contracts
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/memoio/go-mefs/utils"
+)
+
+func TestConcurrentTx(t *testing.T) {
+	utils.StartLogger()
+
+	adminSk, _ := genAccount(t)
+	userSk, userHex := genAccount(t)
+	userAddr := common.HexToAddress(userHex)
+
+	balance := new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+	sc, err := NewSimulatedChain(adminSk, []common.Address{userAddr}, balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sc.Close()
+
+	// without nonce management, they get the same nonce and retry after minutes
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, errs[i] = NewCManage(userAddr, userSk).DeployIndexer()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	nonce, err := sc.NonceAt(context.Background(), userAddr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != uint64(len(errs)) {
+		t.Fatal("got nonce: ", nonce, ", expected: ", len(errs))
+	}
+}
+
+func TestTxJournal(t *testing.T) {
+	adminSk, _ := genAccount(t)
+	_, userHex := genAccount(t)
+
+	balance := new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+	sc, err := NewSimulatedChain(adminSk, nil, balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sc.Close()
+
+	dir, err := ioutil.TempDir("", "txjournal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	journal := filepath.Join(dir, "txjournal")
+	err = txm.load(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer txm.load("")
+
+	auth, err := makeAuth(adminSk, nil, nil, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := getClient(EndPoint)
+	nonce, err := client.PendingNonceAt(context.Background(), auth.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := types.NewTransaction(nonce, common.HexToAddress(userHex), big.NewInt(1), 21000, big.NewInt(defaultGasPrice), nil)
+	signedTx, err := auth.Signer(types.HomesteadSigner{}, auth.From, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.SendTransaction(context.Background(), signedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// pending tx is restored from journal after restart
+	key := txKey(auth.From, nonce)
+	txm.Lock()
+	delete(txm.pending, key)
+	txm.Unlock()
+
+	err = txm.load(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txm.Lock()
+	p, ok := txm.pending[key]
+	txm.Unlock()
+	if !ok || p.latest().Hash() != signedTx.Hash() {
+		t.Fatal("pending tx is not loaded from journal")
+	}
+
+	// it is mined, and removed from journal
+	txm.checkPending()
+
+	txm.Lock()
+	_, ok = txm.pending[key]
+	txm.Unlock()
+	if ok {
+		t.Fatal("mined tx is still pending")
+	}
+}
@@ -0,0 +1,35 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGasStrategy(t *testing.T) {
+	SetTxConfig(TxConfig{
+		MinGasPrice: big.NewInt(100),
+		MaxGasPrice: big.NewInt(1000),
+		MaxGasLimit: 5000000,
+		PriceBump:   50,
+	})
+	defer SetTxConfig(TxConfig{})
+
+	if p := txm.gasPrice(nil); p.Int64() != 100 {
+		t.Fatal("got gas price: ", p, ", expected: 100")
+	}
+	if p := txm.gasPrice(big.NewInt(2000)); p.Int64() != 1000 {
+		t.Fatal("got gas price: ", p, ", expected: 1000")
+	}
+	if p := bumpGasPrice(big.NewInt(400)); p.Int64() != 600 {
+		t.Fatal("got bumped gas price: ", p, ", expected: 600")
+	}
+	if p := bumpGasPrice(big.NewInt(900)); p.Int64() != 1000 {
+		t.Fatal("got bumped gas price: ", p, ", expected: 1000")
+	}
+	if l := txm.gasLimit(defaultGasLimit); l != 5000000 {
+		t.Fatal("got gas limit: ", l, ", expected: 5000000")
+	}
+	if l := txm.estimateLimit(100000, nil); l != 120000 {
+		t.Fatal("got estimated gas limit: ", l, ", expected: 120000")
+	}
+}
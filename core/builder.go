@@ -170,7 +170,7 @@ func setupNode(ctx context.Context, n *MefsNode, cfg *BuildCfg) error {
 		}
 	}
 
-	n.Data = data.New(n.Identity.Pretty(), n.Blockstore, n.Repo.Datastore(), n.PeerHost, n.Routing)
+	n.Data = data.New(n.Identity.Pretty(), n.Blockstore, n.Repo.Datastore(), n.PeerHost, n.Routing, rcfg.ReadCache)
 
 	return nil
 }
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+	"github.com/memoio/go-mefs/core/commands/cmdenv"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils/address"
+)
+
+const billDate = "2006-01-02"
+
+var BillingCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "billing of local accounts",
+		ShortDescription: `
+'billing' shows deposits, spacetime pays, channel pays, pledges and gas
+recorded by this node.
+`,
+	},
+
+	Subcommands: map[string]*cmds.Command{
+		"report": billingReportCmd,
+	},
+}
+
+var billingReportCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "report billing entries of account between dates",
+		ShortDescription: `
+'billing report' lists income and expense of account, with totals of each kind;
+from and to are local dates like 2020-01-02, both are included.
+`,
+	},
+
+	Arguments: []cmds.Argument{},
+	Options: []cmds.Option{
+		cmds.StringOption("address", "addr", "The account address or id, default is this node").WithDefault(""),
+		cmds.StringOption("from", "Start date, format is 2006-01-02").WithDefault(""),
+		cmds.StringOption("to", "End date, format is 2006-01-02, default is today").WithDefault(""),
+		cmds.StringOption("format", "Output format, csv or json").WithDefault("csv"),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		n, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+
+		owner := n.Identity.Pretty()
+		addr := req.Options["address"].(string)
+		if addr != "" {
+			owner = addr
+			if len(addr) == 42 {
+				owner, err = address.GetIDFromAddress(addr)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		var start int64
+		from := req.Options["from"].(string)
+		if from != "" {
+			t, err := time.ParseInLocation(billDate, from, time.Local)
+			if err != nil {
+				return err
+			}
+			start = t.Unix()
+		}
+
+		end := time.Now().Unix()
+		to := req.Options["to"].(string)
+		if to != "" {
+			t, err := time.ParseInLocation(billDate, to, time.Local)
+			if err != nil {
+				return err
+			}
+			end = t.AddDate(0, 0, 1).Unix() - 1
+		}
+
+		if start > end {
+			return role.ErrInvalidInput
+		}
+
+		bes, err := role.GetBills(owner, start, end)
+		if err != nil {
+			return err
+		}
+
+		out, err := role.FormatBills(owner, start, end, bes, req.Options["format"].(string))
+		if err != nil {
+			return err
+		}
+
+		list := &StringList{
+			ChildLists: []string{out},
+		}
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, fl *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", fl)
+			return err
+		}),
+	},
+}
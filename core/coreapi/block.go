@@ -11,6 +11,7 @@ import (
 	"github.com/memoio/go-mefs/role"
 	blocks "github.com/memoio/go-mefs/source/go-block-format"
 	cid "github.com/memoio/go-mefs/source/go-cid"
+	"github.com/memoio/go-mefs/userNode/user"
 )
 
 type BlockAPI CoreAPI
@@ -64,7 +65,22 @@ func (api *BlockAPI) Get(ctx context.Context, p string) (io.Reader, error) {
 	return bytes.NewReader(b.RawData()), nil
 }
 
+// GetFrom gets block p from peerid; users pay for it by channel as downloading
+// does, other roles can only get it with test sign in test build
 func (api *BlockAPI) GetFrom(ctx context.Context, p string, peerid string) (io.Reader, error) {
+	userIns, ok := api.node.Inst.(*user.Info)
+	if ok {
+		data, err := userIns.GetBlockFrom(ctx, p, peerid)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(data), nil
+	}
+
+	if !role.AllowTestSign {
+		return nil, role.ErrNotUser
+	}
+
 	sig, err := role.BuildSignMessage()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,199 @@
+package pdp
+
+import (
+	"encoding/binary"
+
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+	"golang.org/x/crypto/blake2s"
+)
+
+// 多个挑战（可属于不同用户）的证明聚合为一个Delta:
+// Delta = sum(c_i * delta_i)，c_i由所有psi_i, y_i哈希得到；
+// 每个证明保留自己的psi_i, y_i，验证时所有配对在一次MillerLoop中计算：
+// prod(e(c_i*(H_i - r_i*psi_i + y_i*g1), pk_i) * e(c_i*psi_i, zeta_i)) * e(-Delta, g2) == 1
+
+// batchWeights returns c_i of proofs
+func batchWeights(proofs []*ProofV1) []bls.Fr {
+	h, _ := blake2s.New256(nil)
+	for _, pf := range proofs {
+		h.Write(pf.Psi)
+		h.Write(pf.Y)
+	}
+	seed := h.Sum(nil)
+
+	weights := make([]bls.Fr, len(proofs))
+	buf := make([]byte, len(seed)+8)
+	copy(buf, seed)
+	for i := range proofs {
+		binary.BigEndian.PutUint64(buf[len(seed):], uint64(i))
+		weights[i].SetHashOf(buf)
+	}
+	return weights
+}
+
+// AggregateProofsV1 replaces Delta of each proof with the aggregated one;
+// proofs should be verified by BatchVerifierV1 in the same order
+func AggregateProofsV1(proofs []*ProofV1) error {
+	if len(proofs) == 0 {
+		return ErrNumOutOfRange
+	}
+
+	weights := batchWeights(proofs)
+
+	var delta, t bls.G1
+	delta.Clear()
+	for i, pf := range proofs {
+		err := t.Deserialize(pf.Delta)
+		if err != nil {
+			return err
+		}
+		bls.G1Mul(&t, &t, &weights[i])
+		bls.G1Add(&delta, &delta, &t)
+	}
+
+	agg := delta.Serialize()
+	for _, pf := range proofs {
+		pf.Delta = agg
+	}
+	return nil
+}
+
+// BatchVerifierV1 collects proofs with aggregated Delta and verifies them in one pass
+type BatchVerifierV1 struct {
+	delta  []byte
+	vks    []*VerifyKeyV1
+	chals  []Challenge
+	proofs []*ProofV1
+}
+
+// NewBatchVerifierV1 creates an empty batch verifier
+func NewBatchVerifierV1() *BatchVerifierV1 {
+	return &BatchVerifierV1{}
+}
+
+// Add returns a verify key whose VerifyProof puts proof into the batch,
+// the real result is returned by Result
+func (bv *BatchVerifierV1) Add(vk VerifyKey) (VerifyKey, error) {
+	v, ok := vk.(*VerifyKeyV1)
+	if !ok || v == nil {
+		return nil, ErrKeyIsNil
+	}
+	return &batchVerifyKeyV1{VerifyKeyV1: v, bv: bv}, nil
+}
+
+// Len returns number of proofs in the batch
+func (bv *BatchVerifierV1) Len() int {
+	return len(bv.proofs)
+}
+
+// Result verifies all proofs in the batch
+func (bv *BatchVerifierV1) Result() (bool, error) {
+	if len(bv.proofs) == 0 {
+		return false, ErrNumOutOfRange
+	}
+
+	weights := batchWeights(bv.proofs)
+
+	g1s := make([]bls.G1, 0, 2*len(bv.proofs)+1)
+	g2s := make([]bls.G2, 0, 2*len(bv.proofs)+1)
+
+	var psi, x, tempG1 bls.G1
+	var y, tempFr, HWi bls.Fr
+	for i, pf := range bv.proofs {
+		err := psi.Deserialize(pf.Psi)
+		if err != nil {
+			return false, err
+		}
+
+		err = y.Deserialize(pf.Y)
+		if err != nil {
+			return false, err
+		}
+
+		// x = H(Wi) - r*psi + y*g1
+		HWi.Clear()
+		for _, index := range bv.chals[i].GetIndices() {
+			h := blake2s.Sum256([]byte(index))
+			tempFr.SetLittleEndian(h[:])
+			bls.FrAdd(&HWi, &HWi, &tempFr)
+		}
+		bls.FrAdd(&HWi, &HWi, &y)
+		bls.G1Mul(&x, &GenG1, &HWi)
+
+		tempFr.SetInt64(bv.chals[i].GetSeed())
+		bls.G1Mul(&tempG1, &psi, &tempFr)
+		bls.G1Sub(&x, &x, &tempG1)
+
+		bls.G1Mul(&x, &x, &weights[i])
+		bls.G1Mul(&tempG1, &psi, &weights[i])
+
+		g1s = append(g1s, x, tempG1)
+		g2s = append(g2s, bv.vks[i].BlsPk, bv.vks[i].Zeta)
+	}
+
+	var delta bls.G1
+	err := delta.Deserialize(bv.delta)
+	if err != nil {
+		return false, err
+	}
+	bls.G1Neg(&delta, &delta)
+	g1s = append(g1s, delta)
+	g2s = append(g2s, GenG2)
+
+	var e bls.GT
+	bls.MillerLoopVec(&e, g1s, g2s)
+	bls.FinalExp(&e, &e)
+
+	return e.IsOne(), nil
+}
+
+// batchVerifyKeyV1 is verify key of one proof in batch
+type batchVerifyKeyV1 struct {
+	*VerifyKeyV1
+	bv *BatchVerifierV1
+}
+
+// VerifyProof checks format of proof and puts it into the batch
+func (vk *batchVerifyKeyV1) VerifyProof(chal Challenge, proof Proof) (bool, error) {
+	pf, ok := proof.(*ProofV1)
+	if !ok {
+		return false, nil
+	}
+
+	var psi, delta bls.G1
+	err := psi.Deserialize(pf.Psi)
+	if err != nil {
+		return false, err
+	}
+
+	if psi.IsZero() {
+		return false, nil
+	}
+
+	err = delta.Deserialize(pf.Delta)
+	if err != nil {
+		return false, err
+	}
+
+	if delta.IsZero() {
+		return false, nil
+	}
+
+	var y bls.Fr
+	err = y.Deserialize(pf.Y)
+	if err != nil {
+		return false, err
+	}
+
+	bv := vk.bv
+	if bv.delta == nil {
+		bv.delta = pf.Delta
+	} else if string(bv.delta) != string(pf.Delta) {
+		return false, nil
+	}
+
+	bv.vks = append(bv.vks, vk.VerifyKeyV1)
+	bv.chals = append(bv.chals, chal)
+	bv.proofs = append(bv.proofs, pf)
+	return true, nil
+}
@@ -0,0 +1,175 @@
+package pdp
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	batchGroups  = 16 // users on one provider
+	batchSegNum  = 4  // challenged segments of each user
+	batchSegSize = 4 * 1024
+)
+
+// genBatchProofs gens proofs of users, each user has its own key set
+func genBatchProofs(groups int) ([]VerifyKey, []*ChallengeV1, []*ProofV1, error) {
+	vks := make([]VerifyKey, groups)
+	chals := make([]*ChallengeV1, groups)
+	proofs := make([]*ProofV1, groups)
+
+	rand.Seed(time.Now().UnixNano())
+	for g := 0; g < groups; g++ {
+		data := make([]byte, batchSegNum*batchSegSize)
+		fillRandom(data)
+
+		keySet, err := GenKeySetV1WithSeed(data[:32], SCount)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		segments := make([][]byte, batchSegNum)
+		tags := make([][]byte, batchSegNum)
+		blocks := make([]string, batchSegNum)
+		for i := 0; i < batchSegNum; i++ {
+			segments[i] = data[batchSegSize*i : batchSegSize*(i+1)]
+			blocks[i] = strconv.Itoa(g) + "_" + strconv.Itoa(i)
+			tags[i], err = keySet.GenTag([]byte(blocks[i]), segments[i], 0, 32, true)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
+		chals[g] = &ChallengeV1{
+			R:       time.Now().Unix() + int64(g),
+			Indices: blocks,
+		}
+
+		pf, err := keySet.Pk.GenProof(chals[g], segments, tags, 32)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		vks[g] = keySet.VerifyKey()
+		proofs[g] = pf.(*ProofV1)
+	}
+
+	return vks, chals, proofs, nil
+}
+
+func verifyBatch(vks []VerifyKey, chals []*ChallengeV1, proofs []*ProofV1) (bool, error) {
+	bv := NewBatchVerifierV1()
+	for i := range proofs {
+		vk, err := bv.Add(vks[i])
+		if err != nil {
+			return false, err
+		}
+		ok, err := vk.VerifyProof(chals[i], proofs[i])
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return bv.Result()
+}
+
+func TestBatchProofV1(t *testing.T) {
+	err := Init(BLS12_381)
+	if err != nil {
+		panic(err)
+	}
+
+	vks, chals, proofs, err := genBatchProofs(batchGroups)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = AggregateProofsV1(proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := verifyBatch(vks, chals, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("batch verification failed")
+	}
+
+	// proofs of one user are in wrong order
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	res, err = verifyBatch(vks, chals, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("batch with swapped proofs is verified")
+	}
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+
+	// challenge of one user is changed
+	chals[3].R++
+	res, err = verifyBatch(vks, chals, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("batch with wrong challenge is verified")
+	}
+}
+
+func BenchmarkVerifyProofsV1(b *testing.B) {
+	err := Init(BLS12_381)
+	if err != nil {
+		panic(err)
+	}
+
+	vks, chals, proofs, err := genBatchProofs(batchGroups)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// -------------- keeper verifies proofs one by one --------------- //
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for g := range proofs {
+			result, err := vks[g].VerifyProof(chals[g], proofs[g])
+			if err != nil {
+				b.Fatal(err)
+			}
+			if !result {
+				b.Fatal("Verificaition failed!")
+			}
+		}
+	}
+}
+
+func BenchmarkVerifyBatchProofV1(b *testing.B) {
+	err := Init(BLS12_381)
+	if err != nil {
+		panic(err)
+	}
+
+	vks, chals, proofs, err := genBatchProofs(batchGroups)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	err = AggregateProofsV1(proofs)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// -------------- keeper verifies aggregated proofs in one pass --------------- //
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := verifyBatch(vks, chals, proofs)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if !result {
+			b.Fatal("Verificaition failed!")
+		}
+	}
+}
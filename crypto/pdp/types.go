@@ -26,6 +26,10 @@ const (
 	BLS   = 2
 	PDPV0 = 3
 	PDPV1 = 4
+	// PDPV0 tags over replicas sealed by latin square scrambling
+	PDPV0Latin = 5
+	// PDPV0 tags over replicas sealed by vdf encoding
+	PDPV0VDF = 6
 )
 
 var GenG1 G1
@@ -42,10 +46,12 @@ var GtSize int
 
 // TagMap maps a hash code to it's default length
 var TagMap = map[int]int{
-	CRC32: 4,
-	BLS:   32,
-	PDPV0: 48,
-	PDPV1: 48,
+	CRC32:      4,
+	BLS:        32,
+	PDPV0:      48,
+	PDPV1:      48,
+	PDPV0Latin: 48,
+	PDPV0VDF:   48,
 }
 
 // ChallengeV1 gives
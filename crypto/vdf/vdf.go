@@ -1,10 +1,16 @@
 package vdf
 
 import (
-	"fmt"
+	"errors"
 	"math/big"
 )
 
+// SealWordSize is the length of word sealed by Seal
+const SealWordSize = 16
+
+// ErrInvalidSealInput is returned when codec or data cannot be used to seal
+var ErrInvalidSealInput = errors.New("vdf codec should be created with t = 127 and data length should be multiple of 16")
+
 /*
 const (
 	T uint = 3201
@@ -34,7 +40,6 @@ func NewVDF(t uint) *VDFCodec {
 	vdfCodec.Mod = big.NewInt(1).Lsh(big.NewInt(1), t+1)
 	r := big.NewInt(1).Lsh(big.NewInt(1), t-1)
 	vdfCodec.V = setr(r)
-	fmt.Println("vdfCodec.V=", vdfCodec.V)
 	return vdfCodec
 
 }
@@ -158,3 +163,69 @@ func (codec *VDFCodec) decode(crypto []byte, key *big.Int, round int) []byte {
 	}
 	return out.Bytes()
 }
+
+//Seal 原地编码data，每16byte为一组，结果仍为16byte；codec需以T=127创建
+//编码较慢而解码很快，用于副本封装
+func (codec *VDFCodec) Seal(data, key []byte, round int) error {
+	if codec.T != 127 || len(data)%SealWordSize != 0 {
+		return ErrInvalidSealInput
+	}
+
+	k := new(big.Int).SetBytes(key)
+	out := new(big.Int)
+	for i := 0; i < len(data); i += SealWordSize {
+		word := data[i : i+SealWordSize]
+		out.SetBytes(word)
+		for j := 0; j < round; j++ {
+			out.Add(out, k)
+			even := out.Bit(0) == 0
+			if even { //保证结果为奇数
+				out.Add(out, one)
+			}
+			out.Exp(out, codec.V, codec.Mod)
+			if even {
+				out.Add(out, one)
+			}
+			out.Mod(out, codec.Mod)
+		}
+		putWord(word, out)
+	}
+	return nil
+}
+
+//Unseal 原地解码Seal的结果
+func (codec *VDFCodec) Unseal(data, key []byte, round int) error {
+	if codec.T != 127 || len(data)%SealWordSize != 0 {
+		return ErrInvalidSealInput
+	}
+
+	k := new(big.Int).SetBytes(key)
+	out := new(big.Int)
+	for i := 0; i < len(data); i += SealWordSize {
+		word := data[i : i+SealWordSize]
+		out.SetBytes(word)
+		for j := 0; j < round; j++ {
+			even := out.Bit(0) == 0
+			if even {
+				out.Sub(out, one)
+			}
+			out.Exp(out, five, codec.Mod)
+			out.Sub(out, k)
+			if even {
+				out.Sub(out, one)
+			}
+			out.Mod(out, codec.Mod)
+		}
+		putWord(word, out)
+	}
+	return nil
+}
+
+// putWord writes x into word as big endian with leading zeros
+func putWord(word []byte, x *big.Int) {
+	b := x.Bytes()
+	for i := range word[:len(word)-len(b)] {
+		word[i] = 0
+	}
+	copy(word[len(word)-len(b):], b)
+}
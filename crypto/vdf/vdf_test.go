@@ -1,6 +1,7 @@
 package vdf
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -131,3 +132,67 @@ func TestVdf(t *testing.T) {
 
 	fmt.Println("Decoded Data =", de)
 }
+
+func TestSeal(t *testing.T) {
+	vdf := NewVDF(127)
+
+	sourceData := RandBytesMaskImprSrc(4096)
+	// include words which seal to boundary values
+	for i := 0; i < SealWordSize; i++ {
+		sourceData[i] = 0
+		sourceData[SealWordSize+i] = 0xff
+	}
+	key := RandBytesMaskImprSrc(32)
+
+	data := make([]byte, len(sourceData))
+	copy(data, sourceData)
+
+	err := vdf.Seal(data, key, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(data, sourceData) {
+		t.Fatal("sealed data is same as source data")
+	}
+
+	err = vdf.Unseal(data, key, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, sourceData) {
+		t.Fatal("unsealed data is not same as source data")
+	}
+
+	err = vdf.Seal(data[:15], key, 1)
+	if err != ErrInvalidSealInput {
+		t.Fatal("seal short data should fail")
+	}
+}
+
+// BenchmarkSeal seals a 32KB segment in one round
+func BenchmarkSeal(b *testing.B) {
+	vdf := NewVDF(127)
+	data := RandBytesMaskImprSrc(32 * 1024)
+	key := RandBytesMaskImprSrc(32)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vdf.Seal(data, key, 1)
+	}
+}
+
+// BenchmarkUnseal unseals a 32KB segment in one round
+func BenchmarkUnseal(b *testing.B) {
+	vdf := NewVDF(127)
+	data := RandBytesMaskImprSrc(32 * 1024)
+	key := RandBytesMaskImprSrc(32)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vdf.Unseal(data, key, 1)
+	}
+}
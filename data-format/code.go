@@ -2,9 +2,11 @@ package dataformat
 
 import (
 	"errors"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	proto "github.com/gogo/protobuf/proto"
 	"github.com/memoio/go-mefs/crypto/pdp"
@@ -20,12 +22,20 @@ type DataCoder struct {
 	BlsKey     pdp.KeySet
 	Repair     bool
 	RLength    int // recover how long
+	TagWorkers int // goroutines generating tags in Encode, number of cpus if not set
+	// SealSegment seals segment segIndex of replica chunkID of MulPolicy in
+	// Encode, instead of seal of tag flag; post data is sealed by it
+	SealSegment func(seg []byte, chunkID, segIndex int) error
 	blockCount int
 	tagCount   int
 	tagSize    int
 	segSize    int
 	fieldSize  int
 	prefixSize int
+
+	// computed once in PreCompute and shared across stripes
+	enc  reedsolomon.Encoder // data
+	encP reedsolomon.Encoder // tags
 }
 
 // NewDataCoder 构建一个dataformat配置
@@ -39,6 +49,8 @@ func NewDataCoder(keyset pdp.KeySet, policy, dataCount, parityCount, version, ta
 	case MulPolicy:
 		parityCount = dataCount + parityCount - 1
 		dataCount = 1
+	case LrcPolicy:
+		return nil, ErrWrongPolicy // needs local count, use NewDataCoderWithBopts
 	default:
 		return nil, ErrWrongPolicy
 	}
@@ -106,9 +118,18 @@ func (d *DataCoder) PreCompute() error {
 
 	d.tagSize = int(s)
 	d.segSize = int(d.Prefix.Bopts.SegmentSize)
-	d.fieldSize = d.segSize + d.tagSize*d.tagCount
+	d.fieldSize = d.segSize + d.tagSize*TagCount(d.Prefix.Bopts)
 
-	return nil
+	if d.Prefix.Bopts.Policy == LrcPolicy {
+		err := checkLrc(d.Prefix.Bopts)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.enc, d.encP = nil, nil
+
+	return d.checkSeal()
 }
 
 func (d *DataCoder) Encode(data []byte, ncidPrefix string, start int) ([][]byte, int, error) {
@@ -117,7 +138,6 @@ func (d *DataCoder) Encode(data []byte, ncidPrefix string, start int) ([][]byte,
 	}
 
 	dc := int(d.Prefix.Bopts.DataCount)
-	pc := int(d.Prefix.Bopts.ParityCount)
 
 	endSegment := 1 + (len(data)-1)/(d.segSize*dc)
 
@@ -139,85 +159,282 @@ func (d *DataCoder) Encode(data []byte, ncidPrefix string, start int) ([][]byte,
 	dataGroup := make([][]byte, d.blockCount)
 	// 生成taggroup装一组的tag+tagP
 	tagGroup := make([][]byte, d.blockCount*d.tagCount)
+	fields := make([][]byte, d.blockCount)
 
-	enc, err := reedsolomon.New(int(dc), int(pc))
+	enc, encP, err := d.newEncoders()
 	if err != nil {
 		return nil, 0, err
 	}
 
-	encP, err := reedsolomon.New(d.blockCount, d.blockCount*(d.tagCount-1))
+	// data of all fields first, then their tags in parallel
+	jobs := make([]tagJob, 0, endSegment*d.blockCount)
+	for i := start; i < start+endSegment && len(data) != 0; i++ {
+		beginOffset := preLen + (i-start)*d.fieldSize
+		for j := 0; j < d.blockCount; j++ {
+			fields[j] = stripe[j][beginOffset : beginOffset+d.fieldSize]
+		}
+
+		data, err = d.encodeData(enc, fields, dataGroup, data, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		jobs = d.appendTagJobs(jobs, fields, ncidPrefix, i)
+		// 生成Field结构，此时beginOffset为下一个Field的起始偏移
+	}
+
+	err = d.genTags(jobs)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	for i := start; i < start+endSegment && len(data) != 0; i++ {
-		beginOffset := preLen + (i-start)*d.fieldSize
-		for j := 0; j < dc; j++ {
-			dataGroup[j] = stripe[j][beginOffset : beginOffset+d.segSize]
-			// 填充数据
-			if len(data) < d.segSize {
-				copy(dataGroup[j], data)
-				data = data[:0]
-			} else {
-				copy(dataGroup[j], data[:d.segSize])
-				data = data[d.segSize:]
-			}
+	for i := 0; i < endSegment; i++ {
+		beginOffset := preLen + i*d.fieldSize
+		for j := 0; j < d.blockCount; j++ {
+			fields[j] = stripe[j][beginOffset : beginOffset+d.fieldSize]
 		}
 
-		switch d.Prefix.Bopts.Policy {
-		case MulPolicy:
-			for j := dc; j < d.blockCount; j++ {
-				dataGroup[j] = stripe[j][beginOffset : beginOffset+d.segSize]
-				res := copy(dataGroup[j], dataGroup[0])
-				if res != d.segSize {
-					utils.MLogger.Error("copied: ", res, " is less than: ", d.segSize)
-				}
+		err = d.encodeTags(encP, fields, tagGroup)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	// endoffset
+	return stripe, start + endSegment, nil
+}
+
+// newEncoders returns encoder of data and encoder of tags
+func (d *DataCoder) newEncoders() (reedsolomon.Encoder, reedsolomon.Encoder, error) {
+	if d.enc != nil && d.encP != nil {
+		return d.enc, d.encP, nil
+	}
+
+	dc := int(d.Prefix.Bopts.DataCount)
+	gc := int(d.Prefix.Bopts.ParityCount)
+	if d.Prefix.Bopts.Policy == LrcPolicy {
+		gc -= int(d.Prefix.Bopts.LocalCount)
+	}
+
+	enc, err := reedsolomon.New(dc, gc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encP, err := reedsolomon.New(d.blockCount, d.blockCount*(d.tagCount-1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d.enc, d.encP = enc, encP
+	return enc, encP, nil
+}
+
+// encodeField fills field i of each chunk with data and its tags, fields[j] is
+// field of chunk j; it returns data left
+func (d *DataCoder) encodeField(enc, encP reedsolomon.Encoder, fields, dataGroup, tagGroup [][]byte, data []byte, ncidPrefix string, i int) ([]byte, error) {
+	data, err := d.encodeData(enc, fields, dataGroup, data, i)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.genTags(d.appendTagJobs(nil, fields, ncidPrefix, i))
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.encodeTags(encP, fields, tagGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// encodeData fills segments i of fields with data and its parities; it returns
+// data left
+func (d *DataCoder) encodeData(enc reedsolomon.Encoder, fields, dataGroup [][]byte, data []byte, i int) ([]byte, error) {
+	dc := int(d.Prefix.Bopts.DataCount)
+	for j := 0; j < dc; j++ {
+		dataGroup[j] = fields[j][:d.segSize]
+		// 填充数据
+		if len(data) < d.segSize {
+			copy(dataGroup[j], data)
+			data = data[:0]
+		} else {
+			copy(dataGroup[j], data[:d.segSize])
+			data = data[d.segSize:]
+		}
+	}
+
+	var err error
+	switch d.Prefix.Bopts.Policy {
+	case MulPolicy:
+		for j := dc; j < d.blockCount; j++ {
+			dataGroup[j] = fields[j][:d.segSize]
+			res := copy(dataGroup[j], dataGroup[0])
+			if res != d.segSize {
+				utils.MLogger.Error("copied: ", res, " is less than: ", d.segSize)
 			}
-		case RsPolicy:
-			for j := dc; j < d.blockCount; j++ {
-				dataGroup[j] = stripe[j][beginOffset : beginOffset+d.segSize]
+		}
+
+		// seal each replica after copy, replicas are sealed in parallel
+		if IsSealed(d.Prefix.Bopts.TagFlag) || d.SealSegment != nil {
+			errs := make([]error, d.blockCount)
+			var wg sync.WaitGroup
+			for j := 0; j < d.blockCount; j++ {
+				wg.Add(1)
+				go func(j int) {
+					defer wg.Done()
+					if d.SealSegment != nil {
+						errs[j] = d.SealSegment(dataGroup[j], j, i)
+					} else {
+						errs[j] = d.seal(dataGroup[j], j)
+					}
+				}(j)
 			}
-			err = enc.Encode(dataGroup)
-			if err != nil {
-				return nil, 0, err
+			wg.Wait()
+
+			for _, err := range errs {
+				if err != nil {
+					return nil, err
+				}
 			}
-		default:
-			return nil, 0, ErrWrongPolicy
 		}
+	case RsPolicy:
+		for j := dc; j < d.blockCount; j++ {
+			dataGroup[j] = fields[j][:d.segSize]
+		}
+		err = enc.Encode(dataGroup)
+		if err != nil {
+			return nil, err
+		}
+	case LrcPolicy:
+		for j := dc; j < d.blockCount; j++ {
+			dataGroup[j] = fields[j][:d.segSize]
+		}
+		err = d.encodeLrc(enc, dataGroup)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrWrongPolicy
+	}
 
-		var res strings.Builder
-		for j := 0; j < d.blockCount; j++ {
-			tagGroup[j] = stripe[j][beginOffset+d.segSize : beginOffset+d.segSize+d.tagSize]
-			// 生成tag并装进taggroup，index为peerid_bucketid_stripeid_blockid_offsetid
-			res.Reset()
-			res.WriteString(ncidPrefix)
-			res.WriteString(metainfo.BlockDelimiter)
-			res.WriteString(strconv.Itoa(j))
-			res.WriteString(metainfo.BlockDelimiter)
-			res.WriteString(strconv.Itoa(i))
-
-			tag, err := d.GenTagForSegment([]byte(res.String()), dataGroup[j])
+	return data, nil
+}
+
+// tagJob is a segment whose tag is written into tag
+type tagJob struct {
+	index   string
+	segment []byte
+	tag     []byte
+}
+
+// appendTagJobs appends tag jobs of field i of each chunk
+func (d *DataCoder) appendTagJobs(jobs []tagJob, fields [][]byte, ncidPrefix string, i int) []tagJob {
+	var res strings.Builder
+	for j := 0; j < d.blockCount; j++ {
+		// index为peerid_bucketid_stripeid_blockid_offsetid
+		res.Reset()
+		res.WriteString(ncidPrefix)
+		res.WriteString(metainfo.BlockDelimiter)
+		res.WriteString(strconv.Itoa(j))
+		res.WriteString(metainfo.BlockDelimiter)
+		res.WriteString(strconv.Itoa(i))
+
+		jobs = append(jobs, tagJob{
+			index:   res.String(),
+			segment: fields[j][:d.segSize],
+			tag:     fields[j][d.segSize : d.segSize+d.tagSize],
+		})
+	}
+	return jobs
+}
+
+func (d *DataCoder) tagWorkers() int {
+	if d.TagWorkers > 0 {
+		return d.TagWorkers
+	}
+	return runtime.NumCPU()
+}
+
+// genTags generates tags of jobs by TagWorkers goroutines
+func (d *DataCoder) genTags(jobs []tagJob) error {
+	genTag := func(job *tagJob) error {
+		tag, err := d.GenTagForSegment([]byte(job.index), job.segment)
+		if err != nil {
+			utils.MLogger.Error("Gen tag for: ", job.index, " fails: ", err)
+			return err
+		}
+		copy(job.tag, tag)
+		return nil
+	}
+
+	workers := d.tagWorkers()
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	if workers <= 1 {
+		for k := range jobs {
+			err := genTag(&jobs[k])
 			if err != nil {
-				utils.MLogger.Error("Gen tag for: ", res.String(), " fails: ", err)
-				return nil, 0, err
+				return err
 			}
-			copy(tagGroup[j], tag)
 		}
+		return nil
+	}
 
-		for j := 1; j < d.tagCount; j++ {
-			for k := 0; k < d.blockCount; k++ {
-				tagGroup[j*d.blockCount+k] = stripe[k][beginOffset+d.segSize+j*d.tagSize : beginOffset+d.segSize+(j+1)*d.tagSize]
+	var wg sync.WaitGroup
+	var once sync.Once
+	var gerr error
+	ch := make(chan int, len(jobs))
+	for k := range jobs {
+		ch <- k
+	}
+	close(ch)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range ch {
+				err := genTag(&jobs[k])
+				if err != nil {
+					once.Do(func() {
+						gerr = err
+					})
+					return
+				}
 			}
+		}()
+	}
+	wg.Wait()
+
+	return gerr
+}
+
+// encodeTags fills tag parities of fields, whose tags are generated
+func (d *DataCoder) encodeTags(encP reedsolomon.Encoder, fields, tagGroup [][]byte) error {
+	for j := 0; j < d.blockCount; j++ {
+		tagGroup[j] = fields[j][d.segSize : d.segSize+d.tagSize]
+	}
+
+	for j := 1; j < d.tagCount; j++ {
+		for k := 0; k < d.blockCount; k++ {
+			tagGroup[j*d.blockCount+k] = fields[k][d.segSize+j*d.tagSize : d.segSize+(j+1)*d.tagSize]
 		}
-		// 生成tag+tagP的taggroup格式
-		err = encP.Encode(tagGroup)
-		if err != nil {
-			return nil, 0, err
-		}
-		// 生成Field结构，此时beginOffset为下一个Field的起始偏移
 	}
-	// endoffset
-	return stripe, start + endSegment, nil
+	// 生成tag+tagP的taggroup格式
+	err := encP.Encode(tagGroup)
+	if err != nil {
+		return err
+	}
+
+	if d.Prefix.Bopts.Policy == LrcPolicy {
+		d.encodeLocalTags(fields, 0)
+	}
+
+	return nil
 }
 
 func (d *DataCoder) Decode(stripe [][]byte, start, length int) ([]byte, error) {
@@ -245,11 +462,21 @@ func (d *DataCoder) Decode(stripe [][]byte, start, length int) ([]byte, error) {
 		segLength = 1 + (minLen-d.prefixSize-1)/d.fieldSize - segStart
 	}
 
+	sealed := IsSealed(d.Prefix.Bopts.TagFlag)
 	res := make([]byte, 0, segLength*int(d.Prefix.Bopts.DataCount)*d.segSize)
 	// 根据offset从每个块中提取Field的data
 	for i := segStart; i < segStart+segLength; i++ {
 		for j := 0; j < int(d.Prefix.Bopts.DataCount); j++ {
+			if len(data[j]) < d.prefixSize+i*d.fieldSize+d.segSize {
+				return nil, ErrRepairCrash
+			}
 			res = append(res, data[j][d.prefixSize+i*d.fieldSize:d.prefixSize+i*d.fieldSize+d.segSize]...)
+			if sealed {
+				err = d.unseal(res[len(res)-d.segSize:], j)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 
@@ -320,6 +547,10 @@ func (d *DataCoder) recover(stripe [][]byte) ([][]byte, int, error) {
 }
 
 func (d *DataCoder) recoverField(stripe [][]byte) ([][]byte, error) {
+	if d.Prefix.Bopts.Policy == LrcPolicy {
+		return d.recoverLrcField(stripe)
+	}
+
 	tmpData := make([][]byte, d.blockCount)
 	// 解析出data、tag、tagP
 	for i := 0; i < d.blockCount; i++ {
@@ -401,10 +632,31 @@ func (d *DataCoder) recoverData(data [][]byte, dc, pc int) ([][]byte, error) {
 			return nil, errors.New("no available")
 		}
 
+		// unseal the available replica, then reseal it for lost ones
+		sealed := IsSealed(d.Prefix.Bopts.TagFlag)
+		var raw []byte
+		if sealed {
+			raw = make([]byte, len(data[i]))
+			copy(raw, data[i])
+			err := d.unseal(raw, i)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		for j := 0; j < d.blockCount; j++ {
 			if data[j] == nil {
 				data[j] = make([]byte, 0)
-				data[j] = append(data[j], data[i]...)
+				if !sealed {
+					data[j] = append(data[j], data[i]...)
+					continue
+				}
+
+				data[j] = append(data[j], raw...)
+				err := d.seal(data[j], j)
+				if err != nil {
+					return nil, err
+				}
 			}
 		}
 		return data, nil
@@ -478,17 +730,27 @@ func decodeStripe(data [][]byte) (*mpb.BlockOptions, int, int, error) {
 		return nil, 0, 0, errors.New("no available block")
 	}
 
-	if prefix != nil && (int(prefix.Bopts.DataCount) > avaNum || int(prefix.Bopts.DataCount) > len(lengths)) {
+	if prefix == nil {
+		return nil, 0, 0, ErrDataBroken
+	}
+
+	need := int(prefix.Bopts.DataCount)
+	// lrc repairs a chunk from its local group, which may be less than data count
+	if prefix.Bopts.Policy == LrcPolicy && avaNum < need {
+		need = avaNum
+	}
+
+	if need > avaNum || need > len(lengths) {
 		utils.MLogger.Error("repair crash, need data count: ", prefix.Bopts.DataCount, ", but got avaNum: ", avaNum)
 		return nil, 0, 0, ErrRepairCrash
 	}
 
 	sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
 
-	if lengths[prefix.Bopts.DataCount-1] <= 0 {
+	if lengths[need-1] <= 0 {
 		utils.MLogger.Error("repair crash after sort: need count: ", prefix.Bopts.DataCount, ", but got avaNum again: ", avaNum)
 		return nil, 0, 0, ErrRepairCrash
 	}
 
-	return prefix, preLen, lengths[prefix.Bopts.DataCount-1], nil
+	return prefix, preLen, lengths[need-1], nil
 }
@@ -1,8 +1,10 @@
 package dataformat
 
 import (
+	"bytes"
 	"crypto/md5"
 	"crypto/sha256"
+	"io"
 	"log"
 	"math/rand"
 	"strconv"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/memoio/go-mefs/crypto/aes"
 	"github.com/memoio/go-mefs/crypto/pdp"
+	mpb "github.com/memoio/go-mefs/pb"
 )
 
 // 全局配置
@@ -60,13 +63,158 @@ func BenchmarkEncode(b *testing.B) {
 	}
 }
 
-func CodeAndRepair(policy, dc, pc, size int) {
+func benchmarkEncodeWorkers(opt *DataCoder, data []byte, workers int) func(b *testing.B) {
+	return func(b *testing.B) {
+		opt.TagWorkers = workers
+		b.SetBytes(int64(len(data)))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _, err := opt.Encode(data, "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0", 0)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkEncodeTagWorkers(b *testing.B) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		log.Fatal(err)
+	}
+	keyset, err := pdp.GenKeySetV1()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data := make([]byte, 3*DefaultSegmentSize*16)
+	fillRandom(data)
+
+	opt, err := NewDataCoder(keyset, RsPolicy, 3, 2, CurrentVersion, pdp.PDPV0, DefaultSegmentSize, DefaultSegmentCount, DefaultCrypt, userID, userID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, workers := range []int{1, 2, 4, 8, 16} {
+		b.Run("Workers:"+strconv.Itoa(workers), benchmarkEncodeWorkers(opt, data, workers))
+	}
+}
+
+// BenchmarkSealStripe reports rate of sealing replicas on upload, bytes are
+// of all replicas
+func BenchmarkSealStripe(b *testing.B) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		log.Fatal(err)
+	}
+	keyset, err := pdp.GenKeySetV1()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opt, err := newTestCoder(keyset, MulPolicy, pdp.PDPV0VDF, 1, 2, testSealIDs(3))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data := make([]byte, DefaultSegmentSize)
+	fillRandom(data)
+	b.SetBytes(int64(3 * len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := opt.Encode(data, "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0", 0)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncodeTagWorkers(t *testing.T) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		log.Fatal(err)
+	}
+	keyset, err := pdp.GenKeySetV1WithSeed(skbyte, pdp.SCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bo := DefaultBucketOptions()
+	bo.Policy = LrcPolicy
+	bo.DataCount = 4
+	bo.ParityCount = 4
+	bo.LocalCount = 2
+	bo.TagFlag = pdp.PDPV0
+	opt, err := NewDataCoderWithBopts(keyset, bo, userID, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, 4*DefaultSegmentSize*5+100)
+	fillRandom(data)
+
+	ncid := "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0"
+	opt.TagWorkers = 1
+	seq, end, err := opt.Encode(data, ncid, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt.TagWorkers = 8
+	par, parEnd, err := opt.Encode(data, ncid, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if end != parEnd {
+		t.Fatal("end is ", parEnd, ", want ", end)
+	}
+
+	for j := range seq {
+		if !bytes.Equal(seq[j], par[j]) {
+			t.Fatal("chunk ", j, " differs with parallel tags")
+		}
+		if !VerifyBlock(par[j], ncid+"_"+strconv.Itoa(j), keyset) {
+			t.Fatal("chunk ", j, " is not verified")
+		}
+	}
+}
+
+// newTestCoder creates coder of default sizes, sealed replicas are bound to sealIDs
+func newTestCoder(keyset pdp.KeySet, policy, tagFlag, dc, pc int, sealIDs []string) (*DataCoder, error) {
+	if !IsSealed(int32(tagFlag)) || policy != MulPolicy {
+		return NewDataCoder(keyset, policy, dc, pc, CurrentVersion, tagFlag, DefaultSegmentSize, DefaultSegmentCount, DefaultCrypt, userID, userID)
+	}
+
+	bo := &mpb.BucketOptions{
+		Version:      CurrentVersion,
+		Policy:       MulPolicy,
+		DataCount:    1,
+		ParityCount:  int32(dc + pc - 1),
+		TagFlag:      int32(tagFlag),
+		SegmentSize:  DefaultSegmentSize,
+		SegmentCount: DefaultSegmentCount,
+		Encryption:   DefaultCrypt,
+		SealIDs:      sealIDs,
+	}
+	return NewDataCoderWithBopts(keyset, bo, userID, userID)
+}
+
+func testSealIDs(n int) []string {
+	res := make([]string, n)
+	for i := range res {
+		res[i] = "provider" + strconv.Itoa(i)
+	}
+	return res
+}
+
+func CodeAndRepair(policy, tagFlag, dc, pc, size int) {
 	keyset, err := pdp.GenKeySetV1()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	opt, err := NewDataCoderWithDefault(keyset, policy, dc, pc, userID, userID)
+	opt, err := newTestCoder(keyset, policy, tagFlag, dc, pc, testSealIDs(dc+pc))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -208,13 +356,362 @@ func TestCode(t *testing.T) {
 	size := []int{4095, 4096, 4097, 3*4096 - 1, 3 * 4096, 3*4096 + 1}
 	for _, s := range size {
 		log.Println("test size: ", s)
-		CodeAndRepair(RsPolicy, 3, 2, s)
-		CodeAndRepair(MulPolicy, 3, 2, s)
+		CodeAndRepair(RsPolicy, DefaultTagFlag, 3, 2, s)
+		CodeAndRepair(MulPolicy, DefaultTagFlag, 3, 2, s)
 	}
 
 	return
 }
 
+func TestCodeSealed(t *testing.T) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	size := []int{4097, DefaultSegmentSize + 1}
+	for _, s := range size {
+		log.Println("test sealed size: ", s)
+		CodeAndRepair(MulPolicy, pdp.PDPV0Latin, 3, 2, s)
+		CodeAndRepair(MulPolicy, pdp.PDPV0VDF, 3, 2, s)
+	}
+
+	keyset, err := pdp.GenKeySetV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt, err := newTestCoder(keyset, MulPolicy, pdp.PDPV0Latin, 3, 2, testSealIDs(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, DefaultSegmentSize)
+	fillRandom(data)
+	datas, _, err := opt.Encode(data, "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replicas should differ from each other
+	for i := 1; i < len(datas); i++ {
+		if bytes.Equal(datas[0][opt.prefixSize:opt.prefixSize+opt.segSize], datas[i][opt.prefixSize:opt.prefixSize+opt.segSize]) {
+			t.Fatal("replica ", i, " is same as replica 0")
+		}
+	}
+
+	// replicas are bound to providers
+	sealIDs := testSealIDs(5)
+	sealIDs[1] = "provider5"
+	other, err := newTestCoder(keyset, MulPolicy, pdp.PDPV0VDF, 3, 2, sealIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	odatas, _, err := other.Encode(data, "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdatas, _, err := newTestSealed(t, keyset, pdp.PDPV0VDF).Encode(data, "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pre, seg := opt.prefixSize, opt.segSize
+	if !bytes.Equal(odatas[0][pre:pre+seg], vdatas[0][pre:pre+seg]) {
+		t.Fatal("replica of same provider differs")
+	}
+	if bytes.Equal(odatas[1][pre:pre+seg], vdatas[1][pre:pre+seg]) {
+		t.Fatal("replicas of different providers are same")
+	}
+
+	_, err = newTestCoder(keyset, MulPolicy, pdp.PDPV0VDF, 3, 2, testSealIDs(4))
+	if err != ErrWrongSealIDs {
+		t.Fatal("each sealed replica should be bound to a provider")
+	}
+
+	_, err = NewDataCoder(keyset, RsPolicy, 3, 2, CurrentVersion, pdp.PDPV0Latin, DefaultSegmentSize, DefaultSegmentCount, DefaultCrypt, userID, userID)
+	if err != ErrWrongPolicy {
+		t.Fatal("seal should only be used with MulPolicy")
+	}
+}
+
+func newTestSealed(t *testing.T, keyset pdp.KeySet, tagFlag int) *DataCoder {
+	d, err := newTestCoder(keyset, MulPolicy, tagFlag, 3, 2, testSealIDs(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestCodeLrc(t *testing.T) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyset, err := pdp.GenKeySetV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 4 data, 2 global parities, 2 local groups: {0,1,6}, {2,3,7}
+	bo := DefaultBucketOptions()
+	bo.Policy = LrcPolicy
+	bo.DataCount = 4
+	bo.ParityCount = 4
+	bo.LocalCount = 2
+
+	opt, err := NewDataCoderWithBopts(keyset, bo, userID, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g := LocalGroup(bo, 1); len(g) != 2 || g[0] != 0 || g[1] != 6 {
+		t.Fatal("got local group: ", g, ", expected: [0 6]")
+	}
+	if g := LocalGroup(bo, 4); g != nil {
+		t.Fatal("global parity should not have local group: ", g)
+	}
+
+	data := make([]byte, 3*DefaultSegmentSize+1)
+	fillRandom(data)
+	ncid := "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0"
+	datas, _, err := opt.Encode(data, ncid, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotdata, err := opt.Decode(datas, 0, len(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gotdata[:len(data)], data) {
+		t.Fatal("data is not right after decode")
+	}
+
+	copyStripe := func(keep ...int) [][]byte {
+		res := make([][]byte, len(datas))
+		for _, i := range keep {
+			res[i] = append([]byte{}, datas[i]...)
+		}
+		return res
+	}
+
+	// one lost chunk is repaired from its local group only
+	for _, lost := range []int{1, 6} {
+		stripe := copyStripe(LocalRepairGroup(datas[0], lost)...)
+		stripe, _, err = Repair(stripe)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(stripe[lost], datas[lost]) {
+			t.Fatal("chunk ", lost, " is not right after local repair")
+		}
+		if !VerifyBlock(stripe[lost], ncid+"_"+strconv.Itoa(lost), keyset) {
+			t.Fatal("tag is wrong for chunk ", lost)
+		}
+	}
+
+	// two lost chunks of one group are repaired by global parities
+	stripe := copyStripe(2, 3, 4, 5, 6, 7)
+	stripe, _, err = Repair(stripe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range datas {
+		if !bytes.Equal(stripe[i], datas[i]) {
+			t.Fatal("chunk ", i, " is not right after global repair")
+		}
+	}
+
+	// too many lost
+	stripe = copyStripe(0, 2, 3)
+	opt.Repair = true
+	_, err = opt.Decode(stripe, 0, len(data))
+	if err == nil {
+		t.Fatal("decode should fail with lost chunks")
+	}
+}
+
+func TestStreamCode(t *testing.T) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyset, err := pdp.GenKeySetV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bo := DefaultBucketOptions()
+	bo.SegmentCount = 4
+
+	opt, err := NewDataCoderWithBopts(keyset, bo, userID, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ncid := "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0"
+	stripeSize := int(bo.DataCount*bo.SegmentSize*bo.SegmentCount) - 4097
+	data := make([]byte, stripeSize+int(bo.DataCount*bo.SegmentSize)+1)
+	fillRandom(data)
+
+	// start from field 1, so it is cut at segment count
+	r := bytes.NewReader(data)
+	bufs := make([]*bytes.Buffer, opt.blockCount)
+	ws := make([]io.Writer, opt.blockCount)
+	for i := range bufs {
+		bufs[i] = new(bytes.Buffer)
+		ws[i] = bufs[i]
+	}
+
+	end, err := opt.StreamEncode(r, ws, ncid, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := int(bo.DataCount*bo.SegmentSize) * (end - 1)
+	datas, encEnd, err := opt.Encode(data[:first], ncid, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if end != encEnd || end != int(bo.SegmentCount) {
+		t.Fatal("got end: ", end, ", expected: ", encEnd)
+	}
+
+	for i := range datas {
+		if !bytes.Equal(bufs[i].Bytes(), datas[i]) {
+			t.Fatal("chunk ", i, " of stream encode is not same as encode")
+		}
+	}
+
+	// the rest is in next stripe
+	for i := range bufs {
+		bufs[i].Reset()
+	}
+	end, err = opt.StreamEncode(r, ws, ncid, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if end != 2 {
+		t.Fatal("got end: ", end, ", expected: 2")
+	}
+
+	_, err = opt.StreamEncode(r, ws, ncid, 0)
+	if err != io.EOF {
+		t.Fatal("got err: ", err, ", expected EOF")
+	}
+
+	rest := data[first:]
+	for _, lost := range []int{-1, 0} {
+		rs := make([]io.Reader, opt.blockCount)
+		for i := range rs {
+			if i != lost {
+				rs[i] = bytes.NewReader(bufs[i].Bytes())
+			}
+		}
+
+		opt.Repair = lost >= 0
+		var out bytes.Buffer
+		_, err = opt.StreamDecode(rs, &out, 0, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes()[:len(rest)], rest) {
+			t.Fatal("data is not right after stream decode, lost chunk: ", lost)
+		}
+	}
+
+	// only the second field
+	rs := make([]io.Reader, opt.blockCount)
+	for i := range rs {
+		rs[i] = bytes.NewReader(bufs[i].Bytes())
+	}
+	var out bytes.Buffer
+	_, err = opt.StreamDecode(rs, &out, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	segStripe := int(bo.DataCount * bo.SegmentSize)
+	if !bytes.Equal(out.Bytes()[:len(rest)-segStripe], rest[segStripe:]) {
+		t.Fatal("data is not right after stream decode from field 1")
+	}
+}
+
+func TestGetFields(t *testing.T) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		log.Fatal(err)
+	}
+	keyset, err := pdp.GenKeySetV1WithSeed(skbyte, pdp.SCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt, err := NewDataCoderWithDefault(keyset, RsPolicy, 3, 2, userID, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, 3*DefaultSegmentSize*6)
+	fillRandom(data)
+
+	ncid := "8MGxCuiT75bje883b7uFb6eMrJt5cP_1_0"
+	datas, _, err := opt.Encode(data, ncid, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fieldSize, err := FieldSize(opt.Prefix.GetBopts())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for j, block := range datas {
+		res, err := GetFields(block, 3, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ok, err := VerifyBlockLength(res, 3, 2)
+		if !ok || err != nil {
+			t.Fatal("fields of chunk ", j, " have wrong length: ", err)
+		}
+
+		if !bytes.Equal(res[len(res)-2*fieldSize:], block[len(block)-5*fieldSize:len(block)-3*fieldSize]) {
+			t.Fatal("fields of chunk ", j, " are wrong")
+		}
+
+		if !VerifyBlock(res, ncid+"_"+strconv.Itoa(j), keyset) {
+			t.Fatal("fields of chunk ", j, " are not verified")
+		}
+
+		// fields of fields
+		sub, err := GetFields(res, 4, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		whole, err := GetFields(block, 4, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sub, whole) {
+			t.Fatal("fields of partial chunk ", j, " are wrong")
+		}
+	}
+
+	_, err = GetFields(datas[0], 1, 1)
+	if err != ErrCannotGetSegment {
+		t.Fatal("GetFields should fail before start")
+	}
+
+	_, err = GetFields(datas[0], 6, 3)
+	if err != ErrDataTooShort {
+		t.Fatal("GetFields should fail after end")
+	}
+}
+
 func fillRandom(p []byte) {
 	rand.Seed(time.Now().UnixNano())
 	for i := 0; i < len(p); i += 7 {
@@ -14,6 +14,7 @@ import (
 const (
 	RsPolicy            = 1
 	MulPolicy           = 2
+	LrcPolicy           = 3
 	DefaultSegmentSize  = 32 * 1024
 	DefaultSegmentCount = 64
 	DefaultTagFlag      = pdp.PDPV0
@@ -32,6 +33,8 @@ var (
 	ErrDataToolong      = errors.New("input Data is too long for a block")
 	ErrRepairCrash      = errors.New("repair crash")
 	ErrRecoverData      = errors.New("The recovered data is incorrect")
+	ErrChunkCount       = errors.New("wrong count of chunks")
+	ErrWrongSealIDs     = errors.New("sealed replicas should be bound to one provider each")
 )
 
 // DefaultBucketOptions is default bucket option
@@ -62,6 +65,64 @@ func DefaultSuperBucketOptions() *mpb.BucketOptions {
 	}
 }
 
+// TagCount returns number of tags in each field of block under bo
+func TagCount(bo *mpb.BucketOptions) int {
+	if bo.GetDataCount() < 1 {
+		return 0
+	}
+
+	tagCount := int(2 + (bo.GetParityCount()-1)/bo.GetDataCount())
+	if bo.GetPolicy() == LrcPolicy {
+		// local sums of tags follow
+		tagCount *= 2
+	}
+	return tagCount
+}
+
+// FieldSize returns size of a field, which is a segment and its tags, of
+// block under bo
+func FieldSize(bo *mpb.BucketOptions) (int, error) {
+	tagSize, ok := pdp.TagMap[int(bo.GetTagFlag())]
+	if !ok {
+		return 0, ErrWrongTagFlag
+	}
+	return int(bo.GetSegmentSize()) + tagSize*TagCount(bo), nil
+}
+
+// GetFields cuts count fields from field begin out of data, which is a block
+// (or part of a block) with prefix; the result has prefix with start at begin
+func GetFields(data []byte, begin, count int) ([]byte, error) {
+	pre, preLen, err := bf.PrefixDecode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSize, err := FieldSize(pre.GetBopts())
+	if err != nil {
+		return nil, err
+	}
+
+	if begin < int(pre.Start) || count < 0 {
+		return nil, ErrCannotGetSegment
+	}
+
+	off := preLen + (begin-int(pre.Start))*fieldSize
+	if len(data) < off+count*fieldSize {
+		return nil, ErrDataTooShort
+	}
+
+	pre.Start = int32(begin)
+	prebuf, npreLen, err := bf.PrefixEncode(pre)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, npreLen+count*fieldSize)
+	copy(res, prebuf)
+	copy(res[npreLen:], data[off:off+count*fieldSize])
+	return res, nil
+}
+
 //VerifyBlockLength verify blocks length
 func VerifyBlockLength(data []byte, start, length int) (bool, error) {
 	if data == nil {
@@ -84,7 +145,7 @@ func VerifyBlockLength(data []byte, start, length int) (bool, error) {
 		s = 48
 	}
 
-	fieldSize := int(pre.GetBopts().GetSegmentSize()) + s*int(2+(pre.GetBopts().ParityCount-1)/pre.GetBopts().DataCount)
+	fieldSize := int(pre.GetBopts().GetSegmentSize()) + s*TagCount(pre.GetBopts())
 
 	if dataLen != length*fieldSize {
 		utils.MLogger.Error("VerifyBlockLength has length: ", dataLen, ", need: ", length*fieldSize)
@@ -1,6 +1,7 @@
 package latin
 
 import (
+	"bytes"
 	"math/rand"
 	"testing"
 	"time"
@@ -88,3 +89,46 @@ func fillRandom(p []byte) {
 		}
 	}
 }
+
+func TestCoord(t *testing.T) {
+	var size int64 = 1 << 15 //32K
+	n, ok := GetN(size)
+	if !ok {
+		t.Fatal("")
+	}
+
+	origin := make([]byte, size)
+	fillRandom(origin)
+
+	var last []byte
+	for _, sq := range [][2]uint32{{1, 2}, {3, 4}} {
+		coord, err := Coord(n, sq[0], sq[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := Encode(origin, coord, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Equal(encoded, last) {
+			t.Fatal("different squares scramble the same")
+		}
+		last = encoded
+
+		decoded, err := Decode(encoded, coord, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(origin, decoded) {
+			t.Fatal("not equal")
+		}
+	}
+
+	_, err := Coord(n, 1, 1)
+	if err != ErrInvalidSquare {
+		t.Fatal("same squares should fail")
+	}
+}
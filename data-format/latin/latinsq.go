@@ -1,6 +1,12 @@
 package latin
 
-import "math"
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidSquare is returned when latin squares of such order or index cannot be generated
+var ErrInvalidSquare = errors.New("invalid latin square order or index")
 
 const n uint64 = 1 << 16
 
@@ -141,3 +147,38 @@ func Latin(n, count int) ([][]uint32, error) {
 	}
 	return res, nil
 }
+
+// Supported reports whether orthogonal latin squares of order 2^n can be generated
+func Supported(n int) bool {
+	switch n {
+	case 3, 4, 5, 6, 7, 8, 9, 10, 16:
+		return true
+	default:
+		return false
+	}
+}
+
+//Coord 只生成第a个和第b个拉丁方，作为置乱坐标；a、b不同且在[1, 2^n)内
+func Coord(n int, a, b uint32) ([][]uint32, error) {
+	var length uint32 = 1 << uint(n)
+	if !Supported(n) || a == b || a == 0 || b == 0 || a >= length || b >= length {
+		return nil, ErrInvalidSquare
+	}
+
+	latin := make([]uint32, length)
+	var i uint32
+	for i = 0; i < length; i++ {
+		latin[i] = (i + 1) % length
+	}
+
+	res := make([][]uint32, 2)
+	for k, sq := range []uint32{a, b} {
+		res[k] = make([]uint32, length*length)
+		var j uint32
+		for j = 0; j < length*length; j++ {
+			temp := mul(latin[sq-1], latin[j/length], n)
+			res[k][j] = (temp ^ latin[j%length]) % length
+		}
+	}
+	return res, nil
+}
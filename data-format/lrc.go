@@ -0,0 +1,307 @@
+package dataformat
+
+import (
+	"github.com/memoio/go-mefs/data-format/reedsolomon"
+	mpb "github.com/memoio/go-mefs/pb"
+	bf "github.com/memoio/go-mefs/source/go-block-format"
+)
+
+// With LrcPolicy, ParityCount is split into global and local parities; a
+// stripe of DataCount+ParityCount chunks is laid out as:
+//
+//	[0, dc)               data chunks
+//	[dc, dc+gc)           global parities, reed-solomon of all data chunks
+//	[dc+gc, dc+gc+lc)     local parities, xor of data chunks in each group
+//
+// data chunks are split into LocalCount contiguous groups, so one lost data
+// or local parity chunk is repaired from chunks of its group only.
+//
+// Tags follow the same idea: besides its tag and global tag parities, each
+// field holds xor of those tags over its group, where global parities form
+// one more group; so tags of one lost chunk are repaired locally too.
+
+// lrcCounts returns number of data, global parity and local parity chunks
+func lrcCounts(bo *mpb.BucketOptions) (int, int, int) {
+	dc := int(bo.GetDataCount())
+	lc := int(bo.GetLocalCount())
+	return dc, int(bo.GetParityCount()) - lc, lc
+}
+
+// checkLrc verifies counts of lrc
+func checkLrc(bo *mpb.BucketOptions) error {
+	dc, gc, lc := lrcCounts(bo)
+	if lc < 1 || gc < 1 || lc > dc {
+		return ErrWrongPolicy
+	}
+	return nil
+}
+
+// lrcGroup returns chunks of local group i: its data chunks and local parity;
+// i == lc is group of global parities
+func lrcGroup(dc, gc, lc, i int) []int {
+	var res []int
+	if i == lc {
+		for j := dc; j < dc+gc; j++ {
+			res = append(res, j)
+		}
+		return res
+	}
+
+	for j := 0; j < dc; j++ {
+		if j*lc/dc == i {
+			res = append(res, j)
+		}
+	}
+	return append(res, dc+gc+i)
+}
+
+// lrcGroupOf returns index of local group of chunk
+func lrcGroupOf(dc, gc, lc, chunk int) int {
+	switch {
+	case chunk < dc:
+		return chunk * lc / dc
+	case chunk < dc+gc:
+		return lc
+	default:
+		return chunk - dc - gc
+	}
+}
+
+// LocalGroup returns other chunks which can repair chunk of stripe under
+// bo locally; it is nil if all chunks may be needed
+func LocalGroup(bo *mpb.BucketOptions, chunk int) []int {
+	if bo.GetPolicy() != LrcPolicy || checkLrc(bo) != nil {
+		return nil
+	}
+
+	dc, gc, lc := lrcCounts(bo)
+	if chunk < 0 || chunk >= dc+gc+lc {
+		return nil
+	}
+
+	// global parities are repaired from data chunks
+	gi := lrcGroupOf(dc, gc, lc, chunk)
+	if gi == lc {
+		return nil
+	}
+
+	var res []int
+	for _, j := range lrcGroup(dc, gc, lc, gi) {
+		if j != chunk {
+			res = append(res, j)
+		}
+	}
+	return res
+}
+
+// LocalRepairGroup is LocalGroup with bucket options in prefix of block
+func LocalRepairGroup(block []byte, chunk int) []int {
+	pre, _, err := bf.PrefixDecode(block)
+	if err != nil {
+		return nil
+	}
+	return LocalGroup(pre.GetBopts(), chunk)
+}
+
+func xorInto(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// encodeLrc fills global parities with reed-solomon and local parities with xor
+func (d *DataCoder) encodeLrc(enc reedsolomon.Encoder, dataGroup [][]byte) error {
+	dc, gc, lc := lrcCounts(d.Prefix.Bopts)
+	err := enc.Encode(dataGroup[:dc+gc])
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < lc; i++ {
+		lp := dataGroup[dc+gc+i]
+		for k := range lp {
+			lp[k] = 0
+		}
+		for _, j := range lrcGroup(dc, gc, lc, i) {
+			if j < dc {
+				xorInto(lp, dataGroup[j])
+			}
+		}
+	}
+	return nil
+}
+
+// encodeLocalTags writes xor of tags of each group into local tags of its
+// chunks; field is stripe[j][off:] for chunk j
+func (d *DataCoder) encodeLocalTags(stripe [][]byte, off int) {
+	dc, gc, lc := lrcCounts(d.Prefix.Bopts)
+	tagLen := d.tagCount * d.tagSize
+	begin := off + d.segSize
+	for i := 0; i <= lc; i++ {
+		group := lrcGroup(dc, gc, lc, i)
+		sum := make([]byte, tagLen)
+		for _, j := range group {
+			xorInto(sum, stripe[j][begin:begin+tagLen])
+		}
+		for _, j := range group {
+			copy(stripe[j][begin+tagLen:begin+2*tagLen], sum)
+		}
+	}
+}
+
+// recoverLocal repairs chunks of each group which lost only one chunk;
+// shards[j] is nil if chunk j is lost, local[j] is local sum held by chunk j,
+// or nil if the sum is xor of data chunks in group
+func recoverLocal(shards, local [][]byte, groups [][]int) {
+	for _, group := range groups {
+		lost := -1
+		var sum []byte
+		for _, j := range group {
+			if shards[j] == nil {
+				if lost >= 0 {
+					// lost more than one
+					lost = -2
+					break
+				}
+				lost = j
+				continue
+			}
+			if local != nil && sum == nil && local[j] != nil {
+				sum = make([]byte, len(local[j]))
+				copy(sum, local[j])
+			}
+		}
+
+		if lost < 0 {
+			continue
+		}
+
+		res := sum
+		for _, j := range group {
+			if j == lost {
+				continue
+			}
+			if res == nil {
+				res = make([]byte, len(shards[j]))
+			}
+			xorInto(res, shards[j])
+		}
+		if res != nil {
+			shards[lost] = res
+		}
+	}
+}
+
+// recoverLrcField repairs data and tags of a field; chunks which cannot be
+// repaired, e.g. chunks outside local group are not read, are left nil
+func (d *DataCoder) recoverLrcField(stripe [][]byte) ([][]byte, error) {
+	dc, gc, lc := lrcCounts(d.Prefix.Bopts)
+
+	segs := make([][]byte, d.blockCount)
+	tags := make([][]byte, d.blockCount)
+	sums := make([][]byte, d.blockCount)
+	tagLen := d.tagCount * d.tagSize
+	for i := 0; i < d.blockCount; i++ {
+		if stripe[i] != nil {
+			segs[i] = stripe[i][:d.segSize]
+			tags[i] = stripe[i][d.segSize : d.segSize+tagLen]
+			sums[i] = stripe[i][d.segSize+tagLen : d.segSize+2*tagLen]
+		}
+	}
+
+	// data: local groups first, then global reed-solomon, then local
+	// parities of repaired data
+	var dataGroups, tagGroups [][]int
+	for i := 0; i <= lc; i++ {
+		group := lrcGroup(dc, gc, lc, i)
+		tagGroups = append(tagGroups, group)
+		if i < lc {
+			dataGroups = append(dataGroups, group)
+		}
+	}
+
+	recoverLocal(segs, nil, dataGroups)
+
+	if hasNil(segs[:dc+gc]) {
+		enc, err := reedsolomon.New(dc, gc)
+		if err != nil {
+			return nil, err
+		}
+		global := append([][]byte{}, segs[:dc+gc]...)
+		if enc.Reconstruct(global) == nil {
+			copy(segs, global)
+			recoverLocal(segs, nil, dataGroups)
+		}
+	}
+
+	// tags: local groups first, then global tag parities
+	recoverLocal(tags, sums, tagGroups)
+
+	if hasNil(tags) {
+		tmpTag := make([][]byte, d.blockCount*d.tagCount)
+		for j := 0; j < d.tagCount; j++ {
+			for i := 0; i < d.blockCount; i++ {
+				if tags[i] != nil {
+					tmpTag[i+j*d.blockCount] = tags[i][j*d.tagSize : (j+1)*d.tagSize]
+				}
+			}
+		}
+
+		encP, err := reedsolomon.New(d.blockCount, d.blockCount*(d.tagCount-1))
+		if err != nil {
+			return nil, err
+		}
+		if encP.Reconstruct(tmpTag) == nil {
+			for i := 0; i < d.blockCount; i++ {
+				if tags[i] != nil {
+					continue
+				}
+				tags[i] = make([]byte, 0, tagLen)
+				for j := 0; j < d.tagCount; j++ {
+					tags[i] = append(tags[i], tmpTag[i+j*d.blockCount]...)
+				}
+			}
+		}
+	}
+
+	res := make([][]byte, d.blockCount)
+	for i := 0; i < d.blockCount; i++ {
+		if stripe[i] != nil {
+			res[i] = stripe[i]
+			continue
+		}
+		if segs[i] == nil || tags[i] == nil {
+			continue
+		}
+
+		// local sum of repaired chunk is the same as others in its group
+		group := tagGroups[lrcGroupOf(dc, gc, lc, i)]
+		sum := make([]byte, tagLen)
+		for _, j := range group {
+			if tags[j] == nil {
+				sum = nil
+				break
+			}
+			xorInto(sum, tags[j])
+		}
+		if sum == nil {
+			continue
+		}
+
+		res[i] = make([]byte, 0, d.fieldSize)
+		res[i] = append(res[i], segs[i]...)
+		res[i] = append(res[i], tags[i]...)
+		res[i] = append(res[i], sum...)
+	}
+
+	return res, nil
+}
+
+func hasNil(shards [][]byte) bool {
+	for _, s := range shards {
+		if s == nil {
+			return true
+		}
+	}
+	return false
+}
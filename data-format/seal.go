@@ -0,0 +1,177 @@
+package dataformat
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"sync"
+
+	"github.com/memoio/go-mefs/crypto/pdp"
+	"github.com/memoio/go-mefs/crypto/vdf"
+	"github.com/memoio/go-mefs/data-format/latin"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// With MulPolicy all chunks of a stripe hold the same data, so providers can
+// dedupe replicas and still pass challenges. Sealed tag flags scramble each
+// replica segment with a key of its chunkID and the provider it is bound to
+// (SealIDs of bucket options) before tags are generated, so every provider of
+// the stripe stores and proves over a different replica; repair unseals an
+// available replica and reseals it for the lost chunk. Sealing is slow and
+// unsealing is fast, so a provider which drops its replica cannot regenerate
+// challenged segments before keepers' timeout.
+
+// seal methods which can be set on buckets
+const (
+	SealLatin = "latin"
+	SealVDF   = "vdf"
+)
+
+// Sealing is sized against a provider which regenerates challenged segments
+// in parallel, since segments are sealed independently: keepers challenge at
+// least SealChalMin chunks of sealed groups and wait SealProofTimeout seconds
+// for proofs; regenerating them on SealAttackCores cores should take at least
+// twice of the timeout:
+//
+//	SealChalMin * SealVDFRound * 12ms / SealAttackCores >= 2 * SealProofTimeout
+//
+// one round of a 32KB segment takes about 12ms on one core (BenchmarkSeal in
+// crypto/vdf), so sealing takes about 0.45s per segment of each replica, i.e.
+// about 15 core-seconds per MB of each replica, 20 with tags, on upload and
+// repair (BenchmarkSealStripe). Unsealing is about five times faster.
+const (
+	SealAttackCores  = 16
+	SealChalMin      = 2048
+	SealProofTimeout = int64(30)
+	SealVDFRound     = 40
+)
+
+var (
+	vdfOnce  sync.Once
+	vdfCodec *vdf.VDFCodec
+
+	latinCoords sync.Map // key: n_chunkID, value: [][]uint32
+)
+
+// IsSealed reports whether replicas are sealed under tagFlag
+func IsSealed(tagFlag int32) bool {
+	return tagFlag == pdp.PDPV0Latin || tagFlag == pdp.PDPV0VDF
+}
+
+// GetSealTagFlag returns tag flag of seal method
+func GetSealTagFlag(method string) (int32, error) {
+	switch method {
+	case SealLatin:
+		return pdp.PDPV0Latin, nil
+	case SealVDF:
+		return pdp.PDPV0VDF, nil
+	default:
+		return 0, ErrWrongTagFlag
+	}
+}
+
+// checkSeal verifies bucket options can be used to seal replicas
+func (d *DataCoder) checkSeal() error {
+	bo := d.Prefix.Bopts
+	if !IsSealed(bo.TagFlag) {
+		return nil
+	}
+
+	if bo.Policy != MulPolicy {
+		return ErrWrongPolicy
+	}
+
+	if len(bo.SealIDs) != d.blockCount {
+		return ErrWrongSealIDs
+	}
+
+	if d.segSize%vdf.SealWordSize != 0 {
+		return ErrWrongTagFlag
+	}
+
+	if bo.TagFlag == pdp.PDPV0Latin {
+		n, ok := latin.GetN(int64(d.segSize))
+		if !ok || !latin.Supported(n) || 2*d.blockCount >= 1<<uint(n) {
+			return ErrWrongTagFlag
+		}
+	}
+	return nil
+}
+
+// sealKey derives key of replica at chunkID, which is bound to its provider
+func (d *DataCoder) sealKey(chunkID int) []byte {
+	key := sha256.Sum256([]byte(d.Prefix.QueryID + metainfo.BlockDelimiter + strconv.Itoa(chunkID) + metainfo.BlockDelimiter + d.Prefix.Bopts.SealIDs[chunkID]))
+	return key[:]
+}
+
+func getVDFCodec() *vdf.VDFCodec {
+	vdfOnce.Do(func() {
+		vdfCodec = vdf.NewVDF(127)
+	})
+	return vdfCodec
+}
+
+// getLatinCoord uses the (2*chunkID+1)th and (2*chunkID+2)th latin squares for replica at chunkID
+func getLatinCoord(n, chunkID int) ([][]uint32, error) {
+	key := strconv.Itoa(n) + metainfo.BlockDelimiter + strconv.Itoa(chunkID)
+	coord, ok := latinCoords.Load(key)
+	if ok {
+		return coord.([][]uint32), nil
+	}
+
+	nc, err := latin.Coord(n, uint32(2*chunkID+1), uint32(2*chunkID+2))
+	if err != nil {
+		return nil, err
+	}
+
+	latinCoords.Store(key, nc)
+	return nc, nil
+}
+
+// seal scrambles segment of replica at chunkID in place; latin squares
+// further mix vdf sealed words of latin sealed replicas
+func (d *DataCoder) seal(seg []byte, chunkID int) error {
+	if !IsSealed(d.Prefix.Bopts.TagFlag) {
+		return nil
+	}
+
+	err := getVDFCodec().Seal(seg, d.sealKey(chunkID), SealVDFRound)
+	if err != nil {
+		return err
+	}
+
+	if d.Prefix.Bopts.TagFlag == pdp.PDPV0Latin {
+		n, _ := latin.GetN(int64(len(seg)))
+		coord, err := getLatinCoord(n, chunkID)
+		if err != nil {
+			return err
+		}
+		res, err := latin.Encode(seg, coord, n)
+		if err != nil {
+			return err
+		}
+		copy(seg, res)
+	}
+	return nil
+}
+
+// unseal restores segment of replica at chunkID in place
+func (d *DataCoder) unseal(seg []byte, chunkID int) error {
+	if !IsSealed(d.Prefix.Bopts.TagFlag) {
+		return nil
+	}
+
+	if d.Prefix.Bopts.TagFlag == pdp.PDPV0Latin {
+		n, _ := latin.GetN(int64(len(seg)))
+		coord, err := getLatinCoord(n, chunkID)
+		if err != nil {
+			return err
+		}
+		res, err := latin.Decode(seg, coord, n)
+		if err != nil {
+			return err
+		}
+		copy(seg, res)
+	}
+
+	return getVDFCodec().Unseal(seg, d.sealKey(chunkID), SealVDFRound)
+}
@@ -0,0 +1,248 @@
+package dataformat
+
+import (
+	"io"
+	"io/ioutil"
+
+	proto "github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	bf "github.com/memoio/go-mefs/source/go-block-format"
+)
+
+// StreamEncode reads data from r and writes chunk j of the stripe to ws[j],
+// in the same format as Encode: prefix, then fields from start. It encodes
+// one field of each chunk at a time, so memory does not grow with segment
+// count. It stops at the end of r or at segment count of block, and returns
+// end field; io.EOF is returned and nothing is written if r has no data.
+func (d *DataCoder) StreamEncode(r io.Reader, ws []io.Writer, ncidPrefix string, start int) (int, error) {
+	if len(ws) < d.blockCount {
+		return start, ErrChunkCount
+	}
+
+	segCount := int(d.Prefix.Bopts.SegmentCount)
+	if segCount > 0 && start >= segCount {
+		return start, ErrDataToolong
+	}
+
+	dc := int(d.Prefix.Bopts.DataCount)
+	row := make([]byte, dc*d.segSize)
+	buf := make([]byte, d.blockCount*d.fieldSize)
+	fields := make([][]byte, d.blockCount)
+	for j := 0; j < d.blockCount; j++ {
+		fields[j] = buf[j*d.fieldSize : (j+1)*d.fieldSize]
+	}
+	dataGroup := make([][]byte, d.blockCount)
+	tagGroup := make([][]byte, d.blockCount*d.tagCount)
+
+	enc, encP, err := d.newEncoders()
+	if err != nil {
+		return start, err
+	}
+
+	i := start
+	for segCount <= 0 || i < segCount {
+		n, err := io.ReadFull(r, row)
+		if n == 0 && (err == io.EOF || err == io.ErrUnexpectedEOF) {
+			break
+		}
+		last := false
+		switch err {
+		case nil:
+		case io.ErrUnexpectedEOF:
+			last = true
+			// padding like the fresh stripe of Encode
+			for k := n; k < len(row); k++ {
+				row[k] = 0
+			}
+		default:
+			return i, err
+		}
+
+		if i == start {
+			d.Prefix.Start = int32(start)
+			preData, _, err := bf.PrefixEncode(d.Prefix)
+			if err != nil {
+				return i, err
+			}
+			for j := 0; j < d.blockCount; j++ {
+				_, err = ws[j].Write(preData)
+				if err != nil {
+					return i, err
+				}
+			}
+		}
+
+		_, err = d.encodeField(enc, encP, fields, dataGroup, tagGroup, row, ncidPrefix, i)
+		if err != nil {
+			return i, err
+		}
+
+		for j := 0; j < d.blockCount; j++ {
+			_, err = ws[j].Write(fields[j])
+			if err != nil {
+				return i, err
+			}
+		}
+
+		i++
+		if last {
+			break
+		}
+	}
+
+	if i == start {
+		return start, io.EOF
+	}
+
+	return i, nil
+}
+
+// StreamDecode reads chunks of a stripe from rs, rs[j] is nil if chunk j is
+// lost, and writes the same data as Decode to w: fields from start, length is
+// count of bytes, -1 means to the end. Lost data chunks are recovered field by
+// field if d.Repair is set. It returns count of bytes written.
+func (d *DataCoder) StreamDecode(rs []io.Reader, w io.Writer, start, length int) (int64, error) {
+	readers := make([]io.Reader, d.blockCount)
+	copy(readers, rs)
+
+	avaNum := 0
+	for j, r := range readers {
+		if r == nil {
+			continue
+		}
+
+		_, err := readPrefix(r)
+		if err == nil && start > 0 {
+			_, err = io.CopyN(ioutil.Discard, r, int64(start*d.fieldSize))
+		}
+		if err != nil {
+			readers[j] = nil
+			continue
+		}
+		avaNum++
+	}
+
+	if avaNum == 0 {
+		return 0, ErrRepairCrash
+	}
+
+	dc := int(d.Prefix.Bopts.DataCount)
+	segLength := -1
+	if length != -1 {
+		segLength = 1 + (length-1)/(dc*d.segSize)
+	}
+
+	buf := make([]byte, d.blockCount*d.fieldSize)
+	fields := make([][]byte, d.blockCount)
+	sealed := IsSealed(d.Prefix.Bopts.TagFlag)
+
+	var written int64
+	for i := start; segLength == -1 || i < start+segLength; i++ {
+		eof := 0
+		for j, r := range readers {
+			fields[j] = nil
+			if r == nil {
+				continue
+			}
+
+			field := buf[j*d.fieldSize : (j+1)*d.fieldSize]
+			_, err := io.ReadFull(r, field)
+			if err != nil {
+				if err == io.EOF {
+					eof++
+				}
+				readers[j] = nil
+				continue
+			}
+			fields[j] = field
+		}
+
+		// all chunks are read to the end
+		if segLength == -1 && eof > 0 && eof == avaNum {
+			break
+		}
+
+		lost := false
+		for j := 0; j < dc; j++ {
+			if fields[j] == nil {
+				lost = true
+				break
+			}
+		}
+
+		if lost {
+			if !d.Repair {
+				return written, ErrRepairCrash
+			}
+
+			res, err := d.recoverField(fields)
+			if err != nil {
+				return written, err
+			}
+			copy(fields, res)
+		}
+
+		for j := 0; j < dc; j++ {
+			if len(fields[j]) < d.segSize {
+				return written, ErrRepairCrash
+			}
+
+			seg := fields[j][:d.segSize]
+			if sealed {
+				err := d.unseal(seg, j)
+				if err != nil {
+					return written, err
+				}
+			}
+
+			n, err := w.Write(seg)
+			written += int64(n)
+			if err != nil {
+				return written, err
+			}
+		}
+
+		avaNum = 0
+		for _, r := range readers {
+			if r != nil {
+				avaNum++
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// readPrefix reads prefix of block from r
+func readPrefix(r io.Reader) (*mpb.BlockOptions, error) {
+	var head [10]byte
+	var x uint64
+	var n int
+	for n = 0; n < len(head); n++ {
+		_, err := io.ReadFull(r, head[n:n+1])
+		if err != nil {
+			return nil, err
+		}
+		if head[n] < 0x80 {
+			x, _ = proto.DecodeVarint(head[:n+1])
+			break
+		}
+	}
+
+	if x == 0 {
+		return nil, ErrDataBroken
+	}
+
+	preData := make([]byte, x)
+	_, err := io.ReadFull(r, preData)
+	if err != nil {
+		return nil, err
+	}
+
+	pre := new(mpb.BlockOptions)
+	err = proto.Unmarshal(preData, pre)
+	if err != nil {
+		return nil, err
+	}
+	return pre, nil
+}
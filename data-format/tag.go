@@ -14,7 +14,7 @@ func (d *DataCoder) GenTagForSegment(index, data []byte) ([]byte, error) {
 		return uint32ToBytes(crc32.ChecksumIEEE(data)), nil
 	case pdp.BLS:
 		return nil, ErrWrongTagFlag
-	case pdp.PDPV0:
+	case pdp.PDPV0, pdp.PDPV0Latin, pdp.PDPV0VDF:
 		res, err := d.BlsKey.GenTag(index, data, 0, 32, true)
 		if err != nil {
 			return nil, err
@@ -108,6 +108,11 @@ Set the value of the 'Datastore.Path' key:
 					return err
 				}
 				keeper.MarketingMoney = mm
+			case "ChalPolicy":
+				err := keeper.SetChalPolicy(value)
+				if err != nil {
+					return err
+				}
 			default:
 			}
 			if parseJSON, _ := req.Options[configJSONOptionName].(bool); parseJSON {
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"time"
 
 	cmds "github.com/ipfs/go-ipfs-cmds"
 	"github.com/memoio/go-mefs/core/commands/cmdenv"
 	"github.com/memoio/go-mefs/manageNode/keeper"
+	"github.com/memoio/go-mefs/role"
 	"github.com/memoio/go-mefs/utils"
 )
 
@@ -39,6 +41,8 @@ var KeeperCmd = &cmds.Command{
 		"list_providers": KeeperListProvidersCmd,
 		"list_keepers":   KeeperListKeepersCmd,
 		"list_income":    KeeperListIncomeCmd,
+		"list_challenge": KeeperListChallengeCmd,
+		"list_chal_log":  KeeperListChalLogCmd,
 		"flush":          KeeperFlushCmd,
 	},
 }
@@ -213,6 +217,112 @@ var KeeperListIncomeCmd = &cmds.Command{
 	},
 }
 
+//KeeperListChallengeCmd list challenge policy
+var KeeperListChallengeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List keeper's challenge policy",
+		ShortDescription: `
+'mefs-keeper info list_challenge' is a plumbing command for printing the last challenge
+policy, sample size and detection probability of each provider in each user group.
+`,
+	},
+
+	Arguments: []cmds.Argument{},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+
+		keeperIns, ok := node.Inst.(*keeper.Info)
+		if !ok {
+			return ErrNotReady
+		}
+
+		stats, err := keeperIns.GetChallengeStats()
+		if err != nil {
+			return err
+		}
+
+		stringList := []string{"policy: " + keeper.ChalPolicy}
+		list := &StringList{
+			ChildLists: append(stringList, stats...),
+		}
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, fl *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", fl)
+			return err
+		}),
+	},
+}
+
+//KeeperListChalLogCmd list audit logs of challenges
+var KeeperListChalLogCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List challenge logs of user",
+		ShortDescription: `
+'mefs-keeper info list_chal_log' is a plumbing command for printing the audit logs
+of challenges and proofs of a user's fs in recent days.
+`,
+	},
+
+	Arguments: []cmds.Argument{
+		cmds.StringArg("userID", true, false, "The id of user"),
+		cmds.StringArg("fsID", true, false, "The fsID of user"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("provider", "pro", "Only list logs of this provider").WithDefault(""),
+		cmds.IntOption("days", "d", "List logs in recent days").WithDefault(1),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+
+		keeperIns, ok := node.Inst.(*keeper.Info)
+		if !ok {
+			return ErrNotReady
+		}
+
+		proID, _ := req.Options["provider"].(string)
+		days, _ := req.Options["days"].(int)
+		end := time.Now().Unix()
+		start := end - int64(days)*24*60*60
+
+		cls, err := keeperIns.GetChalLogs(req.Arguments[0], req.Arguments[1], proID, start, end)
+		if err != nil {
+			return err
+		}
+
+		stringList := make([]string, 0, len(cls))
+		for _, cl := range cls {
+			stringList = append(stringList, role.FormatChalLog(cl))
+		}
+
+		list := &StringList{
+			ChildLists: stringList,
+		}
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, fl *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", fl)
+			return err
+		}),
+	},
+}
+
 var KeeperFlushCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Flush keepers and providers.",
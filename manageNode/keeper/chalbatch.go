@@ -0,0 +1,168 @@
+package keeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/memoio/go-mefs/crypto/pdp"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// chalBatchMin is the least number of groups on one provider which are challenged in a batch
+const chalBatchMin = 2
+
+type chalItem struct {
+	key   string
+	value []byte
+}
+
+// sendChallenges sends challenges of each provider; challenges of many groups
+// on one provider are sent in a batch, and are answered with an aggregated proof
+func (k *Info) sendChallenges(ctx context.Context, chals map[string][]chalItem) {
+	for proID, items := range chals {
+		if len(items) < chalBatchMin {
+			for _, item := range items {
+				utils.MLogger.Infof("Challenge: %s", item.key)
+				k.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), item.key, item.value, nil, proID)
+			}
+			continue
+		}
+
+		chaltime := time.Now().Unix()
+		cb := &mpb.ChalBatch{
+			KeeperID:   k.localID,
+			ProviderID: proID,
+			ChalTime:   chaltime,
+		}
+
+		for _, item := range items {
+			cr := new(mpb.ChalInfo)
+			err := proto.Unmarshal(item.value, cr)
+			if err != nil {
+				continue
+			}
+			cb.Chals = append(cb.Chals, cr)
+		}
+
+		value, err := proto.Marshal(cb)
+		if err != nil {
+			continue
+		}
+
+		// key: pid/"ChalBatch"/kid/chaltime
+		km, err := metainfo.NewKey(proID, mpb.KeyType_ChalBatch, k.localID, utils.UnixToString(chaltime))
+		if err != nil {
+			continue
+		}
+
+		utils.MLogger.Infof("Challenge batch: %s for %d groups", km.ToString(), len(cb.Chals))
+		k.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), km.ToString(), value, nil, proID)
+	}
+}
+
+// key: pid/"ChalBatch"/kid/chaltime
+// handleBatchProof verifies proofs of groups in batch in one pass
+func (k *Info) handleBatchProof(km *metainfo.Key, value []byte, from string) {
+	utils.MLogger.Info("handleBatchProof: ", km.ToString())
+
+	ops := km.GetOptions()
+	if len(ops) < 2 {
+		return
+	}
+
+	proID := km.GetMainID()
+	if ops[0] != k.localID || proID != from {
+		utils.MLogger.Warnf("handleBatchProof: %s fails: wrong keeperID or providerID", km.ToString())
+		return
+	}
+
+	proInfo, ok := k.providers.Load(proID)
+	if !ok {
+		utils.MLogger.Warnf("handleBatchProof: %s fails: no proInfo", km.ToString())
+		return
+	}
+	pi := proInfo.(*pInfo)
+
+	cb := new(mpb.ChalBatch)
+	err := proto.Unmarshal(value, cb)
+	if err != nil {
+		utils.MLogger.Warnf("handleBatchProof: %s fails: %s", km.ToString(), err)
+		return
+	}
+
+	var groups []*groupInfo
+	var linfos []*lInfo
+	var crs []*mpb.ChalInfo
+	var blsKeys []pdp.VerifyKey
+	for _, pcr := range cb.GetChals() {
+		if pcr.GetProviderID() != proID || pcr.GetKeeperID() != k.localID {
+			continue
+		}
+
+		thisGroup := k.getGroupInfo(pcr.GetUserID(), pcr.GetQueryID(), false)
+		if thisGroup == nil || !thisGroup.status {
+			continue
+		}
+
+		thisLinfo := thisGroup.getLInfo(proID, false)
+		if thisLinfo == nil || thisLinfo.lastChalTime != pcr.GetChalTime() {
+			continue
+		}
+
+		thischalresult, ok := thisLinfo.chalMap.Load(pcr.GetChalTime())
+		if !ok {
+			continue
+		}
+		chalResult := thischalresult.(*mpb.ChalInfo)
+
+		pi.credit--
+		if pi.credit < -100 {
+			pi.credit = -100
+		}
+
+		if k.isLateProof(thisGroup, chalResult) {
+			thisLinfo.inChallenge = false
+			continue
+		}
+
+		blsKey, err := k.getUserBLS12Config(pcr.GetUserID(), pcr.GetQueryID())
+		if err != nil {
+			continue
+		}
+
+		chalResult.BlsProof = pcr.GetBlsProof()
+		switch chalResult.GetPolicy() {
+		case role.ChalPolicyRandom:
+			chalResult.FaultBlocks = pcr.GetFaultBlocks()
+		default:
+			chalResult.FailMap = pcr.GetFailMap()
+		}
+
+		groups = append(groups, thisGroup)
+		linfos = append(linfos, thisLinfo)
+		crs = append(crs, chalResult)
+		blsKeys = append(blsKeys, blsKey)
+	}
+
+	if len(crs) == 0 {
+		return
+	}
+
+	ress, sucCids, faultCids, err := role.VerifyChallengeBatch(crs, blsKeys, false)
+	if err != nil {
+		utils.MLogger.Error("batch proof from provider: ", proID, " verify fails: ", err)
+	}
+
+	for i, cr := range crs {
+		// key: qid/"Challenge"/uid/pid/kid/chaltime
+		ckm, err := metainfo.NewKey(cr.GetQueryID(), mpb.KeyType_Challenge, cr.GetUserID(), proID, k.localID, utils.UnixToString(cr.GetChalTime()))
+		if err == nil {
+			k.applyProof(groups[i], linfos[i], pi, ckm.ToString(), cr, ress[i], sucCids[i], faultCids[i])
+		}
+		linfos[i].inChallenge = false
+	}
+}
@@ -29,6 +29,7 @@ func (k *Info) challengeRegular(ctx context.Context) {
 		case <-ticker.C:
 			utils.MLogger.Info("Regular challenge start")
 			pus := k.getQUKeys()
+			chals := make(map[string][]chalItem)
 			for _, pu := range pus {
 				thisGroup := k.getGroupInfo(pu.uid, pu.qid, false)
 				if thisGroup == nil || thisGroup.upkeeping == nil || !thisGroup.status {
@@ -37,7 +38,7 @@ func (k *Info) challengeRegular(ctx context.Context) {
 
 				chalTime := time.Now().Unix()
 
-				if thisGroup.upkeeping.EndTime < chalTime {
+				if thisGroup.isExpired() {
 					utils.MLogger.Infof("Challenge for user %s fsID %s upkeeping has expired", pu.uid, pu.qid)
 					continue
 				}
@@ -60,8 +61,14 @@ func (k *Info) challengeRegular(ctx context.Context) {
 				utils.MLogger.Infof("Challenge for user %s fsID %s at rootTime %d", pu.uid, pu.qid, mtime)
 				count = 0
 				for _, proID := range thisGroup.providers {
+					k.cleanLastChallenge(thisGroup, proID)
 					if pu.uid == pos.GetPostId() {
-						key, value, err := thisGroup.genChallengeRandom100(k.localID, pu.uid, pu.qid, proID, mtime)
+						// pdp and raw post data are challenged in turn
+						genPost := thisGroup.genChallengeRandom100
+						if cdata%2 == 1 {
+							genPost = thisGroup.genChallengePost
+						}
+						key, value, err := genPost(k.localID, pu.uid, pu.qid, proID, mtime)
 						if err != nil {
 							utils.MLogger.Infof("Challenge data for post user %s fsID %s at provider %s fails: %s", pu.uid, pu.qid, proID, err)
 							continue
@@ -71,26 +78,15 @@ func (k *Info) challengeRegular(ctx context.Context) {
 						k.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), key, value, nil, proID)
 						continue
 					}
-					if cdata%2 == 0 {
-						key, value, err := thisGroup.genChallengeData(k.localID, pu.uid, pu.qid, proID, mtime)
-						if err != nil {
-							utils.MLogger.Infof("Challenge data for user %s fsID %s at provider %s fails: %s", pu.uid, pu.qid, proID, err)
-							continue
-						}
-						count++
-						utils.MLogger.Infof("Challenge data: %s", key)
-						k.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), key, value, nil, proID)
-					} else {
-						key, value, err := thisGroup.genChallengeMeta(k.localID, pu.uid, pu.qid, proID, mtime)
-						if err != nil {
-							utils.MLogger.Infof("Challenge meta for user %s fsID %s at provider %s fails: %s", pu.uid, pu.qid, proID, err)
-							continue
+					key, value, err := k.genChallenge(thisGroup, proID, mtime, cdata)
+					if err != nil {
+						if err != errSkipChallenge {
+							utils.MLogger.Infof("Challenge for user %s fsID %s at provider %s fails: %s", pu.uid, pu.qid, proID, err)
 						}
-						count++
-						utils.MLogger.Infof("Challenge meta: %s", key)
-						k.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), key, value, nil, proID)
+						continue
 					}
-
+					count++
+					chals[proID] = append(chals[proID], chalItem{key: key, value: value})
 				}
 
 				if count > 0 {
@@ -98,12 +94,13 @@ func (k *Info) challengeRegular(ctx context.Context) {
 					go k.getUserBLS12Config(pu.uid, pu.qid)
 				}
 			}
+			k.sendChallenges(ctx, chals)
 			cdata++
 		}
 	}
 }
 
-func (g *groupInfo) genChallengeData(localID, userID, qid, proID string, rootTime int64) (string, []byte, error) {
+func (g *groupInfo) genChallengeData(localID, userID, qid, proID string, rootTime int64, policy string) (string, []byte, error) {
 	thisLinfo := g.getLInfo(proID, false)
 	if thisLinfo == nil {
 		return "", nil, role.ErrNotMyProvider
@@ -216,7 +213,7 @@ func (g *groupInfo) genChallengeData(localID, userID, qid, proID string, rootTim
 	}
 
 	thischalresult := &mpb.ChalInfo{
-		Policy:      "smart", // use different policy; such as "1%"
+		Policy:      policy,
 		KeeperID:    localID,
 		ProviderID:  proID,
 		QueryID:     qid,
@@ -341,7 +338,7 @@ func (g *groupInfo) genChallengeMeta(localID, userID, qid, proID string, rootTim
 	}
 
 	thischalresult := &mpb.ChalInfo{
-		Policy:      "meta", // use different policy; such as "1%"
+		Policy:      role.ChalPolicyMeta,
 		KeeperID:    localID,
 		ProviderID:  proID,
 		QueryID:     qid,
@@ -372,6 +369,16 @@ func (g *groupInfo) genChallengeMeta(localID, userID, qid, proID string, rootTim
 }
 
 func (g *groupInfo) genChallengeRandom100(localID, userID, qid, proID string, rootTime int64) (string, []byte, error) {
+	return g.genChallengeBlocks(localID, userID, qid, proID, rootTime, role.ChalPolicyRandom, 100)
+}
+
+// genChallengePost challenges raw segments of post data, which are verified by regenerating
+func (g *groupInfo) genChallengePost(localID, userID, qid, proID string, rootTime int64) (string, []byte, error) {
+	return g.genChallengeBlocks(localID, userID, qid, proID, rootTime, role.ChalPolicyPost, role.PostChalNum)
+}
+
+// genChallengeBlocks challenges at most maxNum blocks
+func (g *groupInfo) genChallengeBlocks(localID, userID, qid, proID string, rootTime int64, policy string, maxNum int) (string, []byte, error) {
 	thisLinfo := g.getLInfo(proID, false)
 	if thisLinfo == nil {
 		return "", nil, role.ErrNotMyProvider
@@ -386,9 +393,9 @@ func (g *groupInfo) genChallengeRandom100(localID, userID, qid, proID string, ro
 
 	challengetime := time.Now().Unix()
 
-	// at most challenge 100 blocks
+	// at most challenge maxNum blocks
 	cset := make(map[string]int)
-	ret := make([]string, 0, 100)
+	ret := make([]string, 0, maxNum)
 	chalnum := 0
 	psum := 0
 	thisLinfo.blockMap.Range(func(key, value interface{}) bool {
@@ -397,7 +404,7 @@ func (g *groupInfo) genChallengeRandom100(localID, userID, qid, proID string, ro
 		ret = append(ret, key.(string)+metainfo.BlockDelimiter+strconv.Itoa(cInfo.offset))
 		psum += cInfo.offset
 		chalnum++
-		if chalnum >= 100 {
+		if chalnum >= maxNum {
 			return false
 		}
 		return true
@@ -416,7 +423,7 @@ func (g *groupInfo) genChallengeRandom100(localID, userID, qid, proID string, ro
 	}
 
 	thischalresult := &mpb.ChalInfo{
-		Policy:      "random100", // use different policy; such as "1%"
+		Policy:      policy,
 		KeeperID:    localID,
 		ProviderID:  proID,
 		QueryID:     qid,
@@ -446,15 +453,27 @@ func (g *groupInfo) genChallengeRandom100(localID, userID, qid, proID string, ro
 	return km.ToString(), hByte, nil
 }
 
-func (l *lInfo) cleanLastChallenge() {
-	if !l.inChallenge {
+// cleanLastChallenge logs last challenge of provider as failed if it has no proof
+func (k *Info) cleanLastChallenge(g *groupInfo, proID string) {
+	thisLinfo := g.getLInfo(proID, false)
+	if thisLinfo == nil || !thisLinfo.inChallenge {
 		return
 	}
 
+	cr, sucCids, faultCids := thisLinfo.cleanLastChallenge()
+	k.putChalLog(cr, sucCids, faultCids, 0)
+}
+
+// cleanLastChallenge marks the unreplied challenge as failed and returns it
+func (l *lInfo) cleanLastChallenge() (*mpb.ChalInfo, []string, []string) {
+	if !l.inChallenge {
+		return nil, nil, nil
+	}
+
 	failChallTime := l.lastChalTime
 	thischalresult, ok := l.chalMap.Load(failChallTime)
 	if !ok {
-		return
+		return nil, nil, nil
 	}
 
 	chalResult := thischalresult.(*mpb.ChalInfo)
@@ -484,6 +503,7 @@ func (l *lInfo) cleanLastChallenge() {
 	}
 
 	l.inChallenge = false
+	return chalResult, sucCids, faultCids
 }
 
 //handleProof handles the challenge result from provider
@@ -550,34 +570,46 @@ func (k *Info) handleProof(km *metainfo.Key, value []byte) {
 
 	chalResult := thischalresult.(*mpb.ChalInfo)
 
-	spliteProof := strings.Split(string(value), metainfo.DELIMITER)
-	if len(spliteProof) < 1 {
-		utils.MLogger.Warnf("handleProof: %s fails: proof is too short", km.ToString())
+	if k.isLateProof(thisGroup, chalResult) {
 		return
 	}
 
 	var err error
-	chalResult.BlsProof, err = b58.Decode(spliteProof[0])
-	if err != nil {
-		utils.MLogger.Warnf("handleProof: %s fails: proof b58 decode failed", km.ToString())
-		return
-	}
-	switch chalResult.GetPolicy() {
-	case "smart", "meta":
-		if len(spliteProof) == 2 {
-			fmap, err := b58.Decode(spliteProof[1])
-			if err == nil {
-				chalResult.FailMap = fmap
-			}
+	if chalResult.GetPolicy() == role.ChalPolicyPost {
+		// raw segments of post data are too large to be encoded in base58
+		err = role.DecodePostProof(chalResult, value)
+		if err != nil {
+			utils.MLogger.Warnf("handleProof: %s fails: post proof decode failed", km.ToString())
+			return
+		}
+	} else {
+		spliteProof := strings.Split(string(value), metainfo.DELIMITER)
+		if len(spliteProof) < 1 {
+			utils.MLogger.Warnf("handleProof: %s fails: proof is too short", km.ToString())
+			return
+		}
+
+		chalResult.BlsProof, err = b58.Decode(spliteProof[0])
+		if err != nil {
+			utils.MLogger.Warnf("handleProof: %s fails: proof b58 decode failed", km.ToString())
+			return
 		}
-	case "random100":
-		if len(spliteProof) == 2 {
-			indices, err := b58.Decode(spliteProof[1])
-			if err == nil {
-				chalResult.FaultBlocks = strings.Split(string(indices), metainfo.DELIMITER)
+		switch chalResult.GetPolicy() {
+		case role.ChalPolicyRandom:
+			if len(spliteProof) == 2 {
+				indices, err := b58.Decode(spliteProof[1])
+				if err == nil {
+					chalResult.FaultBlocks = strings.Split(string(indices), metainfo.DELIMITER)
+				}
+			}
+		default:
+			if len(spliteProof) == 2 {
+				fmap, err := b58.Decode(spliteProof[1])
+				if err == nil {
+					chalResult.FailMap = fmap
+				}
 			}
 		}
-	default:
 	}
 
 	blsKey, err := k.getUserBLS12Config(userID, qid)
@@ -592,8 +624,40 @@ func (k *Info) handleProof(km *metainfo.Key, value []byte) {
 		sucCids = nil
 	}
 
+	if !res && chalResult.GetPolicy() != role.ChalPolicyPost {
+		utils.MLogger.Info("User's verifykey is ", blsKey.Serialize(), "proof is ", chalResult.BlsProof)
+	}
+
+	k.applyProof(thisGroup, thisLinfo, proInfo.(*pInfo), km.ToString(), chalResult, res, sucCids, faultCids)
+}
+
+// isLateProof cleans the challenge as no proof if proof comes too late;
+// sealed replicas and post data take time to regenerate on the fly
+func (k *Info) isLateProof(thisGroup *groupInfo, chalResult *mpb.ChalInfo) bool {
+	elapsed := time.Now().Unix() - chalResult.GetChalTime()
+	switch {
+	case elapsed > sealProofTimeout && thisGroup.hasSealedBucket():
+		utils.MLogger.Warnf("proof of %s from provider %s fails: proof of sealed replicas is too late", chalResult.GetQueryID(), chalResult.GetProviderID())
+	case elapsed > role.PostProofTimeout && chalResult.GetPolicy() == role.ChalPolicyPost:
+		utils.MLogger.Warnf("proof of %s from provider %s fails: proof of post data is too late", chalResult.GetQueryID(), chalResult.GetProviderID())
+	default:
+		return false
+	}
+
+	k.cleanLastChallenge(thisGroup, chalResult.GetProviderID())
+	return true
+}
+
+// applyProof updates blocks and credit of provider by verified challenge result, and persists it
+func (k *Info) applyProof(thisGroup *groupInfo, thisLinfo *lInfo, pi *pInfo, key string, chalResult *mpb.ChalInfo, res bool, sucCids, faultCids []string) {
+	qid := chalResult.GetQueryID()
+	proID := chalResult.GetProviderID()
+	challengetime := chalResult.GetChalTime()
+
+	k.putChalLog(chalResult, sucCids, faultCids, time.Now().Unix())
+
 	if len(sucCids) > 0 {
-		utils.MLogger.Debugf("proof of %s has sucCids: %s", km.ToString(), sucCids)
+		utils.MLogger.Debugf("proof of %s has sucCids: %s", key, sucCids)
 		for _, key := range sucCids {
 			_, ok := thisLinfo.faultCid.Load(key)
 			if ok {
@@ -603,7 +667,7 @@ func (k *Info) handleProof(km *metainfo.Key, value []byte) {
 	}
 
 	if len(faultCids) > 0 {
-		utils.MLogger.Debugf("proof of %s has faultCids: %s", km.ToString(), faultCids)
+		utils.MLogger.Debugf("proof of %s has faultCids: %s", key, faultCids)
 		for _, key := range faultCids {
 			thisLinfo.faultCid.Store(key, struct{}{})
 		}
@@ -615,7 +679,7 @@ func (k *Info) handleProof(km *metainfo.Key, value []byte) {
 		// update thischalinfo.cidMap;
 		// except fault blocks, others are considered as "good"
 		thisLinfo.blockMap.Range(func(k, v interface{}) bool {
-			_, ok = thisLinfo.faultCid.Load(k.(string))
+			_, ok := thisLinfo.faultCid.Load(k.(string))
 			if ok {
 				utils.MLogger.Debugf("do not change faulted %s availtime for %s", k.(string), qid)
 				return true
@@ -626,13 +690,17 @@ func (k *Info) handleProof(km *metainfo.Key, value []byte) {
 			return true
 		})
 
-		proInfo.(*pInfo).credit += 2
-		if proInfo.(*pInfo).credit > 100 {
-			proInfo.(*pInfo).credit = 100
+		pi.credit += 2
+		if pi.credit > 100 {
+			pi.credit = 100
 		}
 	} else {
 		utils.MLogger.Info("handle proof of ", qid, "from provider: ", proID, " verify fail.")
-		utils.MLogger.Info("User's verifykey is ", blsKey.Serialize(), "proof is ", chalResult.BlsProof)
+	}
+
+	// raw segments of post data are verified and too large to keep
+	if chalResult.GetPolicy() == role.ChalPolicyPost {
+		chalResult.BlsProof = nil
 	}
 
 	//update thischalinfo.chalMap
@@ -641,6 +709,5 @@ func (k *Info) handleProof(km *metainfo.Key, value []byte) {
 		return
 	}
 
-	k.putKey(k.context, km.ToString(), hByte, nil, "local", thisGroup.clusterID, thisGroup.bft)
-
+	k.putKey(k.context, key, hByte, nil, "local", thisGroup.clusterID, thisGroup.bft)
 }
@@ -0,0 +1,238 @@
+package keeper
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/bitset"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+const (
+	// maxChalLogs is the most number of challenge logs returned in one query
+	maxChalLogs = 1024
+	// challenge logs older than this are pruned once spacetime of them is paid
+	chalLogRetention = int64(30 * 24 * 60 * 60)
+	// unpaid challenge logs are kept at most this long
+	chalLogMaxAge    = 3 * chalLogRetention
+	chalLogPruneTime = 6 * time.Hour
+)
+
+// putChalLog appends a signed audit log of challenge result;
+// proofTime is 0 if provider does not reply
+func (k *Info) putChalLog(cr *mpb.ChalInfo, sucCids, faultCids []string, proofTime int64) {
+	if cr == nil {
+		return
+	}
+
+	total := int64(len(cr.GetBlocks()))
+	if len(cr.GetChunkMap()) > 0 {
+		bset := bitset.New(0)
+		err := bset.UnmarshalBinary(cr.GetChunkMap())
+		if err == nil {
+			total = int64(bset.Count())
+		}
+	}
+
+	cl := &mpb.ChalLog{
+		QueryID:       cr.GetQueryID(),
+		UserID:        cr.GetUserID(),
+		ProviderID:    cr.GetProviderID(),
+		KeeperID:      k.localID,
+		ChalTime:      cr.GetChalTime(),
+		ProofTime:     proofTime,
+		Policy:        cr.GetPolicy(),
+		ChalNum:       int64(len(sucCids) + len(faultCids)),
+		TotalNum:      total,
+		ChalLength:    cr.GetChalLength(),
+		SuccessLength: cr.GetSuccessLength(),
+		FaultBlocks:   faultCids,
+		Res:           cr.GetRes(),
+	}
+
+	err := role.SignChalLog(cl, k.sk)
+	if err != nil {
+		utils.MLogger.Warnf("sign challenge log of %s at provider %s fails: %s", cl.QueryID, cl.ProviderID, err)
+		return
+	}
+
+	data, err := proto.Marshal(cl)
+	if err != nil {
+		return
+	}
+
+	// key: qid/"ChalLog"/uid/pid/kid/chaltime
+	km, err := metainfo.NewKey(cl.QueryID, mpb.KeyType_ChalLog, cl.UserID, cl.ProviderID, k.localID, utils.UnixToString(cl.ChalTime))
+	if err != nil {
+		return
+	}
+
+	k.ds.PutKey(k.context, km.ToString(), data, nil, "local")
+}
+
+// GetChalLogs lists challenge logs of user's group between start and end;
+// proID filters logs of one provider if it is not empty
+func (k *Info) GetChalLogs(userID, qid, proID string, start, end int64) ([]*mpb.ChalLog, error) {
+	ops := []string{userID}
+	if proID != "" {
+		ops = append(ops, proID)
+	}
+
+	km, err := metainfo.NewKey(qid, mpb.KeyType_ChalLog, ops...)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err := k.ds.Itererate(km.ToString())
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*mpb.ChalLog
+	for _, e := range es {
+		rec := new(mpb.Record)
+		err := proto.Unmarshal(e.Value, rec)
+		if err != nil {
+			continue
+		}
+
+		keys := strings.Split(string(rec.GetKey()), metainfo.DELIMITER)
+		if len(keys) != 6 {
+			continue
+		}
+
+		chalTime := utils.StringToUnix(keys[5])
+		if chalTime < start || chalTime > end {
+			continue
+		}
+
+		cl := new(mpb.ChalLog)
+		err = proto.Unmarshal(rec.GetValue(), cl)
+		if err != nil {
+			continue
+		}
+
+		res = append(res, cl)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].GetChalTime() < res[j].GetChalTime()
+	})
+
+	// keep latest ones
+	if len(res) > maxChalLogs {
+		res = res[len(res)-maxChalLogs:]
+	}
+
+	return res, nil
+}
+
+// key: qid/"ChalLog"/uid/start/end
+func (k *Info) handleGetChalLog(km *metainfo.Key) ([]byte, error) {
+	utils.MLogger.Info("handleGetChalLog: ", km.ToString())
+
+	ops := km.GetOptions()
+	if len(ops) != 3 {
+		return nil, role.ErrWrongKey
+	}
+
+	qid := km.GetMainID()
+	if k.getGroupInfo(ops[0], qid, false) == nil {
+		return nil, role.ErrNotMyUser
+	}
+
+	cls, err := k.GetChalLogs(ops[0], qid, "", utils.StringToUnix(ops[1]), utils.StringToUnix(ops[2]))
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&mpb.ChalLogList{Logs: cls})
+}
+
+// pruneChalLogsRegular removes old challenge logs, so that they do not grow without bound
+func (k *Info) pruneChalLogsRegular(ctx context.Context) {
+	ticker := time.NewTicker(chalLogPruneTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			k.pruneChalLogs()
+		}
+	}
+}
+
+// pruneChalLogs removes challenge logs older than chalLogRetention; logs
+// after last spacetime pay are kept for disputes, unless older than chalLogMaxAge
+func (k *Info) pruneChalLogs() {
+	now := time.Now().Unix()
+	for _, qu := range k.getQUKeys() {
+		gp := k.getGroupInfo(qu.uid, qu.qid, false)
+		if gp == nil {
+			continue
+		}
+
+		for _, proID := range gp.providers {
+			before := now - chalLogMaxAge
+			thisLinfo := gp.getLInfo(proID, false)
+			if thisLinfo != nil && thisLinfo.lastPay != nil {
+				before = thisLinfo.lastPay.GetStart() + thisLinfo.lastPay.GetLength()
+				if before < now-chalLogMaxAge {
+					before = now - chalLogMaxAge
+				}
+			}
+			if before > now-chalLogRetention {
+				before = now - chalLogRetention
+			}
+
+			n := k.deleteChalLogs(qu.uid, qu.qid, proID, before)
+			if n > 0 {
+				utils.MLogger.Infof("prune %d challenge logs of %s at provider %s", n, qu.qid, proID)
+			}
+		}
+	}
+}
+
+// deleteChalLogs deletes challenge logs of provider before given time,
+// and returns the number of deleted logs
+func (k *Info) deleteChalLogs(userID, qid, proID string, before int64) int {
+	km, err := metainfo.NewKey(qid, mpb.KeyType_ChalLog, userID, proID)
+	if err != nil {
+		return 0
+	}
+
+	es, err := k.ds.Itererate(km.ToString())
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, e := range es {
+		rec := new(mpb.Record)
+		err := proto.Unmarshal(e.Value, rec)
+		if err != nil {
+			continue
+		}
+
+		keys := strings.Split(string(rec.GetKey()), metainfo.DELIMITER)
+		if len(keys) != 6 || utils.StringToUnix(keys[5]) >= before {
+			continue
+		}
+
+		err = k.ds.DeleteKey(k.context, string(rec.GetKey()), "local")
+		if err != nil {
+			utils.MLogger.Info("Delete challenge log error: ", err)
+			continue
+		}
+		count++
+	}
+
+	return count
+}
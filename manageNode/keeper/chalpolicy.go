@@ -0,0 +1,273 @@
+package keeper
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// ChalPolicyAdaptive chooses challenge policy and sample size for each provider by its risk
+const ChalPolicyAdaptive = "adaptive"
+
+const (
+	riskHigh = 5 // challenge data every time with large sample
+	riskLow  = 2 // below this, challenge less often with small sample
+
+	chalProbHigh   = 0.99
+	chalProbNormal = 0.9
+	chalProbLow    = 0.6
+
+	chalLowInterval = int64(15 * 60) // least interval of challenges for low risk provider
+)
+
+var (
+	// ChalPolicy is the challenge policy of keeper:
+	// "adaptive" scales policy and sample size with provider risk;
+	// "smart" alternates data and meta challenges;
+	// a chunk count such as "200" or a percentage such as "5%" always challenges data this way
+	ChalPolicy = ChalPolicyAdaptive
+	// ChalFaultRate is the fraction of lost chunks which challenges are sized to detect
+	ChalFaultRate = 0.01
+)
+
+var errSkipChallenge = errors.New("skip challenge this round")
+
+// SetChalPolicy validates and sets keeper's challenge policy
+func SetChalPolicy(policy string) error {
+	if policy != ChalPolicyAdaptive {
+		_, ok := role.GetChalNum(policy, 0)
+		if !ok || policy == role.ChalPolicyMeta {
+			return role.ErrInvalidInput
+		}
+	}
+
+	ChalPolicy = policy
+	return nil
+}
+
+// chalStat records the plan of last challenge
+type chalStat struct {
+	policy  string
+	risk    int
+	chalNum uint
+	total   uint
+	prob    float64 // probability of detecting ChalFaultRate lost chunks
+	time    int64
+}
+
+// getChalRisk evaluates risk of provider on this group:
+// low credit, unrepaired fault blocks and new data since last data challenge raise it
+func (k *Info) getChalRisk(l *lInfo, proID string) int {
+	risk := riskHigh
+	proInfo, ok := k.providers.Load(proID)
+	if ok {
+		// credit is in [-100, 100]
+		risk = (100 - proInfo.(*pInfo).credit) / 25
+	}
+
+	faults := 0
+	l.faultCid.Range(func(key, value interface{}) bool {
+		faults++
+		return false
+	})
+
+	if faults > 0 {
+		risk += riskHigh
+	}
+
+	l.RLock()
+	grown := l.maxlength > l.chalLength
+	l.RUnlock()
+	if grown {
+		risk += riskLow
+	}
+
+	return risk
+}
+
+// dataChunks returns the number of data chunks of buckets stored on this provider
+func (l *lInfo) dataChunks() uint {
+	count := uint(0)
+	l.blockMap.Range(func(key, value interface{}) bool {
+		if !strings.HasPrefix(key.(string), "-") && !strings.HasPrefix(key.(string), "0"+metainfo.BlockDelimiter) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// genChallenge generates challenge for provider according to ChalPolicy
+func (k *Info) genChallenge(g *groupInfo, proID string, rootTime, tick int64) (string, []byte, error) {
+	thisLinfo := g.getLInfo(proID, false)
+	if thisLinfo == nil {
+		return "", nil, role.ErrNotMyProvider
+	}
+
+	risk := k.getChalRisk(thisLinfo, proID)
+	total := thisLinfo.dataChunks()
+
+	meta := false
+	prob := 0.0
+	policy := ChalPolicy
+	switch ChalPolicy {
+	case ChalPolicyAdaptive:
+		lastPolicy := ""
+		thisLinfo.RLock()
+		if thisLinfo.chalStat != nil {
+			lastPolicy = thisLinfo.chalStat.policy
+		}
+		thisLinfo.RUnlock()
+
+		switch {
+		case risk >= riskHigh:
+			prob = chalProbHigh
+		case risk >= riskLow:
+			prob = chalProbNormal
+			meta = lastPolicy != role.ChalPolicyMeta
+		default:
+			if time.Now().Unix()-thisLinfo.lastChalTime < chalLowInterval {
+				return "", nil, errSkipChallenge
+			}
+			prob = chalProbLow
+			meta = lastPolicy != role.ChalPolicyMeta
+		}
+
+		chalNum := role.GetChalNumForProb(prob, ChalFaultRate)
+		if chalNum > total {
+			chalNum = total
+		}
+		if chalNum == 0 {
+			chalNum = 1
+		}
+		policy = strconv.FormatUint(uint64(chalNum), 10)
+	case role.ChalPolicySmart:
+		meta = tick%2 != 0
+	default:
+	}
+
+	// sealed replicas are proved in time only if they are stored, not
+	// regenerated on the fly, when enough chunks are challenged
+	if g.hasSealedBucket() {
+		chalNum, _ := role.GetChalNum(policy, total)
+		if chalNum < sealChalMin && chalNum < total {
+			chalNum = sealChalMin
+			if chalNum > total {
+				chalNum = total
+			}
+			policy = strconv.FormatUint(uint64(chalNum), 10)
+		}
+	}
+
+	var key string
+	var value []byte
+	var err error
+	if meta {
+		key, value, err = g.genChallengeMeta(k.localID, g.userID, g.groupID, proID, rootTime)
+		// no meta, challenge data instead
+		if err == role.ErrEmptyData {
+			meta = false
+		}
+	}
+
+	if !meta {
+		key, value, err = g.genChallengeData(k.localID, g.userID, g.groupID, proID, rootTime, policy)
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	// key: qid/"Challenge"/uid/pid/kid/chaltime
+	km, err := metainfo.NewKeyFromString(key)
+	if err != nil {
+		return "", nil, err
+	}
+	ops := km.GetOptions()
+
+	stat := &chalStat{
+		policy: policy,
+		risk:   risk,
+		total:  total,
+		time:   utils.StringToUnix(ops[len(ops)-1]),
+	}
+
+	if meta {
+		stat.policy = role.ChalPolicyMeta
+	} else {
+		stat.chalNum, _ = role.GetChalNum(policy, total)
+		if stat.chalNum > total {
+			stat.chalNum = total
+		}
+		stat.prob = role.DetectProb(stat.chalNum, total, ChalFaultRate)
+	}
+
+	thisLinfo.Lock()
+	if !meta {
+		thisLinfo.chalLength = thisLinfo.maxlength
+	}
+	thisLinfo.chalStat = stat
+	thisLinfo.Unlock()
+
+	utils.MLogger.Infof("Challenge %s for user %s fsID %s at provider %s with risk %d", stat.policy, g.userID, g.groupID, proID, risk)
+
+	return key, value, nil
+}
+
+// GetChallengeStats lists plan of last challenge of each provider, grouped by user group
+func (k *Info) GetChallengeStats() ([]string, error) {
+	var res []string
+	qus := k.getQUKeys()
+	sort.Slice(qus, func(i, j int) bool {
+		return qus[i].qid < qus[j].qid
+	})
+
+	for _, qu := range qus {
+		gp := k.getGroupInfo(qu.uid, qu.qid, false)
+		if gp == nil {
+			continue
+		}
+
+		res = append(res, "user: "+qu.uid+", fsID: "+qu.qid)
+		for _, proID := range gp.providers {
+			thisLinfo := gp.getLInfo(proID, false)
+			if thisLinfo == nil {
+				continue
+			}
+
+			thisLinfo.RLock()
+			stat := thisLinfo.chalStat
+			thisLinfo.RUnlock()
+			if stat == nil {
+				continue
+			}
+
+			var buf strings.Builder
+			buf.WriteString("  provider: ")
+			buf.WriteString(proID)
+			buf.WriteString(", risk: ")
+			buf.WriteString(strconv.Itoa(stat.risk))
+			buf.WriteString(", policy: ")
+			buf.WriteString(stat.policy)
+			if stat.policy != role.ChalPolicyMeta {
+				buf.WriteString(", challenged: ")
+				buf.WriteString(strconv.FormatUint(uint64(stat.chalNum), 10))
+				buf.WriteString("/")
+				buf.WriteString(strconv.FormatUint(uint64(stat.total), 10))
+				buf.WriteString(", detectProb: ")
+				buf.WriteString(strconv.FormatFloat(stat.prob, 'f', 4, 64))
+			}
+			buf.WriteString(", time: ")
+			buf.WriteString(time.Unix(stat.time, 0).Format(utils.SHOWTIME))
+			res = append(res, buf.String())
+		}
+	}
+
+	return res, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/memoio/go-mefs/crypto/pdp"
+	df "github.com/memoio/go-mefs/data-format"
 	mpb "github.com/memoio/go-mefs/pb"
 	"github.com/memoio/go-mefs/role"
 	"github.com/memoio/go-mefs/utils/metainfo"
@@ -23,6 +24,13 @@ const (
 	kpMapTime        = 11 * time.Minute
 
 	payInternval = int64(2 * 60 * 60)
+
+	sealProofTimeout = df.SealProofTimeout //密封副本的挑战须在此时间内返回证明，单位：秒
+	sealChalMin      = df.SealChalMin      //密封副本至少挑战的块数，多核并行重新生成这些segment的时间也超过sealProofTimeout
+
+	ukReloadInterval = int64(60 * 60)      //upkeeping过期后，每隔此时间重新加载，以发现用户续期，单位：秒
+	windDownTime     = time.Hour           //检查upkeeping是否过了宽限期的周期
+	settleTimeout    = int64(24 * 60 * 60) //宽限期后，最多再等待此时间完成最后的时空支付，单位：秒
 )
 
 // MarketingMoney is used to post price
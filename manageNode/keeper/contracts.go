@@ -2,12 +2,14 @@ package keeper
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/gogo/protobuf/proto"
 	"github.com/memoio/go-mefs/contracts"
 	id "github.com/memoio/go-mefs/crypto/identity"
 	mpb "github.com/memoio/go-mefs/pb"
@@ -96,6 +98,131 @@ func (g *groupInfo) loadContracts(mode bool) error {
 	return nil
 }
 
+// getCapacity returns capacity(MB) of upkeeping, including capacity resized
+// in place by user, which cannot be changed on chain
+func (g *groupInfo) getCapacity() int64 {
+	if g.upkeeping == nil {
+		return 0
+	}
+
+	rec := g.ukRecord
+	if rec != nil && rec.GetUpKeepingID() == g.upkeeping.UpKeepingID && rec.GetCapacity() > g.upkeeping.Capacity {
+		return rec.GetCapacity()
+	}
+	return g.upkeeping.Capacity
+}
+
+// getGracePeriod returns seconds of keeping data after upkeeping ends, which is
+// set by user in renew record, so that all keepers of group apply the same one
+func (g *groupInfo) getGracePeriod() int64 {
+	if g.ukRecord == nil || g.ukRecord.GetGracePeriod() <= 0 {
+		return utils.DefaultGracePeriod * 24 * 60 * 60
+	}
+	return g.ukRecord.GetGracePeriod()
+}
+
+// setUkRecord accepts renew record of user if money left in upkeeping pays
+// its capacity till end and its grace period is not too long
+func (g *groupInfo) setUkRecord(rec *mpb.UpKeepingRecord) error {
+	if g.upkeeping == nil || rec.GetUpKeepingID() != g.upkeeping.UpKeepingID {
+		return role.ErrWrongValue
+	}
+
+	if rec.GetGracePeriod() > utils.MaxGracePeriod*24*60*60 {
+		return role.ErrWrongValue
+	}
+
+	if rec.GetCapacity() > g.upkeeping.Capacity {
+		days := (g.upkeeping.EndTime - time.Now().Unix()) / (24 * 60 * 60)
+		left := new(big.Int).Sub(g.upkeeping.Money, g.upkeeping.NeedPay)
+		if left.Cmp(role.GetStoreCost(g.upkeeping.Price, rec.GetCapacity(), days)) < 0 {
+			return role.ErrWrongMoney
+		}
+	}
+
+	g.ukRecord = rec
+	return nil
+}
+
+// handleUkRecord sets capacity resized in place and grace period by user;
+// key: queryID/"Renew"/userID, value: UpKeepingRecord
+func (k *Info) handleUkRecord(km *metainfo.Key, metaValue, sig []byte) {
+	ops := km.GetOptions()
+	if len(ops) != 1 || !k.ds.VerifyKey(k.context, km.ToString(), metaValue, sig) {
+		return
+	}
+
+	gp := k.getGroupInfo(ops[0], km.GetMainID(), false)
+	if gp == nil {
+		return
+	}
+
+	rec := new(mpb.UpKeepingRecord)
+	err := proto.Unmarshal(metaValue, rec)
+	if err != nil {
+		return
+	}
+
+	// money is topped up just before
+	err = gp.loadContracts(true)
+	if err != nil {
+		return
+	}
+
+	err = gp.setUkRecord(rec)
+	if err != nil {
+		utils.MLogger.Warnf("Renew record of user %s fsID %s with capacity %d MB and grace period %ds is refused: %s", gp.userID, gp.groupID, rec.GetCapacity(), rec.GetGracePeriod(), err)
+		return
+	}
+
+	k.ds.PutKey(k.context, km.ToString(), metaValue, sig, "local")
+	utils.MLogger.Infof("Capacity of user %s fsID %s is %d MB, grace period is %ds", gp.userID, gp.groupID, gp.getCapacity(), gp.getGracePeriod())
+}
+
+// loadUkRecord loads renew record of user stored by handleUkRecord
+func (k *Info) loadUkRecord(gp *groupInfo) {
+	km, err := metainfo.NewKey(gp.groupID, mpb.KeyType_Renew, gp.userID)
+	if err != nil {
+		return
+	}
+
+	val, err := k.ds.GetKey(k.context, km.ToString(), "local")
+	if err != nil {
+		return
+	}
+
+	rec := new(mpb.UpKeepingRecord)
+	if proto.Unmarshal(val, rec) == nil {
+		gp.setUkRecord(rec)
+	}
+}
+
+// isExpired checks whether upkeeping of group is expired; an expired upkeeping
+// is reloaded once in a while, since user may renew or resize it
+func (g *groupInfo) isExpired() bool {
+	now := time.Now().Unix()
+	if g.upkeeping.EndTime >= now {
+		return false
+	}
+
+	if now-g.ukLoadTime < ukReloadInterval {
+		return true
+	}
+	g.ukLoadTime = now
+
+	err := g.loadContracts(true)
+	if err != nil {
+		return true
+	}
+
+	if g.upkeeping.EndTime < now {
+		return true
+	}
+
+	utils.MLogger.Infof("upkeeping of user %s fsID %s is renewed to %d", g.userID, g.groupID, g.upkeeping.EndTime)
+	return false
+}
+
 func (k *Info) loadContract(mode bool) error {
 	if k.kItem == nil || mode {
 		kItem, err := role.GetKeeperInfo(k.localID, k.localID)
@@ -122,6 +249,7 @@ func (k *Info) loadContract(mode bool) error {
 			if err != nil {
 				return err
 			}
+			role.RecordBill(k.localID, role.BillPledge, price, false, "", "", "pledge keeper")
 		}
 
 		kItem, err = role.GetKeeperInfo(k.localID, k.localID)
@@ -309,6 +437,7 @@ func (k *Info) getIncome(localAddr common.Address, pBlock int64) (int64, error)
 			}
 
 			k.ManageIncome.Add(k.ManageIncome, mIncome)
+			role.RecordBill(k.localID, role.BillSpaceTime, mIncome, true, "", "", fmt.Sprintf("manage income in blocks %d-%d", startBlock, endBlock))
 			startBlock = endBlock
 
 			if endBlock == latestBlock {
@@ -339,6 +468,7 @@ func (k *Info) getIncome(localAddr common.Address, pBlock int64) (int64, error)
 			}
 
 			k.PostIncome.Add(k.PostIncome, postMIncome)
+			role.RecordBill(k.localID, role.BillSpaceTime, postMIncome, true, "", "", fmt.Sprintf("post income in blocks %d-%d", postStartBlock, endBlock))
 			postStartBlock = endBlock
 
 			if endBlock == latestBlock {
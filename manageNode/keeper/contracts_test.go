@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+)
+
+func TestSetUkRecord(t *testing.T) {
+	price := big.NewInt(utils.STOREPRICE)
+	g := &groupInfo{
+		upkeeping: &role.UpKeepingItem{
+			UpKeepingID: "uk1",
+			Capacity:    100,
+			Price:       price,
+			EndTime:     time.Now().Unix() + 10*24*60*60 + 60,
+			Money:       role.GetStoreCost(price, 200, 10),
+			NeedPay:     big.NewInt(0),
+		},
+	}
+
+	if g.setUkRecord(&mpb.UpKeepingRecord{UpKeepingID: "uk0", Capacity: 200}) == nil || g.getCapacity() != 100 {
+		t.Fatal("record of old upkeeping is accepted")
+	}
+
+	// money left does not pay for capacity till end
+	if g.setUkRecord(&mpb.UpKeepingRecord{UpKeepingID: "uk1", Capacity: 300}) == nil || g.getCapacity() != 100 {
+		t.Fatal("record which is not paid is accepted")
+	}
+
+	err := g.setUkRecord(&mpb.UpKeepingRecord{UpKeepingID: "uk1", Capacity: 200})
+	if err != nil || g.getCapacity() != 200 {
+		t.Fatal("resized capacity is not accepted: ", err, g.getCapacity())
+	}
+
+	if g.getGracePeriod() != utils.DefaultGracePeriod*24*60*60 {
+		t.Fatal("grace period is not default: ", g.getGracePeriod())
+	}
+
+	if g.setUkRecord(&mpb.UpKeepingRecord{UpKeepingID: "uk1", Capacity: 200, GracePeriod: (utils.MaxGracePeriod + 1) * 24 * 60 * 60}) == nil {
+		t.Fatal("too long grace period is accepted")
+	}
+
+	err = g.setUkRecord(&mpb.UpKeepingRecord{UpKeepingID: "uk1", Capacity: 200, GracePeriod: 60})
+	if err != nil || g.getGracePeriod() != 60 {
+		t.Fatal("grace period of user is not used: ", err, g.getGracePeriod())
+	}
+
+	// record is dropped with a new upkeeping
+	g.upkeeping = &role.UpKeepingItem{UpKeepingID: "uk2", Capacity: 150}
+	if g.getCapacity() != 150 {
+		t.Fatal("capacity of old upkeeping is used: ", g.getCapacity())
+	}
+}
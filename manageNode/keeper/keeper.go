@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"context"
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
@@ -159,6 +160,8 @@ func (k *Info) Start(ctx context.Context, opts interface{}) error {
 	go k.stPayRegular(ctx)
 	go k.checkPeers(ctx) //check if connect
 	go k.getFromChainRegular(ctx)
+	go k.windDownRegular(ctx)
+	go k.pruneChalLogsRegular(ctx)
 
 	k.state = true
 	utils.MLogger.Info("Keeper Service is ready")
@@ -841,6 +844,7 @@ func (k *Info) createGroup(uid, qid string, keepers, providers []string) (*group
 		}
 
 		gInfo.loadContracts(false)
+		k.loadUkRecord(gInfo)
 
 		k.loadUserBucket(uid, qid)
 		k.loadUserBucketStripes(uid, qid)
@@ -920,6 +924,17 @@ func (k *Info) deleteGroup(ctx context.Context, qid string) {
 	}
 
 	utils.MLogger.Info(qid, " is a test userID, clean its data")
+	k.deleteGroupBlocks(ctx, thisGroup, true)
+
+	// delete group
+	k.ms.groupNum.Dec()
+	k.ukpGroup.Delete(qid)
+}
+
+// deleteGroupBlocks deletes meta of blocks in group; providers are asked to
+// delete blocks too if toProviders is set
+func (k *Info) deleteGroupBlocks(ctx context.Context, thisGroup *groupInfo, toProviders bool) {
+	qid := thisGroup.groupID
 	for _, proID := range thisGroup.providers {
 		thisLinfo := thisGroup.getLInfo(proID, false)
 		if thisLinfo == nil {
@@ -928,15 +943,17 @@ func (k *Info) deleteGroup(ctx context.Context, qid string) {
 
 		thisLinfo.blockMap.Range(func(key, value interface{}) bool {
 			blockID := qid + metainfo.BlockDelimiter + key.(string)
-			utils.MLogger.Info("Delete testUser block: ", blockID)
+			utils.MLogger.Info("Delete block: ", blockID)
 			//先通知Provider删除块
-			km, err := metainfo.NewKey(blockID, mpb.KeyType_Block)
-			if err != nil {
-				return false
-			}
-			err = k.ds.DeleteBlock(ctx, km.ToString(), proID)
-			if err != nil {
-				utils.MLogger.Info("Delete testUser block: ", blockID, " error:", err)
+			if toProviders {
+				km, err := metainfo.NewKey(blockID, mpb.KeyType_Block)
+				if err != nil {
+					return false
+				}
+				err = k.ds.DeleteBlock(ctx, km.ToString(), proID)
+				if err != nil {
+					utils.MLogger.Info("Delete block: ", blockID, " error:", err)
+				}
 			}
 
 			kmBlock, err := metainfo.NewKey(blockID, mpb.KeyType_BlockPos)
@@ -952,11 +969,9 @@ func (k *Info) deleteGroup(ctx context.Context, qid string) {
 
 			return true
 		})
-	}
 
-	// delete group
-	k.ms.groupNum.Dec()
-	k.ukpGroup.Delete(qid)
+		k.deleteChalLogs(thisGroup.userID, qid, proID, math.MaxInt64)
+	}
 }
 
 /*====================Block Meta Ops=========================*/
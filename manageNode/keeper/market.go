@@ -0,0 +1,190 @@
+package keeper
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// handleMarket returns offers of providers known by this keeper,
+// key: userID/"Market"/userID;
+// or cheapest eligible placement for a new lfs within budget(wei, 0 is unlimited),
+// key: userID/"Market"/userID/capacity/duration/providercount/datacount/paritycount/budget
+func (k *Info) handleMarket(km *metainfo.Key) ([]byte, error) {
+	utils.MLogger.Info("handleMarket: ", km.ToString())
+	options := km.GetOptions()
+	switch len(options) {
+	case 1:
+		return proto.Marshal(k.getMarket())
+	case 7:
+		capacity, err := strconv.ParseInt(options[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		duration, err := strconv.ParseInt(options[2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		pc, err := strconv.Atoi(options[3])
+		if err != nil {
+			return nil, err
+		}
+		dc, err := strconv.ParseInt(options[4], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		parity, err := strconv.ParseInt(options[5], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		budget, ok := new(big.Int).SetString(options[6], 10)
+		if !ok {
+			return nil, role.ErrInvalidInput
+		}
+		if capacity <= 0 || duration <= 0 || pc <= 0 || dc <= 0 || parity < 0 || budget.Sign() < 0 {
+			return nil, role.ErrInvalidInput
+		}
+		if budget.Sign() == 0 {
+			budget = nil
+		}
+		quote, err := k.quotePlacement(capacity, duration, pc, storedSize(capacity, dc, parity), budget)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(quote)
+	default:
+		return nil, metainfo.ErrIllegalKey
+	}
+}
+
+// storedSize returns MB stored by providers for capacity(MB) of data, which is
+// encoded into dc data and parity chunks
+func storedSize(capacity, dc, parity int64) int64 {
+	return (capacity*(dc+parity) + dc - 1) / dc
+}
+
+func (k *Info) getMarket() *mpb.Market {
+	market := new(mpb.Market)
+	k.providers.Range(func(key, value interface{}) bool {
+		thisP := value.(*pInfo)
+		thisP.RLock()
+		defer thisP.RUnlock()
+		if thisP.offerItem == nil || thisP.offerItem.Price == nil {
+			return true
+		}
+		market.Offers = append(market.Offers, &mpb.MarketOffer{
+			ProviderID: thisP.providerID,
+			OfferID:    thisP.offerItem.OfferID,
+			Capacity:   thisP.offerItem.Capacity,
+			Duration:   thisP.offerItem.Duration,
+			Price:      thisP.offerItem.Price.Bytes(),
+			Credit:     int64(thisP.credit),
+			Location:   thisP.eAddr,
+			Online:     thisP.online,
+		})
+		return true
+	})
+	return market
+}
+
+// providerOffer is offer and credit of a provider, taken under its lock
+type providerOffer struct {
+	providerID string
+	price      *big.Int
+	capacity   int64 // MB
+	duration   int64 // second
+	credit     int
+}
+
+// eligibleProviders returns online providers whose offer price is not more
+// than price, ordered by orderProviders
+func (k *Info) eligibleProviders(price *big.Int) []providerOffer {
+	var res []providerOffer
+	k.providers.Range(func(key, value interface{}) bool {
+		thisP := value.(*pInfo)
+		thisP.RLock()
+		defer thisP.RUnlock()
+		if thisP.providerID == k.localID || !thisP.online || thisP.offerItem == nil || thisP.offerItem.Price == nil {
+			return true
+		}
+		if price != nil && thisP.offerItem.Price.Cmp(price) > 0 {
+			utils.MLogger.Debugf("provider %s need price %d, but %d; has credit: %d", thisP.providerID, thisP.offerItem.Price, price, thisP.credit)
+			return true
+		}
+		res = append(res, providerOffer{
+			providerID: thisP.providerID,
+			price:      new(big.Int).Set(thisP.offerItem.Price),
+			capacity:   thisP.offerItem.Capacity,
+			duration:   thisP.offerItem.Duration,
+			credit:     thisP.credit,
+		})
+		return true
+	})
+
+	orderProviders(res)
+	return res
+}
+
+// orderProviders sorts offers cheapest first; providers with the same price
+// are sorted by credit, then by id
+func orderProviders(offers []providerOffer) {
+	sort.Slice(offers, func(i, j int) bool {
+		c := offers[i].price.Cmp(offers[j].price)
+		if c != 0 {
+			return c < 0
+		}
+		if offers[i].credit != offers[j].credit {
+			return offers[i].credit > offers[j].credit
+		}
+		return offers[i].providerID < offers[j].providerID
+	})
+}
+
+// quotePlacement chooses the cheapest pc providers and up to pc spares with
+// positive credit whose offer covers capacity(MB) and duration(day); price of
+// lfs is the highest offer price of them, so that spares accept it too, and cost
+// pays for size(MB) stored by all providers. With budget, spares whose price
+// makes cost over it are left out.
+func (k *Info) quotePlacement(capacity, duration int64, pc int, size int64, budget *big.Int) (*mpb.MarketQuote, error) {
+	var chosen []providerOffer
+	for _, po := range k.eligibleProviders(nil) {
+		if len(chosen) >= 2*pc {
+			break
+		}
+		if po.credit <= 0 || po.capacity < capacity || po.duration < duration*24*60*60 {
+			continue
+		}
+		// offers are sorted by price, so are costs of the rest
+		if len(chosen) >= pc && budget != nil && role.GetStoreCost(po.price, size, duration).Cmp(budget) > 0 {
+			break
+		}
+		chosen = append(chosen, po)
+	}
+
+	if len(chosen) < pc {
+		utils.MLogger.Infof("No enough eligible providers for capacity %d MB and duration %d days, want %d, have %d", capacity, duration, pc, len(chosen))
+		return nil, role.ErrNotEnoughProvider
+	}
+
+	price := chosen[len(chosen)-1].price
+	cost := role.GetStoreCost(price, size, duration)
+	if budget != nil && cost.Cmp(budget) > 0 {
+		utils.MLogger.Infof("Cheapest placement of %d providers costs %d, more than budget %d", pc, cost, budget)
+		return nil, role.ErrWrongMoney
+	}
+
+	quote := &mpb.MarketQuote{
+		Price: price.Bytes(),
+		Cost:  cost.Bytes(),
+	}
+	for _, po := range chosen {
+		quote.Providers = append(quote.Providers, po.providerID)
+	}
+	return quote, nil
+}
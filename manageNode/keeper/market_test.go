@@ -0,0 +1,129 @@
+package keeper
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+)
+
+func addTestProvider(k *Info, proID string, price, capacity, days int64, credit int, online bool) {
+	k.providers.Store(proID, &pInfo{
+		providerID: proID,
+		credit:     credit,
+		online:     online,
+		offerItem: &role.OfferItem{
+			ProviderID: proID,
+			Capacity:   capacity,
+			Duration:   days * 24 * 60 * 60,
+			Price:      big.NewInt(price),
+		},
+	})
+}
+
+func TestOrderProviders(t *testing.T) {
+	offers := []providerOffer{
+		{providerID: "p1", price: big.NewInt(30), credit: 1},
+		{providerID: "p2", price: big.NewInt(10), credit: 1},
+		{providerID: "p3", price: big.NewInt(20), credit: 5},
+		{providerID: "p4", price: big.NewInt(20), credit: 9},
+		{providerID: "p5", price: big.NewInt(10), credit: 1},
+	}
+
+	orderProviders(offers)
+
+	var got []string
+	for _, po := range offers {
+		got = append(got, po.providerID)
+	}
+
+	want := []string{"p2", "p5", "p4", "p3", "p1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatal("providers are ordered as ", got, ", want ", want)
+	}
+}
+
+func TestQuotePlacement(t *testing.T) {
+	utils.StartLogger()
+
+	k := &Info{localID: "k1"}
+	addTestProvider(k, "p1", 50, 1000, 100, 1, true)
+	addTestProvider(k, "p2", 10, 1000, 100, 1, true)
+	addTestProvider(k, "p3", 30, 1000, 100, 1, true)
+	addTestProvider(k, "p4", 20, 1000, 100, 1, true)
+	addTestProvider(k, "p5", 5, 1000, 100, 1, false) // offline
+	addTestProvider(k, "p6", 5, 1000, 100, 0, true)  // no credit
+	addTestProvider(k, "p7", 5, 10, 100, 1, true)    // small capacity
+	addTestProvider(k, "p8", 5, 1000, 10, 1, true)   // short duration
+	addTestProvider(k, "k1", 1, 1000, 100, 1, true)  // local node
+
+	size := storedSize(100, 3, 2)
+	if size != 167 {
+		t.Fatal("stored size is ", size, ", want 167")
+	}
+
+	quote, err := k.quotePlacement(100, 30, 2, size, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"p2", "p4", "p3", "p1"}
+	if !reflect.DeepEqual(quote.GetProviders(), want) {
+		t.Fatal("quote providers are ", quote.GetProviders(), ", want ", want)
+	}
+
+	// spares accept price of lfs
+	price := new(big.Int).SetBytes(quote.GetPrice())
+	if price.Cmp(big.NewInt(50)) != 0 {
+		t.Fatal("quote price is ", price, ", want 50")
+	}
+
+	cost := new(big.Int).SetBytes(quote.GetCost())
+	if cost.Cmp(role.GetStoreCost(price, size, 30)) != 0 {
+		t.Fatal("quote cost is ", cost)
+	}
+
+	// at most 2*pc providers are quoted
+	quote, err = k.quotePlacement(100, 30, 1, size, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(quote.GetProviders(), []string{"p2", "p4"}) || new(big.Int).SetBytes(quote.GetPrice()).Cmp(big.NewInt(20)) != 0 {
+		t.Fatal("wrong quote for one provider: ", quote)
+	}
+
+	// spares over budget are left out
+	budget := role.GetStoreCost(big.NewInt(30), size, 30)
+	quote, err = k.quotePlacement(100, 30, 2, size, budget)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(quote.GetProviders(), []string{"p2", "p4", "p3"}) || new(big.Int).SetBytes(quote.GetCost()).Cmp(budget) != 0 {
+		t.Fatal("wrong quote within budget: ", quote)
+	}
+
+	_, err = k.quotePlacement(100, 30, 2, size, role.GetStoreCost(big.NewInt(10), size, 30))
+	if err != role.ErrWrongMoney {
+		t.Fatal("quote over budget: ", err)
+	}
+
+	_, err = k.quotePlacement(100, 30, 5, size, nil)
+	if err != role.ErrNotEnoughProvider {
+		t.Fatal("quote without enough providers: ", err)
+	}
+
+	// price limits eligible providers
+	var got []string
+	for _, po := range k.eligibleProviders(big.NewInt(20)) {
+		got = append(got, po.providerID)
+	}
+
+	want = []string{"p7", "p8", "p6", "p2", "p4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatal("eligible providers are ", got, ", want ", want)
+	}
+}
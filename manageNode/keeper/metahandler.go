@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -38,6 +39,10 @@ func (k *Info) HandleMetaMessage(opType mpb.OpType, metaKey string, metaValue, s
 		go k.handleUserInit(km, from)
 	case mpb.KeyType_UserNotify:
 		return k.handleUserNotify(km, metaValue, from)
+	case mpb.KeyType_Market:
+		if opType == mpb.OpType_Get {
+			return k.handleMarket(km)
+		}
 	case mpb.KeyType_UserStart:
 		return k.handleUserStart(km, metaValue, sig, from)
 	case mpb.KeyType_UserStop:
@@ -46,6 +51,10 @@ func (k *Info) HandleMetaMessage(opType mpb.OpType, metaKey string, metaValue, s
 		return k.handleHeartBeat(km, metaValue, from)
 	case mpb.KeyType_Bucket:
 		go k.handleAddBucket(km, metaValue, sig, from)
+	case mpb.KeyType_Renew:
+		if opType == mpb.OpType_Put {
+			go k.handleUkRecord(km, metaValue, sig)
+		}
 	case mpb.KeyType_BlockPos:
 		switch opType {
 		case mpb.OpType_Put:
@@ -59,6 +68,10 @@ func (k *Info) HandleMetaMessage(opType mpb.OpType, metaKey string, metaValue, s
 		if opType == mpb.OpType_Put {
 			go k.handleProof(km, metaValue)
 		}
+	case mpb.KeyType_ChalBatch:
+		if opType == mpb.OpType_Put {
+			go k.handleBatchProof(km, metaValue, from)
+		}
 	case mpb.KeyType_Repair: //provider 修复回复
 		switch opType {
 		case mpb.OpType_Put:
@@ -68,6 +81,10 @@ func (k *Info) HandleMetaMessage(opType mpb.OpType, metaKey string, metaValue, s
 		}
 	case mpb.KeyType_Storage:
 		go k.handleStorage(km, metaValue, from)
+	case mpb.KeyType_LostBlock:
+		if opType == mpb.OpType_Put {
+			go k.handleLostBlock(km, metaValue, from)
+		}
 	case mpb.KeyType_ExternalAddress:
 		switch opType {
 		case mpb.OpType_Put:
@@ -79,6 +96,10 @@ func (k *Info) HandleMetaMessage(opType mpb.OpType, metaKey string, metaValue, s
 		}
 	case mpb.KeyType_ChalTime:
 		return k.handleChalTime(km)
+	case mpb.KeyType_ChalLog:
+		if opType == mpb.OpType_Get {
+			return k.handleGetChalLog(km)
+		}
 	case mpb.KeyType_Pos:
 		switch opType {
 		case mpb.OpType_Put:
@@ -88,6 +109,13 @@ func (k *Info) HandleMetaMessage(opType mpb.OpType, metaKey string, metaValue, s
 		case mpb.OpType_Get:
 			go k.handlePostGet(km, metaValue, from)
 		}
+	case mpb.KeyType_StPayDispute:
+		switch opType {
+		case mpb.OpType_Put:
+			return k.handleSTClaim(km, metaValue, from)
+		case mpb.OpType_Get:
+			return k.handleGetSTLeaves(km, from)
+		}
 	case mpb.KeyType_StPaySign:
 		switch opType {
 		case mpb.OpType_Put:
@@ -185,13 +213,52 @@ func (k *Info) handlePutStPaySign(km *metainfo.Key, metaValue, sig []byte, from
 }
 
 // key is /qid/"Sign"/uid/pid/kid/stStart/length
-// value is hash
+// value is pay without signs of role.STValueVersion; it is not signed if it is
+// less than accepted counter claim of provider. Keepers before it send hash of
+// pay, which is signed as before.
 func (k *Info) handleGetStPaySign(km *metainfo.Key, metaValue, sig []byte, from string) {
 	utils.MLogger.Info("handleGetSign: ", km.ToString())
-	// verify sig first
-	// verify metaValue
-	// sign it
-	nsig, err := id.Sign(k.sk, metaValue)
+	ops := km.GetOptions()
+	if len(ops) < 5 {
+		return
+	}
+
+	gp := k.getGroupInfo(ops[0], km.GetMainID(), false)
+	if gp == nil || gp.upkeeping == nil {
+		return
+	}
+
+	var hash []byte
+	stv := new(mpb.STValue)
+	err := proto.Unmarshal(metaValue, stv)
+	if err != nil || stv.GetVersion() != role.STValueVersion {
+		if len(metaValue) != 32 {
+			return
+		}
+		utils.MLogger.Infof("SpaceTimePay of user %s fsID %s pro %s is signed by hash from %s", gp.userID, gp.groupID, ops[1], from)
+		hash = metaValue
+	} else {
+		if len(stv.GetRoot()) < 32 {
+			return
+		}
+
+		sv := new(big.Int).SetBytes(stv.GetValue())
+		linfo := gp.getLInfo(ops[1], false)
+		if linfo != nil {
+			claim := linfo.getClaim(stv.GetStart(), sv)
+			if claim != nil {
+				utils.MLogger.Infof("SpaceTimePay of user %s fsID %s pro %s from %d value %d is less than its claim %d, refuse to sign", gp.userID, gp.groupID, ops[1], stv.GetStart(), sv, new(big.Int).SetBytes(claim.GetValue()))
+				return
+			}
+		}
+
+		hash, err = role.GetHashForST(gp.upkeeping.UpKeepingID, ops[1], big.NewInt(stv.GetStart()), big.NewInt(stv.GetLength()), sv, stv.GetRoot()[:32], stv.GetShare())
+		if err != nil {
+			return
+		}
+	}
+
+	nsig, err := id.Sign(k.sk, hash)
 	if err != nil {
 		return
 	}
@@ -633,7 +700,7 @@ func (k *Info) handleProQuit(km *metainfo.Key, value []byte, from string) ([]byt
 		k.ds.SendMetaRequest(k.context, int32(mpb.OpType_Put), km.ToString(), nil, nil, from)
 
 		//add provider:1.find a new provider
-		newPro, err := k.findNewProvider(gp.upkeeping.Price, gp.upkeeping.Capacity, gp.upkeeping.Duration, gp.providers)
+		newPro, err := k.findNewProvider(gp.upkeeping.Price, gp.getCapacity(), gp.upkeeping.Duration, gp.providers)
 		if err != nil {
 			utils.MLogger.Error("findNewProvider fails:", err)
 			return nil, err
@@ -6,7 +6,9 @@ import (
 	"strings"
 	"time"
 
+	df "github.com/memoio/go-mefs/data-format"
 	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
 	"github.com/memoio/go-mefs/utils"
 	"github.com/memoio/go-mefs/utils/metainfo"
 	"github.com/memoio/go-mefs/utils/pos"
@@ -39,7 +41,7 @@ func (k *Info) checkLedgerRafi(ctx context.Context) {
 					continue
 				}
 
-				if gp.upkeeping.EndTime < time.Now().Unix() {
+				if gp.isExpired() {
 					utils.MLogger.Infof("Repair for user %s fsID %s upkeeping has expired", pu.uid, pu.qid)
 					continue
 				}
@@ -246,7 +248,7 @@ func (k *Info) checkLedgerRafi(ctx context.Context) {
 						}
 
 						// lost multiple chunks
-						if multipleLost > int(binfo.bops.GetParityCount()/2) {
+						if multipleLost > int(binfo.options(j).GetParityCount()/2) {
 							cInfo, ok := binfo.stripes.Load(repairCid)
 							if ok {
 								res.Reset()
@@ -295,7 +297,7 @@ func (k *Info) checkLedgerV2(ctx context.Context) {
 					continue
 				}
 
-				if gp.upkeeping.EndTime < time.Now().Unix() {
+				if gp.isExpired() {
 					utils.MLogger.Infof("Repair for user %s fsID %s upkeeping has expired", pu.uid, pu.qid)
 					continue
 				}
@@ -577,7 +579,7 @@ func (k *Info) repairBlock(ctx context.Context, rBlockID string) {
 	k.ms.repairNum.Inc()
 	k.ms.faultNum.Inc()
 	var response, oldpid string
-	var offset int
+	var offset, tries int
 	// uid_qid_bid_sid_cid
 	blkinfo := strings.Split(rBlockID, metainfo.BlockDelimiter)
 	if len(blkinfo) < 5 {
@@ -628,6 +630,7 @@ func (k *Info) repairBlock(ctx context.Context, rBlockID string) {
 			}
 			response = pid
 			oldpid = pid
+			tries = thisinfo.(*blockInfo).repair
 			offset = thisinfo.(*blockInfo).offset
 			stripNum, err := strconv.Atoi(blkinfo[3])
 			if err == nil && thisbucket.curStripes > stripNum {
@@ -645,6 +648,35 @@ func (k *Info) repairBlock(ctx context.Context, rBlockID string) {
 		return
 	}
 
+	// with lrc, a lost chunk is repaired from its local group at first try,
+	// if others of the group are fine; retries use all chunks of the stripe
+	chunk, err := strconv.Atoi(blkinfo[4])
+	if err == nil && tries == 1 {
+		stripe, _ := strconv.Atoi(blkinfo[3])
+		group := df.LocalGroup(thisbucket.options(stripe), chunk)
+		local := make([]string, 0, len(group)+1)
+		for _, i := range group {
+			res.Reset()
+			res.WriteString(blkinfo[3])
+			res.WriteString(metainfo.BlockDelimiter)
+			res.WriteString(strconv.Itoa(i))
+			thisinfo, ok := thisbucket.stripes.Load(res.String())
+			if !ok || thisinfo.(*blockInfo).repair != 0 {
+				break
+			}
+			local = append(local, strconv.Itoa(i)+metainfo.BlockDelimiter+thisinfo.(*blockInfo).storedOn)
+		}
+
+		if len(group) > 0 && len(local) == len(group) {
+			for _, cpid := range cpids {
+				if strings.HasPrefix(cpid, blkinfo[4]+metainfo.BlockDelimiter) {
+					local = append(local, cpid)
+				}
+			}
+			cpids = local
+		}
+	}
+
 	credit := 0
 	if len(response) > 0 {
 		proInfo, ok := k.providers.Load(response)
@@ -659,6 +691,12 @@ func (k *Info) repairBlock(ctx context.Context, rBlockID string) {
 	}
 
 	if len(response) == 0 || response == "" || credit < -50 {
+		// sealed replica is bound to its provider, a new provider cannot prove it
+		if df.IsSealed(thisbucket.bops.GetTagFlag()) {
+			utils.MLogger.Infof("Repair %s: sealed chunk waits for its provider %s", rBlockID, oldpid)
+			return
+		}
+
 		utils.MLogger.Info("Repair: need choose a new provider to replace old due to low credit: ", response)
 		response = k.searchNewProvider(ctx, qid, ugid)
 		if response == "" {
@@ -670,6 +708,23 @@ func (k *Info) repairBlock(ctx context.Context, rBlockID string) {
 	// cid1_pid1/cid2_pid2
 	metaValue := strings.Join(cpids, metainfo.DELIMITER)
 
+	// repairer reads other chunks of stripe with read token
+	prefix := strings.Join(blkinfo[1:4], metainfo.BlockDelimiter) + metainfo.BlockDelimiter
+	rbids := make([]string, 0, len(cpids))
+	for _, cpid := range cpids {
+		cid := strings.SplitN(cpid, metainfo.BlockDelimiter, 2)[0]
+		if cid == blkinfo[4] {
+			continue
+		}
+		rbids = append(rbids, prefix+cid)
+	}
+
+	sig, err := role.BuildReadTokenMessage(qid, response, k.localID, k.sk, rbids)
+	if err != nil {
+		utils.MLogger.Info("construct repair read token error: ", err)
+		return
+	}
+
 	km, err := metainfo.NewKey(blockID, mpb.KeyType_Repair, uid, strconv.Itoa(offset))
 	if err != nil {
 		utils.MLogger.Info("construct repair KV error: ", err)
@@ -677,7 +732,7 @@ func (k *Info) repairBlock(ctx context.Context, rBlockID string) {
 	}
 
 	utils.MLogger.Infof("%s has cpids: %s on %s repairs on %s", rBlockID, cpids, oldpid, response)
-	k.ds.SendMetaRequest(k.context, int32(mpb.OpType_Get), km.ToString(), []byte(metaValue), nil, response)
+	k.ds.SendMetaRequest(k.context, int32(mpb.OpType_Get), km.ToString(), []byte(metaValue), sig, response)
 }
 
 // key: queryID_bucketID_stripeID_chunkID/"Repair"/uid
@@ -747,6 +802,57 @@ func (k *Info) handleRepairUpdate(km *metainfo.Key, metaValue []byte, keeper str
 	k.addBlockMeta(qid, bid, newPid, newOffset, true)
 }
 
+// handleLostBlock repairs blocks lost on failed disks or corrupted on provider;
+// key: queryID/"LostBlock"/uid, value: blockID1/blockID2/...
+func (k *Info) handleLostBlock(km *metainfo.Key, metaValue []byte, provider string) {
+	utils.MLogger.Info("handleLostBlock: ", km.ToString(), " From:", provider)
+
+	qid := km.GetMainID()
+	ops := km.GetOptions()
+	if len(ops) < 1 {
+		return
+	}
+
+	uid := ops[0]
+	// not repair post blocks
+	if uid == pos.GetPostId() {
+		return
+	}
+
+	gp := k.getGroupInfo(uid, qid, false)
+	if gp == nil || gp.upkeeping == nil || !gp.status {
+		return
+	}
+
+	// only master repair
+	if !gp.isMaster(qid) || gp.isExpired() {
+		return
+	}
+
+	pre := uid + metainfo.BlockDelimiter
+	for _, blockID := range strings.Split(string(metaValue), metainfo.DELIMITER) {
+		// qid_bid_sid_cid
+		blkinfo := strings.SplitN(blockID, metainfo.BlockDelimiter, 3)
+		if len(blkinfo) < 3 || blkinfo[0] != qid {
+			continue
+		}
+
+		binfo := gp.getBucketInfo(blkinfo[1], false)
+		if binfo == nil {
+			continue
+		}
+
+		thisinfo, ok := binfo.stripes.Load(blkinfo[2])
+		if !ok || thisinfo.(*blockInfo).storedOn != provider {
+			continue
+		}
+
+		utils.MLogger.Info("Need repair cid lost on disk: ", blockID)
+		thisinfo.(*blockInfo).repair++
+		k.repch <- pre + blockID
+	}
+}
+
 //searchNewProvider find a NEW provider for user
 func (k *Info) searchNewProvider(ctx context.Context, gid string, ugid []string) string {
 	response := ""
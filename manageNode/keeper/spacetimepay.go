@@ -1,12 +1,8 @@
 package keeper
 
 import (
-	"bytes"
 	"context"
-	"crypto/sha256"
-	"encoding/gob"
 	"math/big"
-	"sort"
 	"strconv"
 	"time"
 
@@ -19,7 +15,6 @@ import (
 	"github.com/memoio/go-mefs/utils/address"
 	"github.com/memoio/go-mefs/utils/metainfo"
 	"github.com/memoio/go-mefs/utils/pos"
-	mt "gitlab.com/NebulousLabs/merkletree"
 )
 
 func (k *Info) stPrePayRegular(ctx context.Context) {
@@ -167,7 +162,14 @@ func (g *groupInfo) stPrePay(ctx context.Context, proID, localSk, localID string
 			endTime = g.upkeeping.EndTime
 		}
 
-		amount, mroot := thisLinfo.stSummary(price, startTime, endTime)
+		amount, mroot, leaves := thisLinfo.stSummary(price, startTime, endTime)
+		if claim := thisLinfo.getClaim(startTime, amount); claim != nil {
+			utils.MLogger.Infof("SpaceTimePay for user %s fsID %s pro %s from %d uses its claim, value %d is more than %d", g.userID, g.groupID, proID, startTime, new(big.Int).SetBytes(claim.GetValue()), amount)
+			endTime = claim.GetStart() + claim.GetLength()
+			amount = new(big.Int).SetBytes(claim.GetValue())
+			mroot = claim.GetRoot()
+			leaves = nil
+		}
 		chalfrequency := thisLinfo.stShare(startTime, endTime)
 		if amount.Sign() > 0 && len(mroot) >= 32 {
 			needPay := new(big.Int).Add(g.upkeeping.NeedPay, amount)
@@ -188,6 +190,7 @@ func (g *groupInfo) stPrePay(ctx context.Context, proID, localSk, localID string
 					Root:   mroot,
 				},
 				checkNum: 0,
+				leaves:   leaves,
 			}
 
 			thisLinfo.currentPay = cpay
@@ -235,6 +238,12 @@ func (g *groupInfo) stPrePay(ctx context.Context, proID, localSk, localID string
 			if err != nil {
 				return err
 			}
+			cpay.hash = hash
+
+			req, err := cpay.signRequest()
+			if err != nil {
+				return err
+			}
 
 			mkey, err = metainfo.NewKey(g.groupID, mpb.KeyType_StPaySign, g.userID, proID, localID, st.String(), sl.String())
 			if err != nil {
@@ -252,7 +261,7 @@ func (g *groupInfo) stPrePay(ctx context.Context, proID, localSk, localID string
 					cpay.Unlock()
 					continue
 				}
-				go ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), key, hash, sign, kid)
+				go ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), key, req, sign, kid)
 			}
 
 			utils.MLogger.Infof("SpaceTimePay start for user %s fsID %s for provider %s at %d ", g.userID, g.groupID, proID, thisLinfo.currentPay.Start)
@@ -284,6 +293,15 @@ func (g *groupInfo) stPay(ctx context.Context, proID, localSk, localID string, d
 	if thisLinfo.currentPay != nil {
 		cpay := thisLinfo.currentPay
 		cpay.Lock()
+		// other keepers do not sign pay less than accepted claim of provider,
+		// so pay it again with the claim
+		if claim := thisLinfo.getClaim(cpay.Start, new(big.Int).SetBytes(cpay.Value)); claim != nil {
+			utils.MLogger.Infof("SpaceTimePay for user %s fsID %s pro %s from %d is less than its claim, pay again", g.userID, g.groupID, proID, cpay.Start)
+			cpay.Unlock()
+			thisLinfo.currentPay = nil
+			return role.ErrWrongMoney
+		}
+
 		if cpay.Status <= 0 {
 			pAddr, err := address.GetAddressFromID(proID)
 			if err != nil {
@@ -349,11 +367,17 @@ func (g *groupInfo) stPay(ctx context.Context, proID, localSk, localID string, d
 			}
 		}
 
+		req, err := cpay.signRequest()
+		if err != nil {
+			cpay.Unlock()
+			return err
+		}
+
 		for _, kid := range g.keepers {
 			if kid == localID {
 				continue
 			}
-			go ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), key, cpay.hash, sign, kid)
+			go ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), key, req, sign, kid)
 		}
 
 		cpay.Status = int32(len(g.keepers) * 2 / 3)
@@ -364,16 +388,10 @@ func (g *groupInfo) stPay(ctx context.Context, proID, localSk, localID string, d
 	return nil
 }
 
-type timeValue struct {
-	time  int64
-	space int64
-}
-
-// challeng results to spacetime value
+// challeng results to spacetime value, and leaves behind merkle root of it
 // lastTime is the lastest challenge time which is before Now
-func (l *lInfo) stSummary(price *big.Int, start, end int64) (*big.Int, []byte) {
-	spacetime := big.NewInt(0)
-	var tsl []timeValue //用来对挑战时间排序
+func (l *lInfo) stSummary(price *big.Int, start, end int64) (*big.Int, []byte, []*mpb.STLeaf) {
+	var tsl []*mpb.STLeaf //用来对挑战时间排序
 
 	var deletes []int64
 	l.chalMap.Range(func(k, value interface{}) bool {
@@ -383,11 +401,10 @@ func (l *lInfo) stSummary(price *big.Int, start, end int64) (*big.Int, []byte) {
 			deletes = append(deletes, key)
 		} else if key < end {
 			chalres := value.(*mpb.ChalInfo)
-			tv := timeValue{
-				time:  key,
-				space: chalres.SuccessLength,
-			}
-			tsl = append(tsl, tv)
+			tsl = append(tsl, &mpb.STLeaf{
+				Time:  key,
+				Space: chalres.SuccessLength,
+			})
 		}
 
 		return true
@@ -397,102 +414,19 @@ func (l *lInfo) stSummary(price *big.Int, start, end int64) (*big.Int, []byte) {
 		l.chalMap.Delete(d)
 	}
 
-	if len(tsl) <= 1 {
+	leaves := role.FillSTLeaves(tsl, start, end)
+	if len(leaves) == 0 {
 		utils.MLogger.Info("no enough challenge data")
-		return spacetime, nil
-	}
-
-	sort.Slice(tsl, func(i, j int) bool {
-		return tsl[i].time < tsl[j].time
-	})
-
-	var newTsl []timeValue
-
-	if tsl[0].time > start && tsl[0].time < start+3600 {
-		tv := timeValue{
-			time:  start,
-			space: tsl[0].space,
-		}
-		newTsl = append(newTsl, tv)
-	} else {
-		tv := timeValue{
-			time:  start,
-			space: 0,
-		}
-		newTsl = append(newTsl, tv)
-	}
-
-	// at least once per hour
-	ftime := start + 3600
-	i := 0
-	for {
-		if i >= len(tsl) || ftime < tsl[i].time {
-			tv := timeValue{
-				time:  ftime,
-				space: 0,
-			}
-			ftime += 3600
-			newTsl = append(newTsl, tv)
-		} else {
-			newTsl = append(newTsl, tsl[i])
-			ftime = tsl[i].time + 3600
-			i++
-		}
-
-		if ftime > end && i == len(tsl) {
-			break
-		}
+		return big.NewInt(0), nil, nil
 	}
 
-	tLen := len(newTsl)
-
-	if newTsl[tLen-1].time < end && newTsl[tLen-1].time > end-3600 {
-		tv := timeValue{
-			time:  end,
-			space: newTsl[tLen-1].space,
-		}
-		newTsl = append(newTsl, tv)
-	} else {
-		tv := timeValue{
-			time:  end,
-			space: 0,
-		}
-		newTsl = append(newTsl, tv)
-	}
-
-	mtree := mt.New(sha256.New())
-	mtree.SetIndex(0)
-
-	var nbuf bytes.Buffer
-	enc := gob.NewEncoder(&nbuf)
-
-	timepre := newTsl[0].time
-	lengthpre := newTsl[0].space
-	for _, tv := range newTsl[1:] {
-		spacetime.Add(spacetime, big.NewInt((tv.time-timepre)*int64(lengthpre+tv.space)/2))
-		timepre = tv.time
-		lengthpre = tv.space
-		enc.Encode(tv)
-		mtree.Push(nbuf.Bytes())
-	}
-	if spacetime.Sign() <= 0 {
-		utils.MLogger.Info("error spacetime<=0")
-	}
-
-	spacetime.Mul(spacetime, price)
-	spacetime.Quo(spacetime, big.NewInt(1024*1024*60*60))
-
-	stWei := new(big.Float).SetInt(spacetime)
-	stWei.Quo(stWei, contracts.GetMemoPrice())
-	stWei.Int(spacetime)
-
+	spacetime := role.GetSTAmount(price, leaves)
 	if spacetime.Sign() <= 0 {
 		utils.MLogger.Info("error!amount:", spacetime, "price:", price)
 	}
 
 	utils.MLogger.Debug("spacetime  calc is:", spacetime)
-	utils.MLogger.Debug(tsl)
-	return spacetime, mtree.Root()
+	return spacetime, role.GetSTRoot(leaves), leaves
 }
 
 //get challenge frequency
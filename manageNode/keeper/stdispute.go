@@ -0,0 +1,214 @@
+package keeper
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/address"
+	"github.com/memoio/go-mefs/utils/metainfo"
+	"github.com/memoio/go-mefs/utils/pos"
+)
+
+// signRequest returns pay without signs, which is sent to other keepers;
+// they re-verify it before signing
+func (c *chalpay) signRequest() ([]byte, error) {
+	return proto.Marshal(&mpb.STValue{
+		Start:   c.Start,
+		Length:  c.Length,
+		Value:   c.Value,
+		Root:    c.Root,
+		Share:   c.Share,
+		Version: role.STValueVersion,
+	})
+}
+
+// getClaim returns accepted counter claim of provider which starts at start
+// and is more than value
+func (l *lInfo) getClaim(start int64, value *big.Int) *mpb.STClaim {
+	l.RLock()
+	claim := l.claim
+	l.RUnlock()
+	if claim == nil || claim.GetStart() != start {
+		return nil
+	}
+
+	if new(big.Int).SetBytes(claim.GetValue()).Cmp(value) <= 0 {
+		return nil
+	}
+	return claim
+}
+
+// handleGetSTLeaves returns pay proposed by this keeper with its leaves, and
+// challenge logs signed by this keeper in period of pay,
+// key: qid/"StPayDispute"/uid/pid; or logs between start and end,
+// key: qid/"StPayDispute"/uid/pid/start/end
+func (k *Info) handleGetSTLeaves(km *metainfo.Key, from string) ([]byte, error) {
+	utils.MLogger.Info("handleGetSTLeaves: ", km.ToString(), " from: ", from)
+	ops := km.GetOptions()
+	if len(ops) != 2 && len(ops) != 4 {
+		return nil, role.ErrWrongKey
+	}
+
+	qid := km.GetMainID()
+	gp := k.getGroupInfo(ops[0], qid, false)
+	if gp == nil {
+		return nil, role.ErrNotMyUser
+	}
+
+	linfo := gp.getLInfo(ops[1], false)
+	if linfo == nil {
+		return nil, role.ErrNotMyProvider
+	}
+
+	res := new(mpb.STLeaves)
+	var start, end int64
+	if len(ops) == 4 {
+		var err error
+		start, err = strconv.ParseInt(ops[2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		end, err = strconv.ParseInt(ops[3], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	} else if cpay := linfo.currentPay; cpay != nil {
+		cpay.RLock()
+		res.Pay = &mpb.STValue{
+			Start:  cpay.Start,
+			Length: cpay.Length,
+			Value:  cpay.Value,
+			Root:   cpay.Root,
+			Share:  cpay.Share,
+		}
+		res.Leaves = cpay.leaves
+		cpay.RUnlock()
+		start = res.Pay.Start
+		end = res.Pay.Start + res.Pay.Length
+	}
+
+	if end > start {
+		logs, err := k.GetChalLogs(ops[0], qid, ops[1], start, end)
+		if err == nil {
+			res.Logs = logs
+		}
+	}
+
+	return proto.Marshal(res)
+}
+
+// handleSTClaim re-verifies counter claim of provider with challenge logs
+// collected from keepers of group; accepted claim is used for the next pay,
+// and pays less than it are not signed.
+// key: qid/"StPayDispute"/uid/pid, value: STClaim
+func (k *Info) handleSTClaim(km *metainfo.Key, metaValue []byte, from string) ([]byte, error) {
+	utils.MLogger.Info("handleSTClaim: ", km.ToString(), " from: ", from)
+	ops := km.GetOptions()
+	if len(ops) != 2 || ops[1] != from {
+		return nil, role.ErrWrongKey
+	}
+
+	proID := ops[1]
+	gp := k.getGroupInfo(ops[0], km.GetMainID(), false)
+	if gp == nil || gp.upkeeping == nil || gp.userID == pos.GetPostId() {
+		return nil, role.ErrNotMyUser
+	}
+
+	linfo := gp.getLInfo(proID, false)
+	if linfo == nil {
+		return nil, role.ErrNotMyProvider
+	}
+
+	claim := new(mpb.STClaim)
+	err := proto.Unmarshal(metaValue, claim)
+	if err != nil {
+		return nil, err
+	}
+
+	// claim starts from end of last pay
+	found := false
+	for _, pInfo := range gp.upkeeping.Providers {
+		pid, err := address.GetIDFromAddress(pInfo.Addr.String())
+		if err != nil || pid != proID {
+			continue
+		}
+		found = true
+		if pInfo.StEnd.Int64() != claim.GetStart() {
+			return nil, role.ErrWrongValue
+		}
+		break
+	}
+	if !found {
+		return nil, role.ErrNotMyProvider
+	}
+
+	end := claim.GetStart() + claim.GetLength()
+	if end > time.Now().Unix() || end > gp.upkeeping.EndTime {
+		return nil, role.ErrWrongValue
+	}
+
+	logs, err := k.collectChalLogs(gp, proID, claim.GetStart(), end)
+	if err != nil {
+		return nil, err
+	}
+
+	err = role.VerifySTClaim(claim, logs, gp.keepers, gp.groupID, proID, gp.upkeeping.Price)
+	if err != nil {
+		utils.MLogger.Infof("Counter claim of provider %s for user %s fsID %s from %d is wrong: %s", proID, gp.userID, gp.groupID, claim.GetStart(), err)
+		return nil, err
+	}
+
+	linfo.Lock()
+	linfo.claim = claim
+	linfo.Unlock()
+	utils.MLogger.Infof("Accept counter claim of provider %s for user %s fsID %s from %d, length %d value %d", proID, gp.userID, gp.groupID, claim.GetStart(), claim.GetLength(), new(big.Int).SetBytes(claim.GetValue()))
+
+	return []byte("ok"), nil
+}
+
+// collectChalLogs gets challenge logs of provider between start and end from
+// this keeper and other keepers of group, so that provider cannot choose logs
+// of its claim; more than half of keepers should reply
+func (k *Info) collectChalLogs(gp *groupInfo, proID string, start, end int64) ([]*mpb.ChalLog, error) {
+	logs, err := k.GetChalLogs(gp.userID, gp.groupID, proID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	km, err := metainfo.NewKey(gp.groupID, mpb.KeyType_StPayDispute, gp.userID, proID, strconv.FormatInt(start, 10), strconv.FormatInt(end, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	replied := 1
+	for _, kid := range gp.keepers {
+		if kid == k.localID {
+			continue
+		}
+
+		res, err := k.ds.SendMetaRequest(k.context, int32(mpb.OpType_Get), km.ToString(), nil, nil, kid)
+		if err != nil {
+			utils.MLogger.Infof("Get challenge logs of provider %s from keeper %s fails: %s", proID, kid, err)
+			continue
+		}
+
+		stl := new(mpb.STLeaves)
+		err = proto.Unmarshal(res, stl)
+		if err != nil {
+			continue
+		}
+		logs = append(logs, stl.GetLogs()...)
+		replied++
+	}
+
+	if replied*2 <= len(gp.keepers) {
+		utils.MLogger.Infof("Challenge logs of provider %s for user %s fsID %s are from %d keepers, want more than half of %d", proID, gp.userID, gp.groupID, replied, len(gp.keepers))
+		return nil, role.ErrNotEnoughKeeper
+	}
+	return logs, nil
+}
@@ -34,6 +34,8 @@ type groupInfo struct {
 	providers    []string
 	rootID       string
 	upkeeping    *role.UpKeepingItem
+	ukRecord     *mpb.UpKeepingRecord // capacity resized in place and grace period set by user
+	ukLoadTime   int64 // last time of reloading expired upkeeping
 	query        *role.QueryItem
 	bucketNum    int64    // largest bucketID
 	buckets      sync.Map // key:bucketID(string); value: *bucketInfo
@@ -197,6 +199,19 @@ func (g *groupInfo) getBucketInfo(bucketID string, mode bool) *bucketInfo {
 	return thisIb.(*bucketInfo)
 }
 
+// hasSealedBucket reports whether replicas of some bucket are sealed
+func (g *groupInfo) hasSealedBucket() bool {
+	sealed := false
+	g.buckets.Range(func(key, value interface{}) bool {
+		if df.IsSealed(value.(*bucketInfo).bops.GetTagFlag()) {
+			sealed = true
+			return false
+		}
+		return true
+	})
+	return sealed
+}
+
 func (g *groupInfo) addBucket(bucketID string, binfo *mpb.BucketInfo) error {
 	bucketNum, err := strconv.ParseInt(bucketID, 10, 0)
 	if err != nil {
@@ -215,6 +230,16 @@ func (g *groupInfo) addBucket(bucketID string, binfo *mpb.BucketInfo) error {
 	thisBucket.bops = binfo.GetBOpts()
 	thisBucket.chunkNum = int(binfo.GetBOpts().GetDataCount() + binfo.GetBOpts().GetParityCount())
 
+	// stripes of old and new options coexist during transcoding
+	thisBucket.oldBops = binfo.GetOldOpts()
+	thisBucket.newStripe = int(binfo.GetTranscodeStripe())
+	if thisBucket.oldBops != nil {
+		oldNum := int(thisBucket.oldBops.GetDataCount() + thisBucket.oldBops.GetParityCount())
+		if oldNum > thisBucket.chunkNum {
+			thisBucket.chunkNum = oldNum
+		}
+	}
+
 	return nil
 }
 
@@ -394,22 +419,36 @@ func (g *groupInfo) getBlockAvail(bid string) (int64, error) {
 
 type bucketInfo struct {
 	bops       *mpb.BucketOptions
-	chunkNum   int      // = dataCount+parityCount; which is largest chunkID
-	curStripes int      // largest stripeID
-	stripes    sync.Map // key is stripeID_chunkID, value is *cidInfo
+	oldBops    *mpb.BucketOptions // options of stripes before newStripe, during transcoding
+	newStripe  int                // first stripe of bops, during transcoding
+	chunkNum   int                // = dataCount+parityCount; which is largest chunkID
+	curStripes int                // largest stripeID
+	stripes    sync.Map           // key is stripeID_chunkID, value is *cidInfo
+}
+
+// options returns bucket options of stripe
+func (b *bucketInfo) options(stripe int) *mpb.BucketOptions {
+	if b.oldBops != nil && stripe < b.newStripe {
+		return b.oldBops
+	}
+	return b.bops
 }
 
 //lInfo
 type lInfo struct {
+	sync.RWMutex // guards chalStat and claim
 	chalMap      sync.Map       // key:challenge time,value:*chalresult
 	blockMap     sync.Map       // key:bucketid_stripeid_blockid, value: *blockInfo
 	chalCid      map[string]int // value is []bucketid_stripeid_blockid
 	faultCid     sync.Map
 	inChallenge  bool // during challenge or not
 	maxlength    int64
+	chalLength   int64 // maxlength at last data challenge
 	lastChalTime int64
+	chalStat     *chalStat // plan of last challenge
 	lastPay      *chalpay // stores result of last pay
 	currentPay   *chalpay // current
+	claim        *mpb.STClaim // counter claim of provider accepted after re-verification
 	stopSign     map[string][]byte
 }
 
@@ -426,7 +465,8 @@ type chalpay struct {
 	checkNum int
 	stop     bool
 	mpb.STValue
-	hash []byte
+	hash   []byte
+	leaves []*mpb.STLeaf // leaves behind Root
 }
 
 type quKey struct {
@@ -97,35 +97,17 @@ func (k *Info) initUser(uid, gid string, kc, pc int, price *big.Int) (string, er
 
 		newResponse.WriteString(metainfo.DELIMITER)
 
-		pros, err := k.GetProviders()
-		if err != nil {
-			return "", err
-		}
 		pcount := pc * 2
-		// fill providers
-		for _, proID := range pros {
+		// fill providers, cheapest first
+		for _, po := range k.eligibleProviders(price) {
 			if pcount == 0 {
 				break
 			}
-			if proID == localID {
-				continue
-			}
-
-			thisinfo, ok := k.providers.Load(proID)
-			if ok {
-				thisP := thisinfo.(*pInfo)
-				if thisP.online && thisP.offerItem != nil {
-					if thisP.offerItem.Price.Cmp(price) <= 0 {
-						if thisP.credit <= 0 {
-							utils.MLogger.Debugf("provider %s need price %d, but %d; has credit: %d", proID, thisP.offerItem.Price, price, thisP.credit)
-						}
-						newResponse.WriteString(proID)
-						pcount--
-					} else {
-						utils.MLogger.Debugf("provider %s need price %d, but %d; has credit: %d", proID, thisP.offerItem.Price, price, thisP.credit)
-					}
-				}
+			if po.credit <= 0 {
+				utils.MLogger.Debugf("provider %s has price %d, but credit: %d", po.providerID, po.price, po.credit)
 			}
+			newResponse.WriteString(po.providerID)
+			pcount--
 		}
 
 		return newResponse.String(), nil
@@ -0,0 +1,128 @@
+package keeper
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/address"
+	"github.com/memoio/go-mefs/utils/pos"
+)
+
+// windDownRegular winds down groups whose upkeeping has ended for their grace period
+func (k *Info) windDownRegular(ctx context.Context) {
+	utils.MLogger.Info("Wind down of expired upkeeping start!")
+	ticker := time.NewTicker(windDownTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			k.windDownAll(ctx)
+		}
+	}
+}
+
+func (k *Info) windDownAll(ctx context.Context) {
+	uqs := k.getQUKeys()
+	for _, uq := range uqs {
+		if uq.qid == uq.uid || uq.uid == pos.GetPostId() {
+			continue
+		}
+
+		thisGroup := k.getGroupInfo(uq.uid, uq.qid, false)
+		if thisGroup == nil || thisGroup.upkeeping == nil {
+			continue
+		}
+
+		if !thisGroup.isExpired() || time.Now().Unix() < thisGroup.upkeeping.EndTime+thisGroup.getGracePeriod() {
+			continue
+		}
+
+		err := k.windDown(ctx, thisGroup)
+		if err != nil {
+			utils.MLogger.Infof("Wind down user %s fsID %s fails: %s", uq.uid, uq.qid, err)
+		}
+	}
+}
+
+// windDown settles final spacetime payments of group, then master keeper
+// destructs upkeeping, which returns money left to user, and asks providers to
+// delete blocks of group; at last group is removed from keeper.
+func (k *Info) windDown(ctx context.Context, g *groupInfo) error {
+	uItem, err := role.GetUpKeeping(g.userID, g.groupID)
+	if err != nil {
+		// destructed by master keeper
+		if g.localKeeper != g.masterKeeper && time.Now().Unix() > g.upkeeping.EndTime+g.getGracePeriod()+settleTimeout {
+			utils.MLogger.Infof("Upkeeping of user %s fsID %s is destructed, remove it", g.userID, g.groupID)
+			k.deleteGroupBlocks(ctx, g, false)
+			k.removeGroup(g)
+			return nil
+		}
+		return err
+	}
+
+	if uItem.EndTime+g.getGracePeriod() > time.Now().Unix() {
+		// renewed during grace period
+		return nil
+	}
+	g.upkeeping = &uItem
+
+	// final spacetime payments are sent by stPrePayRegular and stPayRegular,
+	// which pay till EndTime of upkeeping
+	settled := true
+	for _, pInfo := range uItem.Providers {
+		if pInfo.Stop {
+			continue
+		}
+
+		paid := big.NewInt(0)
+		for _, pf := range uItem.Proofs {
+			if pf.Provider == pInfo.Addr {
+				paid.Add(paid, pf.StValue)
+			}
+		}
+
+		proID, _ := address.GetIDFromAddress(pInfo.Addr.String())
+		if pInfo.StEnd.Int64() < uItem.EndTime {
+			settled = false
+			utils.MLogger.Infof("Final spacetime pay of user %s fsID %s for provider %s is not settled: paid %d till %d, end at %d", g.userID, g.groupID, proID, paid, pInfo.StEnd, uItem.EndTime)
+			continue
+		}
+		utils.MLogger.Infof("Final spacetime pay of user %s fsID %s for provider %s is settled: paid %d till %d", g.userID, g.groupID, proID, paid, pInfo.StEnd)
+	}
+
+	if !settled {
+		if time.Now().Unix() < uItem.EndTime+g.getGracePeriod()+settleTimeout {
+			return nil
+		}
+		utils.MLogger.Warnf("Final spacetime pay of user %s fsID %s is not settled in time, wind down anyway", g.userID, g.groupID)
+	}
+
+	if g.localKeeper != g.masterKeeper {
+		return nil
+	}
+
+	err = role.DestructUpKeeping(g.userID, g.groupID, k.localID, k.sk)
+	if err != nil {
+		return err
+	}
+
+	utils.MLogger.Infof("Destruct upkeeping %s of user %s fsID %s, need pay %d, money left %d is returned to user", uItem.UpKeepingID, g.userID, g.groupID, uItem.NeedPay, uItem.Money)
+
+	k.deleteGroupBlocks(ctx, g, true)
+	k.removeGroup(g)
+	return nil
+}
+
+// removeGroup removes group from keeper
+func (k *Info) removeGroup(g *groupInfo) {
+	k.ms.groupNum.Dec()
+	if g.localKeeper == g.masterKeeper {
+		k.ms.masterGroupNum.Dec()
+	}
+	k.ukpGroup.Delete(g.groupID)
+}
@@ -97,6 +97,13 @@ const (
 	KeyType_Session         KeyType = 43
 	KeyType_BucketStripes   KeyType = 44
 	KeyType_MoveData        KeyType = 45
+	KeyType_ChalLog         KeyType = 46
+	KeyType_ChalBatch       KeyType = 47
+	KeyType_Market          KeyType = 48
+	KeyType_StPayDispute    KeyType = 49
+	KeyType_Billing         KeyType = 50
+	KeyType_LostBlock       KeyType = 51
+	KeyType_Renew           KeyType = 52
 )
 
 var KeyType_name = map[int32]string{
@@ -137,6 +144,13 @@ var KeyType_name = map[int32]string{
 	43: "Session",
 	44: "BucketStripes",
 	45: "MoveData",
+	46: "ChalLog",
+	47: "ChalBatch",
+	48: "Market",
+	49: "StPayDispute",
+	50: "Billing",
+	51: "LostBlock",
+	52: "Renew",
 }
 
 var KeyType_value = map[string]int32{
@@ -177,6 +191,13 @@ var KeyType_value = map[string]int32{
 	"Session":         43,
 	"BucketStripes":   44,
 	"MoveData":        45,
+	"ChalLog":         46,
+	"ChalBatch":       47,
+	"Market":          48,
+	"StPayDispute":    49,
+	"Billing":         50,
+	"LostBlock":       51,
+	"Renew":           52,
 }
 
 func (x KeyType) String() string {
@@ -190,11 +211,12 @@ func (KeyType) EnumDescriptor() ([]byte, []int) {
 type LfsOp int32
 
 const (
-	LfsOp_OpErr    LfsOp = 0
-	LfsOp_OpAdd    LfsOp = 1
-	LfsOp_OpAppend LfsOp = 2
-	LfsOp_OpDelete LfsOp = 3
-	LfsOp_OpCancel LfsOp = 4
+	LfsOp_OpErr       LfsOp = 0
+	LfsOp_OpAdd       LfsOp = 1
+	LfsOp_OpAppend    LfsOp = 2
+	LfsOp_OpDelete    LfsOp = 3
+	LfsOp_OpCancel    LfsOp = 4
+	LfsOp_OpTranscode LfsOp = 5
 )
 
 var LfsOp_name = map[int32]string{
@@ -203,14 +225,16 @@ var LfsOp_name = map[int32]string{
 	2: "OpAppend",
 	3: "OpDelete",
 	4: "OpCancel",
+	5: "OpTranscode",
 }
 
 var LfsOp_value = map[string]int32{
-	"OpErr":    0,
-	"OpAdd":    1,
-	"OpAppend": 2,
-	"OpDelete": 3,
-	"OpCancel": 4,
+	"OpErr":       0,
+	"OpAdd":       1,
+	"OpAppend":    2,
+	"OpDelete":    3,
+	"OpCancel":    4,
+	"OpTranscode": 5,
 }
 
 func (x LfsOp) String() string {
@@ -687,6 +711,8 @@ type BucketOptions struct {
 	SegmentSize          int32    `protobuf:"varint,6,opt,name=SegmentSize,proto3" json:"SegmentSize,omitempty"`
 	SegmentCount         int32    `protobuf:"varint,7,opt,name=SegmentCount,proto3" json:"SegmentCount,omitempty"`
 	Encryption           int32    `protobuf:"varint,8,opt,name=Encryption,proto3" json:"Encryption,omitempty"`
+	LocalCount           int32    `protobuf:"varint,9,opt,name=LocalCount,proto3" json:"LocalCount,omitempty"`
+	SealIDs              []string `protobuf:"bytes,10,rep,name=SealIDs,proto3" json:"SealIDs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -772,6 +798,20 @@ func (m *BucketOptions) GetEncryption() int32 {
 	return 0
 }
 
+func (m *BucketOptions) GetLocalCount() int32 {
+	if m != nil {
+		return m.LocalCount
+	}
+	return 0
+}
+
+func (m *BucketOptions) GetSealIDs() []string {
+	if m != nil {
+		return m.SealIDs
+	}
+	return nil
+}
+
 // lfs bucket information
 type BucketInfo struct {
 	Name                 string         `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
@@ -785,6 +825,8 @@ type BucketInfo struct {
 	NextObjectID         int64          `protobuf:"varint,10,opt,name=NextObjectID,proto3" json:"NextObjectID,omitempty"`
 	NextOpID             int64          `protobuf:"varint,11,opt,name=NextOpID,proto3" json:"NextOpID,omitempty"`
 	Root                 []byte         `protobuf:"bytes,12,opt,name=Root,proto3" json:"Root,omitempty"`
+	OldOpts              *BucketOptions `protobuf:"bytes,13,opt,name=OldOpts,proto3" json:"OldOpts,omitempty"`
+	TranscodeStripe      int64          `protobuf:"varint,14,opt,name=TranscodeStripe,proto3" json:"TranscodeStripe,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -891,6 +933,20 @@ func (m *BucketInfo) GetRoot() []byte {
 	return nil
 }
 
+func (m *BucketInfo) GetOldOpts() *BucketOptions {
+	if m != nil {
+		return m.OldOpts
+	}
+	return nil
+}
+
+func (m *BucketInfo) GetTranscodeStripe() int64 {
+	if m != nil {
+		return m.TranscodeStripe
+	}
+	return 0
+}
+
 // lfs object plus part information
 type ObjectInfo struct {
 	Info                 *Object       `protobuf:"bytes,1,opt,name=Info,proto3" json:"Info,omitempty"`
@@ -1077,16 +1133,17 @@ func (m *Object) GetMetadata() map[string]string {
 // lfs object part informations
 // insert into objectInfo when add data to an existing object
 type ObjectPart struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	ObjectID             int64    `protobuf:"varint,2,opt,name=ObjectID,proto3" json:"ObjectID,omitempty"`
-	PartID               int64    `protobuf:"varint,3,opt,name=PartID,proto3" json:"PartID,omitempty"`
-	Start                int64    `protobuf:"varint,4,opt,name=Start,proto3" json:"Start,omitempty"`
-	Length               int64    `protobuf:"varint,5,opt,name=Length,proto3" json:"Length,omitempty"`
-	CTime                int64    `protobuf:"varint,6,opt,name=CTime,proto3" json:"CTime,omitempty"`
-	ETag                 string   `protobuf:"bytes,7,opt,name=ETag,proto3" json:"ETag,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name                 string         `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	ObjectID             int64          `protobuf:"varint,2,opt,name=ObjectID,proto3" json:"ObjectID,omitempty"`
+	PartID               int64          `protobuf:"varint,3,opt,name=PartID,proto3" json:"PartID,omitempty"`
+	Start                int64          `protobuf:"varint,4,opt,name=Start,proto3" json:"Start,omitempty"`
+	Length               int64          `protobuf:"varint,5,opt,name=Length,proto3" json:"Length,omitempty"`
+	CTime                int64          `protobuf:"varint,6,opt,name=CTime,proto3" json:"CTime,omitempty"`
+	ETag                 string         `protobuf:"bytes,7,opt,name=ETag,proto3" json:"ETag,omitempty"`
+	BOpts                *BucketOptions `protobuf:"bytes,8,opt,name=BOpts,proto3" json:"BOpts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *ObjectPart) Reset()         { *m = ObjectPart{} }
@@ -1162,6 +1219,13 @@ func (m *ObjectPart) GetETag() string {
 	return ""
 }
 
+func (m *ObjectPart) GetBOpts() *BucketOptions {
+	if m != nil {
+		return m.BOpts
+	}
+	return nil
+}
+
 type DeleteObject struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
 	ObjectID             int64    `protobuf:"varint,2,opt,name=ObjectID,proto3" json:"ObjectID,omitempty"`
@@ -1728,200 +1792,1292 @@ func (m *ChalInfo) GetFailMap() []byte {
 	return nil
 }
 
-type ChannelSign struct {
-	ChannelID            string   `protobuf:"bytes,1,opt,name=ChannelID,proto3" json:"ChannelID,omitempty"`
-	Value                []byte   `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
-	Sig                  []byte   `protobuf:"bytes,3,opt,name=Sig,proto3" json:"Sig,omitempty"`
-	PubKey               []byte   `protobuf:"bytes,4,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+// reply of post challenge: raw segments of challenged blocks in order,
+// without blocks which provider fails to read
+type PostProof struct {
+	Segments             []byte   `protobuf:"bytes,1,opt,name=Segments,proto3" json:"Segments,omitempty"`
+	FaultBlocks          []string `protobuf:"bytes,2,rep,name=FaultBlocks,proto3" json:"FaultBlocks,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ChannelSign) Reset()         { *m = ChannelSign{} }
-func (m *ChannelSign) String() string { return proto.CompactTextString(m) }
-func (*ChannelSign) ProtoMessage()    {}
-func (*ChannelSign) Descriptor() ([]byte, []int) {
+func (m *PostProof) Reset()         { *m = PostProof{} }
+func (m *PostProof) String() string { return proto.CompactTextString(m) }
+func (*PostProof) ProtoMessage()    {}
+func (*PostProof) Descriptor() ([]byte, []int) {
 	return fileDescriptor_2b3c77393cf6fe78, []int{19}
 }
-func (m *ChannelSign) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChannelSign.Unmarshal(m, b)
+func (m *PostProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PostProof.Unmarshal(m, b)
 }
-func (m *ChannelSign) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChannelSign.Marshal(b, m, deterministic)
+func (m *PostProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PostProof.Marshal(b, m, deterministic)
 }
-func (m *ChannelSign) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelSign.Merge(m, src)
+func (m *PostProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostProof.Merge(m, src)
 }
-func (m *ChannelSign) XXX_Size() int {
-	return xxx_messageInfo_ChannelSign.Size(m)
+func (m *PostProof) XXX_Size() int {
+	return xxx_messageInfo_PostProof.Size(m)
 }
-func (m *ChannelSign) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelSign.DiscardUnknown(m)
+func (m *PostProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostProof.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChannelSign proto.InternalMessageInfo
+var xxx_messageInfo_PostProof proto.InternalMessageInfo
 
-func (m *ChannelSign) GetChannelID() string {
+func (m *PostProof) GetSegments() []byte {
 	if m != nil {
-		return m.ChannelID
+		return m.Segments
 	}
-	return ""
+	return nil
 }
 
-func (m *ChannelSign) GetValue() []byte {
+func (m *PostProof) GetFaultBlocks() []string {
 	if m != nil {
-		return m.Value
+		return m.FaultBlocks
 	}
 	return nil
 }
 
-func (m *ChannelSign) GetSig() []byte {
+// challenges of many groups on one provider, which are answered by
+// proofs with one aggregated delta
+type ChalBatch struct {
+	KeeperID             string      `protobuf:"bytes,1,opt,name=KeeperID,proto3" json:"KeeperID,omitempty"`
+	ProviderID           string      `protobuf:"bytes,2,opt,name=ProviderID,proto3" json:"ProviderID,omitempty"`
+	ChalTime             int64       `protobuf:"varint,3,opt,name=ChalTime,proto3" json:"ChalTime,omitempty"`
+	Chals                []*ChalInfo `protobuf:"bytes,4,rep,name=Chals,proto3" json:"Chals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ChalBatch) Reset()         { *m = ChalBatch{} }
+func (m *ChalBatch) String() string { return proto.CompactTextString(m) }
+func (*ChalBatch) ProtoMessage()    {}
+func (*ChalBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{20}
+}
+func (m *ChalBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChalBatch.Unmarshal(m, b)
+}
+func (m *ChalBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChalBatch.Marshal(b, m, deterministic)
+}
+func (m *ChalBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChalBatch.Merge(m, src)
+}
+func (m *ChalBatch) XXX_Size() int {
+	return xxx_messageInfo_ChalBatch.Size(m)
+}
+func (m *ChalBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChalBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChalBatch proto.InternalMessageInfo
+
+func (m *ChalBatch) GetKeeperID() string {
 	if m != nil {
-		return m.Sig
+		return m.KeeperID
 	}
-	return nil
+	return ""
 }
 
-func (m *ChannelSign) GetPubKey() []byte {
+func (m *ChalBatch) GetProviderID() string {
 	if m != nil {
-		return m.PubKey
+		return m.ProviderID
+	}
+	return ""
+}
+
+func (m *ChalBatch) GetChalTime() int64 {
+	if m != nil {
+		return m.ChalTime
+	}
+	return 0
+}
+
+func (m *ChalBatch) GetChals() []*ChalInfo {
+	if m != nil {
+		return m.Chals
 	}
 	return nil
 }
 
-type STValue struct {
-	Status               int32    `protobuf:"varint,1,opt,name=Status,proto3" json:"Status,omitempty"`
-	Start                int64    `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
-	Length               int64    `protobuf:"varint,3,opt,name=Length,proto3" json:"Length,omitempty"`
-	Value                []byte   `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
-	Root                 []byte   `protobuf:"bytes,5,opt,name=Root,proto3" json:"Root,omitempty"`
-	Share                []int64  `protobuf:"varint,6,rep,packed,name=Share,proto3" json:"Share,omitempty"`
-	Sign                 [][]byte `protobuf:"bytes,7,rep,name=Sign,proto3" json:"Sign,omitempty"`
+// merkle proof of one leaf; Proof[0] is the leaf data
+type LeafProof struct {
+	Index                int64    `protobuf:"varint,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Proof                [][]byte `protobuf:"bytes,2,rep,name=Proof,proto3" json:"Proof,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *STValue) Reset()         { *m = STValue{} }
-func (m *STValue) String() string { return proto.CompactTextString(m) }
-func (*STValue) ProtoMessage()    {}
-func (*STValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2b3c77393cf6fe78, []int{20}
+func (m *LeafProof) Reset()         { *m = LeafProof{} }
+func (m *LeafProof) String() string { return proto.CompactTextString(m) }
+func (*LeafProof) ProtoMessage()    {}
+func (*LeafProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{21}
 }
-func (m *STValue) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_STValue.Unmarshal(m, b)
+func (m *LeafProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeafProof.Unmarshal(m, b)
 }
-func (m *STValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_STValue.Marshal(b, m, deterministic)
+func (m *LeafProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeafProof.Marshal(b, m, deterministic)
 }
-func (m *STValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_STValue.Merge(m, src)
+func (m *LeafProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeafProof.Merge(m, src)
 }
-func (m *STValue) XXX_Size() int {
-	return xxx_messageInfo_STValue.Size(m)
+func (m *LeafProof) XXX_Size() int {
+	return xxx_messageInfo_LeafProof.Size(m)
 }
-func (m *STValue) XXX_DiscardUnknown() {
-	xxx_messageInfo_STValue.DiscardUnknown(m)
+func (m *LeafProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeafProof.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_STValue proto.InternalMessageInfo
+var xxx_messageInfo_LeafProof proto.InternalMessageInfo
 
-func (m *STValue) GetStatus() int32 {
+func (m *LeafProof) GetIndex() int64 {
 	if m != nil {
-		return m.Status
+		return m.Index
 	}
 	return 0
 }
 
-func (m *STValue) GetStart() int64 {
+func (m *LeafProof) GetProof() [][]byte {
 	if m != nil {
-		return m.Start
+		return m.Proof
+	}
+	return nil
+}
+
+// proof of an object against the lfs root kept in root contract:
+// op records -> bucket root -> lfs root -> root contract at CTime
+type ObjectProof struct {
+	UserID               string       `protobuf:"bytes,1,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	FsID                 string       `protobuf:"bytes,2,opt,name=FsID,proto3" json:"FsID,omitempty"`
+	RootID               string       `protobuf:"bytes,3,opt,name=RootID,proto3" json:"RootID,omitempty"`
+	CTime                int64        `protobuf:"varint,4,opt,name=CTime,proto3" json:"CTime,omitempty"`
+	BucketID             int64        `protobuf:"varint,5,opt,name=BucketID,proto3" json:"BucketID,omitempty"`
+	OpCount              int64        `protobuf:"varint,6,opt,name=OpCount,proto3" json:"OpCount,omitempty"`
+	BucketRoot           []byte       `protobuf:"bytes,7,opt,name=BucketRoot,proto3" json:"BucketRoot,omitempty"`
+	Ops                  []*LeafProof `protobuf:"bytes,8,rep,name=Ops,proto3" json:"Ops,omitempty"`
+	LfsLeaves            int64        `protobuf:"varint,9,opt,name=LfsLeaves,proto3" json:"LfsLeaves,omitempty"`
+	BucketProof          *LeafProof   `protobuf:"bytes,10,opt,name=BucketProof,proto3" json:"BucketProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ObjectProof) Reset()         { *m = ObjectProof{} }
+func (m *ObjectProof) String() string { return proto.CompactTextString(m) }
+func (*ObjectProof) ProtoMessage()    {}
+func (*ObjectProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{22}
+}
+func (m *ObjectProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectProof.Unmarshal(m, b)
+}
+func (m *ObjectProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectProof.Marshal(b, m, deterministic)
+}
+func (m *ObjectProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectProof.Merge(m, src)
+}
+func (m *ObjectProof) XXX_Size() int {
+	return xxx_messageInfo_ObjectProof.Size(m)
+}
+func (m *ObjectProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectProof proto.InternalMessageInfo
+
+func (m *ObjectProof) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *ObjectProof) GetFsID() string {
+	if m != nil {
+		return m.FsID
+	}
+	return ""
+}
+
+func (m *ObjectProof) GetRootID() string {
+	if m != nil {
+		return m.RootID
+	}
+	return ""
+}
+
+func (m *ObjectProof) GetCTime() int64 {
+	if m != nil {
+		return m.CTime
 	}
 	return 0
 }
 
-func (m *STValue) GetLength() int64 {
+func (m *ObjectProof) GetBucketID() int64 {
 	if m != nil {
-		return m.Length
+		return m.BucketID
 	}
 	return 0
 }
 
-func (m *STValue) GetValue() []byte {
+func (m *ObjectProof) GetOpCount() int64 {
 	if m != nil {
-		return m.Value
+		return m.OpCount
 	}
-	return nil
+	return 0
 }
 
-func (m *STValue) GetRoot() []byte {
+func (m *ObjectProof) GetBucketRoot() []byte {
 	if m != nil {
-		return m.Root
+		return m.BucketRoot
 	}
 	return nil
 }
 
-func (m *STValue) GetShare() []int64 {
+func (m *ObjectProof) GetOps() []*LeafProof {
 	if m != nil {
-		return m.Share
+		return m.Ops
 	}
 	return nil
 }
 
-func (m *STValue) GetSign() [][]byte {
+func (m *ObjectProof) GetLfsLeaves() int64 {
 	if m != nil {
-		return m.Sign
+		return m.LfsLeaves
+	}
+	return 0
+}
+
+func (m *ObjectProof) GetBucketProof() *LeafProof {
+	if m != nil {
+		return m.BucketProof
 	}
 	return nil
 }
 
-type KVData struct {
-	Key                  []byte   `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	Value                []byte   `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+// audit log of one challenge, signed by keeper
+type ChalLog struct {
+	QueryID              string   `protobuf:"bytes,1,opt,name=QueryID,proto3" json:"QueryID,omitempty"`
+	UserID               string   `protobuf:"bytes,2,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	ProviderID           string   `protobuf:"bytes,3,opt,name=ProviderID,proto3" json:"ProviderID,omitempty"`
+	KeeperID             string   `protobuf:"bytes,4,opt,name=KeeperID,proto3" json:"KeeperID,omitempty"`
+	ChalTime             int64    `protobuf:"varint,5,opt,name=ChalTime,proto3" json:"ChalTime,omitempty"`
+	ProofTime            int64    `protobuf:"varint,6,opt,name=ProofTime,proto3" json:"ProofTime,omitempty"`
+	Policy               string   `protobuf:"bytes,7,opt,name=Policy,proto3" json:"Policy,omitempty"`
+	ChalNum              int64    `protobuf:"varint,8,opt,name=ChalNum,proto3" json:"ChalNum,omitempty"`
+	TotalNum             int64    `protobuf:"varint,9,opt,name=TotalNum,proto3" json:"TotalNum,omitempty"`
+	ChalLength           int64    `protobuf:"varint,10,opt,name=ChalLength,proto3" json:"ChalLength,omitempty"`
+	SuccessLength        int64    `protobuf:"varint,11,opt,name=SuccessLength,proto3" json:"SuccessLength,omitempty"`
+	FaultBlocks          []string `protobuf:"bytes,12,rep,name=FaultBlocks,proto3" json:"FaultBlocks,omitempty"`
+	Res                  bool     `protobuf:"varint,13,opt,name=Res,proto3" json:"Res,omitempty"`
+	KeeperSign           []byte   `protobuf:"bytes,14,opt,name=KeeperSign,proto3" json:"KeeperSign,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVData) Reset()         { *m = KVData{} }
-func (m *KVData) String() string { return proto.CompactTextString(m) }
-func (*KVData) ProtoMessage()    {}
-func (*KVData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2b3c77393cf6fe78, []int{21}
+func (m *ChalLog) Reset()         { *m = ChalLog{} }
+func (m *ChalLog) String() string { return proto.CompactTextString(m) }
+func (*ChalLog) ProtoMessage()    {}
+func (*ChalLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{23}
 }
-func (m *KVData) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KVData.Unmarshal(m, b)
+func (m *ChalLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChalLog.Unmarshal(m, b)
 }
-func (m *KVData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KVData.Marshal(b, m, deterministic)
+func (m *ChalLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChalLog.Marshal(b, m, deterministic)
 }
-func (m *KVData) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVData.Merge(m, src)
+func (m *ChalLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChalLog.Merge(m, src)
 }
-func (m *KVData) XXX_Size() int {
-	return xxx_messageInfo_KVData.Size(m)
+func (m *ChalLog) XXX_Size() int {
+	return xxx_messageInfo_ChalLog.Size(m)
 }
-func (m *KVData) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVData.DiscardUnknown(m)
+func (m *ChalLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChalLog.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVData proto.InternalMessageInfo
+var xxx_messageInfo_ChalLog proto.InternalMessageInfo
 
-func (m *KVData) GetKey() []byte {
+func (m *ChalLog) GetQueryID() string {
 	if m != nil {
-		return m.Key
+		return m.QueryID
 	}
-	return nil
+	return ""
 }
 
-func (m *KVData) GetValue() []byte {
+func (m *ChalLog) GetUserID() string {
 	if m != nil {
-		return m.Value
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *ChalLog) GetProviderID() string {
+	if m != nil {
+		return m.ProviderID
+	}
+	return ""
+}
+
+func (m *ChalLog) GetKeeperID() string {
+	if m != nil {
+		return m.KeeperID
+	}
+	return ""
+}
+
+func (m *ChalLog) GetChalTime() int64 {
+	if m != nil {
+		return m.ChalTime
+	}
+	return 0
+}
+
+func (m *ChalLog) GetProofTime() int64 {
+	if m != nil {
+		return m.ProofTime
+	}
+	return 0
+}
+
+func (m *ChalLog) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+func (m *ChalLog) GetChalNum() int64 {
+	if m != nil {
+		return m.ChalNum
+	}
+	return 0
+}
+
+func (m *ChalLog) GetTotalNum() int64 {
+	if m != nil {
+		return m.TotalNum
+	}
+	return 0
+}
+
+func (m *ChalLog) GetChalLength() int64 {
+	if m != nil {
+		return m.ChalLength
+	}
+	return 0
+}
+
+func (m *ChalLog) GetSuccessLength() int64 {
+	if m != nil {
+		return m.SuccessLength
+	}
+	return 0
+}
+
+func (m *ChalLog) GetFaultBlocks() []string {
+	if m != nil {
+		return m.FaultBlocks
 	}
 	return nil
 }
 
+func (m *ChalLog) GetRes() bool {
+	if m != nil {
+		return m.Res
+	}
+	return false
+}
+
+func (m *ChalLog) GetKeeperSign() []byte {
+	if m != nil {
+		return m.KeeperSign
+	}
+	return nil
+}
+
+type ChalLogList struct {
+	Logs                 []*ChalLog `protobuf:"bytes,1,rep,name=Logs,proto3" json:"Logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ChalLogList) Reset()         { *m = ChalLogList{} }
+func (m *ChalLogList) String() string { return proto.CompactTextString(m) }
+func (*ChalLogList) ProtoMessage()    {}
+func (*ChalLogList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{24}
+}
+func (m *ChalLogList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChalLogList.Unmarshal(m, b)
+}
+func (m *ChalLogList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChalLogList.Marshal(b, m, deterministic)
+}
+func (m *ChalLogList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChalLogList.Merge(m, src)
+}
+func (m *ChalLogList) XXX_Size() int {
+	return xxx_messageInfo_ChalLogList.Size(m)
+}
+func (m *ChalLogList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChalLogList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChalLogList proto.InternalMessageInfo
+
+func (m *ChalLogList) GetLogs() []*ChalLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type ChannelSign struct {
+	ChannelID            string     `protobuf:"bytes,1,opt,name=ChannelID,proto3" json:"ChannelID,omitempty"`
+	Value                []byte     `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	Sig                  []byte     `protobuf:"bytes,3,opt,name=Sig,proto3" json:"Sig,omitempty"`
+	PubKey               []byte     `protobuf:"bytes,4,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+	Token                *ReadToken `protobuf:"bytes,5,opt,name=Token,proto3" json:"Token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ChannelSign) Reset()         { *m = ChannelSign{} }
+func (m *ChannelSign) String() string { return proto.CompactTextString(m) }
+func (*ChannelSign) ProtoMessage()    {}
+func (*ChannelSign) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{25}
+}
+func (m *ChannelSign) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelSign.Unmarshal(m, b)
+}
+func (m *ChannelSign) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelSign.Marshal(b, m, deterministic)
+}
+func (m *ChannelSign) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelSign.Merge(m, src)
+}
+func (m *ChannelSign) XXX_Size() int {
+	return xxx_messageInfo_ChannelSign.Size(m)
+}
+func (m *ChannelSign) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelSign.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelSign proto.InternalMessageInfo
+
+func (m *ChannelSign) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *ChannelSign) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ChannelSign) GetSig() []byte {
+	if m != nil {
+		return m.Sig
+	}
+	return nil
+}
+
+func (m *ChannelSign) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *ChannelSign) GetToken() *ReadToken {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
+// authorizes Reader to get BlockIDs without paying before Expire, e.g. for repair;
+// it is issued by keeper of group, or by user for its own blocks
+type ReadToken struct {
+	QueryID              string   `protobuf:"bytes,1,opt,name=QueryID,proto3" json:"QueryID,omitempty"`
+	BlockIDs             []string `protobuf:"bytes,2,rep,name=BlockIDs,proto3" json:"BlockIDs,omitempty"`
+	Reader               string   `protobuf:"bytes,3,opt,name=Reader,proto3" json:"Reader,omitempty"`
+	Issuer               string   `protobuf:"bytes,4,opt,name=Issuer,proto3" json:"Issuer,omitempty"`
+	Expire               int64    `protobuf:"varint,5,opt,name=Expire,proto3" json:"Expire,omitempty"`
+	Sign                 []byte   `protobuf:"bytes,6,opt,name=Sign,proto3" json:"Sign,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadToken) Reset()         { *m = ReadToken{} }
+func (m *ReadToken) String() string { return proto.CompactTextString(m) }
+func (*ReadToken) ProtoMessage()    {}
+func (*ReadToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{26}
+}
+func (m *ReadToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadToken.Unmarshal(m, b)
+}
+func (m *ReadToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadToken.Marshal(b, m, deterministic)
+}
+func (m *ReadToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadToken.Merge(m, src)
+}
+func (m *ReadToken) XXX_Size() int {
+	return xxx_messageInfo_ReadToken.Size(m)
+}
+func (m *ReadToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadToken proto.InternalMessageInfo
+
+func (m *ReadToken) GetQueryID() string {
+	if m != nil {
+		return m.QueryID
+	}
+	return ""
+}
+
+func (m *ReadToken) GetBlockIDs() []string {
+	if m != nil {
+		return m.BlockIDs
+	}
+	return nil
+}
+
+func (m *ReadToken) GetReader() string {
+	if m != nil {
+		return m.Reader
+	}
+	return ""
+}
+
+func (m *ReadToken) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *ReadToken) GetExpire() int64 {
+	if m != nil {
+		return m.Expire
+	}
+	return 0
+}
+
+func (m *ReadToken) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type STValue struct {
+	Status               int32    `protobuf:"varint,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	Start                int64    `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
+	Length               int64    `protobuf:"varint,3,opt,name=Length,proto3" json:"Length,omitempty"`
+	Value                []byte   `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	Root                 []byte   `protobuf:"bytes,5,opt,name=Root,proto3" json:"Root,omitempty"`
+	Share                []int64  `protobuf:"varint,6,rep,packed,name=Share,proto3" json:"Share,omitempty"`
+	Sign                 [][]byte `protobuf:"bytes,7,rep,name=Sign,proto3" json:"Sign,omitempty"`
+	Version              int32    `protobuf:"varint,8,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *STValue) Reset()         { *m = STValue{} }
+func (m *STValue) String() string { return proto.CompactTextString(m) }
+func (*STValue) ProtoMessage()    {}
+func (*STValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{27}
+}
+func (m *STValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_STValue.Unmarshal(m, b)
+}
+func (m *STValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_STValue.Marshal(b, m, deterministic)
+}
+func (m *STValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_STValue.Merge(m, src)
+}
+func (m *STValue) XXX_Size() int {
+	return xxx_messageInfo_STValue.Size(m)
+}
+func (m *STValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_STValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_STValue proto.InternalMessageInfo
+
+func (m *STValue) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *STValue) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *STValue) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *STValue) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *STValue) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *STValue) GetShare() []int64 {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+func (m *STValue) GetSign() [][]byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+func (m *STValue) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type KVData struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVData) Reset()         { *m = KVData{} }
+func (m *KVData) String() string { return proto.CompactTextString(m) }
+func (*KVData) ProtoMessage()    {}
+func (*KVData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{28}
+}
+func (m *KVData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVData.Unmarshal(m, b)
+}
+func (m *KVData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVData.Marshal(b, m, deterministic)
+}
+func (m *KVData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVData.Merge(m, src)
+}
+func (m *KVData) XXX_Size() int {
+	return xxx_messageInfo_KVData.Size(m)
+}
+func (m *KVData) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVData proto.InternalMessageInfo
+
+func (m *KVData) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *KVData) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// leaf of merkle tree of spacetime pay: stored Space(Byte) at Time(second)
+type STLeaf struct {
+	Time                 int64    `protobuf:"varint,1,opt,name=Time,proto3" json:"Time,omitempty"`
+	Space                int64    `protobuf:"varint,2,opt,name=Space,proto3" json:"Space,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *STLeaf) Reset()         { *m = STLeaf{} }
+func (m *STLeaf) String() string { return proto.CompactTextString(m) }
+func (*STLeaf) ProtoMessage()    {}
+func (*STLeaf) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{29}
+}
+func (m *STLeaf) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_STLeaf.Unmarshal(m, b)
+}
+func (m *STLeaf) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_STLeaf.Marshal(b, m, deterministic)
+}
+func (m *STLeaf) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_STLeaf.Merge(m, src)
+}
+func (m *STLeaf) XXX_Size() int {
+	return xxx_messageInfo_STLeaf.Size(m)
+}
+func (m *STLeaf) XXX_DiscardUnknown() {
+	xxx_messageInfo_STLeaf.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_STLeaf proto.InternalMessageInfo
+
+func (m *STLeaf) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *STLeaf) GetSpace() int64 {
+	if m != nil {
+		return m.Space
+	}
+	return 0
+}
+
+// leaves behind Root of spacetime pay proposed by keeper,
+// and challenge logs signed by keeper in the period
+type STLeaves struct {
+	Pay                  *STValue   `protobuf:"bytes,1,opt,name=Pay,proto3" json:"Pay,omitempty"`
+	Leaves               []*STLeaf  `protobuf:"bytes,2,rep,name=Leaves,proto3" json:"Leaves,omitempty"`
+	Logs                 []*ChalLog `protobuf:"bytes,3,rep,name=Logs,proto3" json:"Logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *STLeaves) Reset()         { *m = STLeaves{} }
+func (m *STLeaves) String() string { return proto.CompactTextString(m) }
+func (*STLeaves) ProtoMessage()    {}
+func (*STLeaves) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{30}
+}
+func (m *STLeaves) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_STLeaves.Unmarshal(m, b)
+}
+func (m *STLeaves) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_STLeaves.Marshal(b, m, deterministic)
+}
+func (m *STLeaves) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_STLeaves.Merge(m, src)
+}
+func (m *STLeaves) XXX_Size() int {
+	return xxx_messageInfo_STLeaves.Size(m)
+}
+func (m *STLeaves) XXX_DiscardUnknown() {
+	xxx_messageInfo_STLeaves.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_STLeaves proto.InternalMessageInfo
+
+func (m *STLeaves) GetPay() *STValue {
+	if m != nil {
+		return m.Pay
+	}
+	return nil
+}
+
+func (m *STLeaves) GetLeaves() []*STLeaf {
+	if m != nil {
+		return m.Leaves
+	}
+	return nil
+}
+
+func (m *STLeaves) GetLogs() []*ChalLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+// counter claim of spacetime pay by provider, which is re-verified by keepers
+type STClaim struct {
+	Start                int64      `protobuf:"varint,1,opt,name=Start,proto3" json:"Start,omitempty"`
+	Length               int64      `protobuf:"varint,2,opt,name=Length,proto3" json:"Length,omitempty"`
+	Value                []byte     `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
+	Root                 []byte     `protobuf:"bytes,4,opt,name=Root,proto3" json:"Root,omitempty"`
+	Logs                 []*ChalLog `protobuf:"bytes,5,rep,name=Logs,proto3" json:"Logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *STClaim) Reset()         { *m = STClaim{} }
+func (m *STClaim) String() string { return proto.CompactTextString(m) }
+func (*STClaim) ProtoMessage()    {}
+func (*STClaim) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{31}
+}
+func (m *STClaim) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_STClaim.Unmarshal(m, b)
+}
+func (m *STClaim) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_STClaim.Marshal(b, m, deterministic)
+}
+func (m *STClaim) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_STClaim.Merge(m, src)
+}
+func (m *STClaim) XXX_Size() int {
+	return xxx_messageInfo_STClaim.Size(m)
+}
+func (m *STClaim) XXX_DiscardUnknown() {
+	xxx_messageInfo_STClaim.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_STClaim proto.InternalMessageInfo
+
+func (m *STClaim) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *STClaim) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *STClaim) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *STClaim) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *STClaim) GetLogs() []*ChalLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+// offer of provider seen by keeper
+type MarketOffer struct {
+	ProviderID           string   `protobuf:"bytes,1,opt,name=ProviderID,proto3" json:"ProviderID,omitempty"`
+	OfferID              string   `protobuf:"bytes,2,opt,name=OfferID,proto3" json:"OfferID,omitempty"`
+	Capacity             int64    `protobuf:"varint,3,opt,name=Capacity,proto3" json:"Capacity,omitempty"`
+	Duration             int64    `protobuf:"varint,4,opt,name=Duration,proto3" json:"Duration,omitempty"`
+	Price                []byte   `protobuf:"bytes,5,opt,name=Price,proto3" json:"Price,omitempty"`
+	Credit               int64    `protobuf:"varint,6,opt,name=Credit,proto3" json:"Credit,omitempty"`
+	Location             string   `protobuf:"bytes,7,opt,name=Location,proto3" json:"Location,omitempty"`
+	Online               bool     `protobuf:"varint,8,opt,name=Online,proto3" json:"Online,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MarketOffer) Reset()         { *m = MarketOffer{} }
+func (m *MarketOffer) String() string { return proto.CompactTextString(m) }
+func (*MarketOffer) ProtoMessage()    {}
+func (*MarketOffer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{32}
+}
+func (m *MarketOffer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MarketOffer.Unmarshal(m, b)
+}
+func (m *MarketOffer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MarketOffer.Marshal(b, m, deterministic)
+}
+func (m *MarketOffer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MarketOffer.Merge(m, src)
+}
+func (m *MarketOffer) XXX_Size() int {
+	return xxx_messageInfo_MarketOffer.Size(m)
+}
+func (m *MarketOffer) XXX_DiscardUnknown() {
+	xxx_messageInfo_MarketOffer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MarketOffer proto.InternalMessageInfo
+
+func (m *MarketOffer) GetProviderID() string {
+	if m != nil {
+		return m.ProviderID
+	}
+	return ""
+}
+
+func (m *MarketOffer) GetOfferID() string {
+	if m != nil {
+		return m.OfferID
+	}
+	return ""
+}
+
+func (m *MarketOffer) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *MarketOffer) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *MarketOffer) GetPrice() []byte {
+	if m != nil {
+		return m.Price
+	}
+	return nil
+}
+
+func (m *MarketOffer) GetCredit() int64 {
+	if m != nil {
+		return m.Credit
+	}
+	return 0
+}
+
+func (m *MarketOffer) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *MarketOffer) GetOnline() bool {
+	if m != nil {
+		return m.Online
+	}
+	return false
+}
+
+type Market struct {
+	Offers               []*MarketOffer `protobuf:"bytes,1,rep,name=Offers,proto3" json:"Offers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Market) Reset()         { *m = Market{} }
+func (m *Market) String() string { return proto.CompactTextString(m) }
+func (*Market) ProtoMessage()    {}
+func (*Market) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{33}
+}
+func (m *Market) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Market.Unmarshal(m, b)
+}
+func (m *Market) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Market.Marshal(b, m, deterministic)
+}
+func (m *Market) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Market.Merge(m, src)
+}
+func (m *Market) XXX_Size() int {
+	return xxx_messageInfo_Market.Size(m)
+}
+func (m *Market) XXX_DiscardUnknown() {
+	xxx_messageInfo_Market.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Market proto.InternalMessageInfo
+
+func (m *Market) GetOffers() []*MarketOffer {
+	if m != nil {
+		return m.Offers
+	}
+	return nil
+}
+
+// cheapest eligible placement for a new lfs
+type MarketQuote struct {
+	Providers            []string `protobuf:"bytes,1,rep,name=Providers,proto3" json:"Providers,omitempty"`
+	Price                []byte   `protobuf:"bytes,2,opt,name=Price,proto3" json:"Price,omitempty"`
+	Cost                 []byte   `protobuf:"bytes,3,opt,name=Cost,proto3" json:"Cost,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MarketQuote) Reset()         { *m = MarketQuote{} }
+func (m *MarketQuote) String() string { return proto.CompactTextString(m) }
+func (*MarketQuote) ProtoMessage()    {}
+func (*MarketQuote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{34}
+}
+func (m *MarketQuote) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MarketQuote.Unmarshal(m, b)
+}
+func (m *MarketQuote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MarketQuote.Marshal(b, m, deterministic)
+}
+func (m *MarketQuote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MarketQuote.Merge(m, src)
+}
+func (m *MarketQuote) XXX_Size() int {
+	return xxx_messageInfo_MarketQuote.Size(m)
+}
+func (m *MarketQuote) XXX_DiscardUnknown() {
+	xxx_messageInfo_MarketQuote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MarketQuote proto.InternalMessageInfo
+
+func (m *MarketQuote) GetProviders() []string {
+	if m != nil {
+		return m.Providers
+	}
+	return nil
+}
+
+func (m *MarketQuote) GetPrice() []byte {
+	if m != nil {
+		return m.Price
+	}
+	return nil
+}
+
+func (m *MarketQuote) GetCost() []byte {
+	if m != nil {
+		return m.Cost
+	}
+	return nil
+}
+
+// entry of billing ledger of an account
+type BillEntry struct {
+	Owner                string   `protobuf:"bytes,1,opt,name=Owner,proto3" json:"Owner,omitempty"`
+	Time                 int64    `protobuf:"varint,2,opt,name=Time,proto3" json:"Time,omitempty"`
+	Kind                 string   `protobuf:"bytes,3,opt,name=Kind,proto3" json:"Kind,omitempty"`
+	Value                []byte   `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	Income               bool     `protobuf:"varint,5,opt,name=Income,proto3" json:"Income,omitempty"`
+	Peer                 string   `protobuf:"bytes,6,opt,name=Peer,proto3" json:"Peer,omitempty"`
+	Contract             string   `protobuf:"bytes,7,opt,name=Contract,proto3" json:"Contract,omitempty"`
+	Memo                 string   `protobuf:"bytes,8,opt,name=Memo,proto3" json:"Memo,omitempty"`
+	TxHash               string   `protobuf:"bytes,9,opt,name=TxHash,proto3" json:"TxHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BillEntry) Reset()         { *m = BillEntry{} }
+func (m *BillEntry) String() string { return proto.CompactTextString(m) }
+func (*BillEntry) ProtoMessage()    {}
+func (*BillEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{35}
+}
+func (m *BillEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BillEntry.Unmarshal(m, b)
+}
+func (m *BillEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BillEntry.Marshal(b, m, deterministic)
+}
+func (m *BillEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BillEntry.Merge(m, src)
+}
+func (m *BillEntry) XXX_Size() int {
+	return xxx_messageInfo_BillEntry.Size(m)
+}
+func (m *BillEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_BillEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BillEntry proto.InternalMessageInfo
+
+func (m *BillEntry) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *BillEntry) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *BillEntry) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *BillEntry) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *BillEntry) GetIncome() bool {
+	if m != nil {
+		return m.Income
+	}
+	return false
+}
+
+func (m *BillEntry) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *BillEntry) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *BillEntry) GetMemo() string {
+	if m != nil {
+		return m.Memo
+	}
+	return ""
+}
+
+func (m *BillEntry) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+// renew policy and progress of user's upkeeping, stored locally
+type UpKeepingRecord struct {
+	UpKeepingID          string   `protobuf:"bytes,1,opt,name=UpKeepingID,proto3" json:"UpKeepingID,omitempty"`
+	AutoRenew            bool     `protobuf:"varint,2,opt,name=AutoRenew,proto3" json:"AutoRenew,omitempty"`
+	RenewDays            int64    `protobuf:"varint,3,opt,name=RenewDays,proto3" json:"RenewDays,omitempty"`
+	Capacity             int64    `protobuf:"varint,4,opt,name=Capacity,proto3" json:"Capacity,omitempty"`
+	RenewEnd             int64    `protobuf:"varint,5,opt,name=RenewEnd,proto3" json:"RenewEnd,omitempty"`
+	GracePeriod          int64    `protobuf:"varint,6,opt,name=GracePeriod,proto3" json:"GracePeriod,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpKeepingRecord) Reset()         { *m = UpKeepingRecord{} }
+func (m *UpKeepingRecord) String() string { return proto.CompactTextString(m) }
+func (*UpKeepingRecord) ProtoMessage()    {}
+func (*UpKeepingRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3c77393cf6fe78, []int{36}
+}
+func (m *UpKeepingRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpKeepingRecord.Unmarshal(m, b)
+}
+func (m *UpKeepingRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpKeepingRecord.Marshal(b, m, deterministic)
+}
+func (m *UpKeepingRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpKeepingRecord.Merge(m, src)
+}
+func (m *UpKeepingRecord) XXX_Size() int {
+	return xxx_messageInfo_UpKeepingRecord.Size(m)
+}
+func (m *UpKeepingRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpKeepingRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpKeepingRecord proto.InternalMessageInfo
+
+func (m *UpKeepingRecord) GetUpKeepingID() string {
+	if m != nil {
+		return m.UpKeepingID
+	}
+	return ""
+}
+
+func (m *UpKeepingRecord) GetAutoRenew() bool {
+	if m != nil {
+		return m.AutoRenew
+	}
+	return false
+}
+
+func (m *UpKeepingRecord) GetRenewDays() int64 {
+	if m != nil {
+		return m.RenewDays
+	}
+	return 0
+}
+
+func (m *UpKeepingRecord) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *UpKeepingRecord) GetRenewEnd() int64 {
+	if m != nil {
+		return m.RenewEnd
+	}
+	return 0
+}
+
+func (m *UpKeepingRecord) GetGracePeriod() int64 {
+	if m != nil {
+		return m.GracePeriod
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("mefs.pb.OpType", OpType_name, OpType_value)
 	proto.RegisterEnum("mefs.pb.KeyType", KeyType_name, KeyType_value)
@@ -1946,131 +3102,201 @@ func init() {
 	proto.RegisterType((*ShareLink)(nil), "mefs.pb.ShareLink")
 	proto.RegisterType((*BucketContent)(nil), "mefs.pb.BucketContent")
 	proto.RegisterType((*ChalInfo)(nil), "mefs.pb.ChalInfo")
+	proto.RegisterType((*PostProof)(nil), "mefs.pb.PostProof")
+	proto.RegisterType((*ChalBatch)(nil), "mefs.pb.ChalBatch")
+	proto.RegisterType((*LeafProof)(nil), "mefs.pb.LeafProof")
+	proto.RegisterType((*ObjectProof)(nil), "mefs.pb.ObjectProof")
+	proto.RegisterType((*ChalLog)(nil), "mefs.pb.ChalLog")
+	proto.RegisterType((*ChalLogList)(nil), "mefs.pb.ChalLogList")
 	proto.RegisterType((*ChannelSign)(nil), "mefs.pb.ChannelSign")
+	proto.RegisterType((*ReadToken)(nil), "mefs.pb.ReadToken")
 	proto.RegisterType((*STValue)(nil), "mefs.pb.STValue")
 	proto.RegisterType((*KVData)(nil), "mefs.pb.KVData")
+	proto.RegisterType((*STLeaf)(nil), "mefs.pb.STLeaf")
+	proto.RegisterType((*STLeaves)(nil), "mefs.pb.STLeaves")
+	proto.RegisterType((*STClaim)(nil), "mefs.pb.STClaim")
+	proto.RegisterType((*MarketOffer)(nil), "mefs.pb.MarketOffer")
+	proto.RegisterType((*Market)(nil), "mefs.pb.Market")
+	proto.RegisterType((*MarketQuote)(nil), "mefs.pb.MarketQuote")
+	proto.RegisterType((*BillEntry)(nil), "mefs.pb.BillEntry")
+	proto.RegisterType((*UpKeepingRecord)(nil), "mefs.pb.UpKeepingRecord")
 }
 
 func init() { proto.RegisterFile("mefs.proto", fileDescriptor_2b3c77393cf6fe78) }
 
 var fileDescriptor_2b3c77393cf6fe78 = []byte{
-	// 1875 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x73, 0xe3, 0x48,
-	0x15, 0x1f, 0x59, 0xb6, 0x6c, 0xbd, 0x38, 0x49, 0xaf, 0x76, 0x36, 0x68, 0x43, 0x76, 0x31, 0x82,
-	0x5a, 0xb2, 0x99, 0x65, 0x6a, 0x09, 0x17, 0x3e, 0x4e, 0x71, 0x9c, 0x0c, 0xa9, 0x64, 0x62, 0x6f,
-	0x2b, 0xf3, 0x71, 0xdc, 0x8e, 0xdd, 0xf1, 0x08, 0x2b, 0x92, 0x4a, 0x6a, 0x4f, 0xad, 0xb9, 0x50,
-	0x5b, 0xc5, 0xdf, 0xc0, 0x91, 0x0b, 0x57, 0xce, 0x1c, 0xf8, 0x13, 0xf8, 0x63, 0xb8, 0x71, 0xe2,
-	0x42, 0xbd, 0xd7, 0xad, 0x0f, 0x7b, 0x67, 0x02, 0x55, 0x70, 0x72, 0xff, 0xde, 0xeb, 0x7e, 0xdf,
-	0xef, 0x75, 0xcb, 0x00, 0xf7, 0xf2, 0xae, 0x78, 0x9a, 0xe5, 0xa9, 0x4a, 0xbd, 0xae, 0x5e, 0xdf,
-	0x06, 0xdf, 0x5a, 0xd0, 0xbd, 0x94, 0xab, 0xe7, 0x52, 0x09, 0xcf, 0x87, 0xee, 0x5b, 0x99, 0x17,
-	0x51, 0x9a, 0xf8, 0xd6, 0xc0, 0x3a, 0xec, 0xf0, 0x12, 0x7a, 0x47, 0xd0, 0x5d, 0xc8, 0xd5, 0xcd,
-	0x2a, 0x93, 0x7e, 0x6b, 0x60, 0x1d, 0xee, 0x1c, 0xb3, 0xa7, 0x46, 0xc0, 0xd3, 0x4b, 0x4d, 0xe7,
-	0xe5, 0x06, 0x6f, 0x0f, 0x9c, 0x7b, 0x11, 0x25, 0x17, 0x23, 0xdf, 0x1e, 0x58, 0x87, 0x2e, 0x37,
-	0x08, 0xa5, 0xa7, 0x99, 0x8a, 0xd2, 0xa4, 0xf0, 0xdb, 0x03, 0xfb, 0xd0, 0xe5, 0x25, 0x0c, 0xae,
-	0xc1, 0xe1, 0x72, 0x9a, 0xe6, 0x33, 0x8f, 0x81, 0xbd, 0x90, 0x2b, 0xd2, 0xde, 0xe7, 0xb8, 0xf4,
-	0x1e, 0x43, 0xe7, 0xad, 0x88, 0x97, 0x5a, 0x6f, 0x9f, 0x6b, 0xe0, 0x1d, 0x80, 0x5b, 0x44, 0xf3,
-	0x44, 0xa8, 0x65, 0x2e, 0x49, 0x4d, 0x9f, 0xd7, 0x84, 0xe0, 0x35, 0x38, 0xc3, 0xab, 0xf0, 0x52,
-	0xae, 0x1e, 0xf0, 0x68, 0x0f, 0x9c, 0x6c, 0x79, 0x7b, 0x29, 0x57, 0x46, 0xb0, 0x41, 0x24, 0x59,
-	0x4e, 0x73, 0xa9, 0x90, 0x55, 0x4a, 0x2e, 0x09, 0xc1, 0x3f, 0x2d, 0xd8, 0x7d, 0x51, 0xc8, 0x7c,
-	0x78, 0x15, 0xfe, 0xec, 0xf8, 0x34, 0x4d, 0xee, 0xa2, 0xf9, 0x03, 0x3a, 0x0e, 0xc0, 0xcd, 0x96,
-	0xb7, 0x0b, 0xb9, 0x1a, 0xc6, 0x85, 0x51, 0x53, 0x13, 0xf0, 0x9c, 0x06, 0xcf, 0x8c, 0x9e, 0x12,
-	0xd6, 0x9c, 0x17, 0x14, 0xa9, 0x8a, 0xf3, 0xa2, 0xe6, 0xbc, 0xf2, 0x3b, 0x4d, 0xce, 0x2b, 0xd2,
-	0x95, 0x47, 0x46, 0x97, 0x63, 0x74, 0x95, 0x04, 0xaf, 0x0f, 0xd6, 0x6b, 0xbf, 0x4b, 0x54, 0xeb,
-	0x35, 0xc6, 0x74, 0x9a, 0x2e, 0x13, 0xe5, 0x03, 0xd9, 0xab, 0x81, 0xb7, 0x0f, 0x3d, 0x25, 0xe6,
-	0xa7, 0xc4, 0xd8, 0x22, 0x46, 0x85, 0x83, 0x97, 0x00, 0xc3, 0xe5, 0x74, 0x21, 0x15, 0x4f, 0x53,
-	0xda, 0xa9, 0xd1, 0xc5, 0x88, 0x5c, 0xb6, 0x79, 0x85, 0xd1, 0xc2, 0x71, 0xa6, 0x85, 0xb4, 0x88,
-	0x55, 0x42, 0xcf, 0x83, 0x36, 0x9e, 0x36, 0xce, 0xd2, 0x3a, 0xf8, 0x1a, 0xba, 0x57, 0x77, 0x05,
-	0x09, 0x7d, 0x0c, 0x9d, 0xd3, 0x9b, 0xe8, 0x5e, 0x1a, 0x89, 0x1a, 0x54, 0x87, 0x5a, 0xf5, 0x21,
-	0xef, 0x09, 0x38, 0x43, 0x5c, 0x14, 0xbe, 0x3d, 0xb0, 0x0f, 0xb7, 0x8e, 0x3f, 0xac, 0x6a, 0xb1,
-	0xb6, 0x91, 0x9b, 0x2d, 0xc1, 0x5f, 0x2d, 0xd8, 0x09, 0x97, 0x99, 0xcc, 0x87, 0x71, 0x3a, 0x5d,
-	0x5c, 0x24, 0x77, 0x29, 0x9a, 0xf8, 0x72, 0x3d, 0x61, 0x06, 0x7a, 0x87, 0xb0, 0x8b, 0x8d, 0x30,
-	0x14, 0xd3, 0xc5, 0xb2, 0xe1, 0x44, 0x87, 0x6f, 0x92, 0x6b, 0x6b, 0xed, 0xa6, 0xb5, 0x01, 0xf4,
-	0xaf, 0xe5, 0x37, 0xaa, 0x0a, 0x4e, 0x9b, 0x98, 0x6b, 0x34, 0xef, 0x33, 0xe8, 0x5c, 0x91, 0x4b,
-	0x5d, 0x32, 0xbe, 0x6e, 0x24, 0x13, 0x08, 0xae, 0xd9, 0xc1, 0xb7, 0x2d, 0xd8, 0xd6, 0x87, 0xc6,
-	0xba, 0x4d, 0x1e, 0xb0, 0x7b, 0x0f, 0x9c, 0x49, 0x1a, 0x47, 0xd3, 0x95, 0x31, 0xd7, 0x20, 0x2c,
-	0x8a, 0x91, 0x50, 0x42, 0x7b, 0x62, 0x13, 0xab, 0x26, 0x78, 0x03, 0xd8, 0x9a, 0x88, 0x3c, 0x52,
-	0x2b, 0xcd, 0x6f, 0x13, 0xbf, 0x49, 0x42, 0x8d, 0x37, 0x62, 0x7e, 0x1e, 0x8b, 0xb9, 0xdf, 0xd1,
-	0x1a, 0x0d, 0xc4, 0xb3, 0xa1, 0x9c, 0xdf, 0xcb, 0x44, 0x85, 0xd1, 0xef, 0x24, 0x15, 0x5c, 0x87,
-	0x37, 0x49, 0x18, 0x0b, 0x03, 0xb5, 0xf8, 0x2e, 0x6d, 0x59, 0xa3, 0x79, 0x9f, 0x02, 0x9c, 0x25,
-	0xd3, 0x7c, 0x45, 0x0e, 0xfa, 0x3d, 0xda, 0xd1, 0xa0, 0x04, 0x7f, 0x6f, 0x95, 0x75, 0x47, 0x89,
-	0xf3, 0xa0, 0x7d, 0x2d, 0x4c, 0x85, 0xb8, 0x9c, 0xd6, 0x6b, 0xb5, 0xd8, 0xda, 0xa8, 0xc5, 0x77,
-	0x27, 0xe9, 0x0b, 0xe8, 0x0c, 0xc7, 0x99, 0x2a, 0xc8, 0xe1, 0xad, 0xe3, 0xbd, 0x8d, 0xea, 0x31,
-	0xd1, 0xe6, 0x7a, 0x13, 0x86, 0xf6, 0x4a, 0x26, 0x73, 0xf5, 0x86, 0x22, 0x60, 0x73, 0x83, 0x50,
-	0xf6, 0x73, 0x92, 0xdd, 0xd5, 0xb2, 0x09, 0x78, 0x47, 0xc0, 0xc6, 0xb7, 0xbf, 0x95, 0x53, 0x55,
-	0x50, 0xb9, 0x51, 0x6c, 0x7a, 0xb4, 0xe1, 0x3b, 0x74, 0xb4, 0x7c, 0x24, 0x63, 0x49, 0xae, 0xbb,
-	0x03, 0xeb, 0xb0, 0xc7, 0x2b, 0x5c, 0x16, 0x92, 0x3e, 0x73, 0x31, 0xa2, 0x46, 0x35, 0x85, 0x54,
-	0xd2, 0xf0, 0x3c, 0xe1, 0xec, 0x62, 0x44, 0xfd, 0x6a, 0xf3, 0x0a, 0x57, 0x6d, 0xd3, 0x6f, 0xf4,
-	0xda, 0x3f, 0x2c, 0x00, 0x73, 0x18, 0x83, 0xf9, 0x23, 0x68, 0xe3, 0x2f, 0x05, 0x73, 0xeb, 0x78,
-	0xb7, 0x8a, 0x82, 0xde, 0xc2, 0x89, 0xd9, 0xf0, 0xbe, 0xb5, 0xe9, 0xfd, 0x3b, 0x22, 0x5b, 0xc5,
-	0xa4, 0xdd, 0x8c, 0xc9, 0x01, 0xb8, 0x13, 0x91, 0x9b, 0x2a, 0xd0, 0x41, 0xac, 0x09, 0x68, 0xe9,
-	0xd9, 0x8d, 0x98, 0x53, 0x05, 0xb9, 0x9c, 0xd6, 0x6b, 0x91, 0xe9, 0x6e, 0x44, 0xe6, 0x73, 0xe8,
-	0xe0, 0xe1, 0xc2, 0x87, 0x8d, 0xde, 0xd7, 0x76, 0x23, 0x8f, 0xeb, 0x1d, 0xc1, 0x1f, 0x5b, 0xe0,
-	0x68, 0xea, 0xff, 0xa9, 0x72, 0xf6, 0xa1, 0x57, 0x65, 0x44, 0xbb, 0x58, 0x61, 0xbc, 0xb9, 0x46,
-	0x51, 0x4e, 0xfe, 0xf5, 0x38, 0x2e, 0xb1, 0x45, 0x4e, 0xd3, 0x44, 0xc9, 0x44, 0xd1, 0xbd, 0xe9,
-	0x92, 0xea, 0x26, 0xc9, 0xfb, 0x25, 0xf4, 0x70, 0xae, 0xcc, 0x84, 0x12, 0xc6, 0x9d, 0x4f, 0x36,
-	0xdc, 0x79, 0x5a, 0xf2, 0xcf, 0x12, 0x95, 0xaf, 0x78, 0xb5, 0x7d, 0xff, 0xd7, 0xb0, 0xbd, 0xc6,
-	0x6a, 0xde, 0x9c, 0xee, 0x3b, 0x6e, 0x4e, 0xd7, 0xdc, 0x9c, 0xbf, 0x6a, 0xfd, 0xc2, 0x0a, 0xfe,
-	0x52, 0x55, 0x02, 0x06, 0xea, 0x7d, 0xc1, 0xa9, 0x5c, 0x6d, 0x6d, 0xb8, 0x8a, 0xd3, 0x46, 0xe4,
-	0xca, 0x5c, 0xf0, 0x36, 0x37, 0x08, 0x15, 0x86, 0x4a, 0xe4, 0xaa, 0x4c, 0x3f, 0x81, 0x87, 0x1a,
-	0x48, 0x87, 0xd8, 0xd9, 0x98, 0xf7, 0x54, 0x0e, 0xdd, 0xba, 0x1c, 0x02, 0x0e, 0x7d, 0x4a, 0xbf,
-	0x7c, 0x38, 0x99, 0xef, 0xb5, 0xd7, 0x83, 0x76, 0x23, 0x97, 0xb4, 0x0e, 0xbe, 0x86, 0xde, 0x38,
-	0x33, 0x8f, 0x8e, 0xcf, 0xc0, 0x19, 0x67, 0x94, 0x23, 0x8b, 0xde, 0x36, 0x3b, 0xcd, 0x91, 0x3c,
-	0xce, 0xb8, 0xe1, 0xa2, 0x1c, 0x6a, 0x36, 0x2d, 0x9f, 0xd6, 0x38, 0x21, 0x27, 0x62, 0x15, 0xa7,
-	0x62, 0x56, 0x5e, 0xe2, 0x06, 0x06, 0xe7, 0xd0, 0x3b, 0x15, 0xc9, 0x54, 0xc6, 0xe3, 0xec, 0x7f,
-	0xd1, 0x10, 0xfc, 0xc1, 0x82, 0x3e, 0x0d, 0x8d, 0xf2, 0x1a, 0xc0, 0xf9, 0x95, 0xe2, 0xfc, 0xb2,
-	0xfe, 0xc3, 0xfc, 0xc2, 0x4d, 0x75, 0x52, 0xf4, 0xcd, 0x50, 0x27, 0x05, 0x9f, 0x31, 0x66, 0x72,
-	0xb8, 0xdc, 0x20, 0x74, 0xe7, 0xab, 0xa5, 0xcc, 0x57, 0x17, 0x23, 0x1a, 0x1d, 0x2e, 0x2f, 0x61,
-	0xf0, 0xa7, 0x16, 0xb8, 0xe1, 0x1b, 0x91, 0xcb, 0xab, 0x28, 0x59, 0x34, 0xce, 0x5b, 0xef, 0x3b,
-	0xdf, 0x5a, 0x3b, 0x8f, 0xa3, 0x5e, 0xdb, 0x47, 0xa9, 0xd3, 0x2f, 0xc3, 0x06, 0x05, 0xf9, 0x3a,
-	0x61, 0xc4, 0x6f, 0x6b, 0x7e, 0x4d, 0x59, 0xeb, 0xd6, 0xce, 0x46, 0xb7, 0x56, 0x13, 0xdd, 0xf9,
-	0x6f, 0x26, 0xfa, 0x13, 0x70, 0xc6, 0x7a, 0x84, 0x74, 0xdf, 0x3f, 0x42, 0xcc, 0x16, 0x74, 0x74,
-	0x24, 0xa7, 0xf8, 0x16, 0xec, 0xe9, 0x67, 0xa2, 0x46, 0xd8, 0x6e, 0x97, 0x93, 0xc2, 0x44, 0x0f,
-	0x97, 0xc1, 0xef, 0xcb, 0xeb, 0xda, 0x74, 0x38, 0x5a, 0x7c, 0xfa, 0x66, 0x99, 0x2c, 0xae, 0x97,
-	0xf7, 0xe6, 0xbe, 0xae, 0x30, 0xc6, 0x29, 0x94, 0x73, 0xba, 0x1e, 0x74, 0x5e, 0x4a, 0x88, 0xa7,
-	0x42, 0x39, 0x6f, 0xde, 0xd8, 0x15, 0xc6, 0x49, 0x1a, 0xaa, 0x3c, 0xca, 0x24, 0x8a, 0xd4, 0x4d,
-	0x56, 0x13, 0x82, 0xbf, 0xb5, 0x51, 0xa1, 0x88, 0xcb, 0x37, 0x4e, 0x99, 0x08, 0x6b, 0x3d, 0x11,
-	0xfb, 0xd0, 0xbb, 0x94, 0x32, 0xa3, 0xe4, 0xe9, 0x1c, 0x55, 0x18, 0x93, 0x30, 0xc9, 0xd3, 0xb7,
-	0xd1, 0x8c, 0xb8, 0x26, 0x49, 0x35, 0xa5, 0x91, 0xf6, 0xf6, 0x5a, 0xda, 0xf7, 0xb5, 0x66, 0xea,
-	0x32, 0x93, 0x9c, 0x12, 0xa3, 0x4c, 0x5c, 0x9b, 0x19, 0xa0, 0x9b, 0xbd, 0x41, 0xf1, 0x7e, 0x0c,
-	0xdb, 0xe1, 0x72, 0x3a, 0x95, 0x45, 0x61, 0xb6, 0xe8, 0x0b, 0x75, 0x9d, 0x88, 0xc3, 0xf4, 0x26,
-	0x55, 0x95, 0x18, 0x7d, 0xa7, 0x36, 0x49, 0x68, 0x1b, 0xb5, 0x49, 0xe1, 0xbb, 0xf4, 0x75, 0x61,
-	0x10, 0x9e, 0x3c, 0x17, 0xcb, 0x58, 0x19, 0x26, 0x10, 0xb3, 0x49, 0xa2, 0xd2, 0x8a, 0x8b, 0x49,
-	0x9e, 0xa6, 0x77, 0x94, 0xd0, 0x3e, 0xaf, 0x30, 0xe6, 0x99, 0xcb, 0x82, 0x9a, 0xa1, 0xc7, 0x71,
-	0x89, 0xfe, 0x2c, 0x28, 0x5e, 0x61, 0x34, 0x4f, 0xfc, 0x6d, 0xda, 0xdf, 0xa0, 0xd0, 0x13, 0x3d,
-	0x4f, 0x89, 0xb9, 0x63, 0x9e, 0xf5, 0x1a, 0x36, 0x5e, 0x69, 0x8f, 0x75, 0xf4, 0xcc, 0x2b, 0x6d,
-	0x1f, 0x7a, 0x78, 0x41, 0x53, 0xf4, 0x3e, 0xd2, 0xd1, 0x2b, 0xb1, 0xf7, 0x25, 0x74, 0x75, 0x55,
-	0x15, 0xfe, 0x1e, 0x55, 0xeb, 0x66, 0x71, 0x9b, 0x6a, 0xe3, 0xe5, 0xb6, 0xaa, 0xec, 0x9e, 0x8b,
-	0xcc, 0xf7, 0xb5, 0x37, 0x25, 0x46, 0xdb, 0xce, 0x45, 0x14, 0x23, 0xeb, 0x63, 0x6d, 0x9b, 0x81,
-	0xc1, 0x02, 0xb6, 0x4e, 0xdf, 0x88, 0x24, 0x91, 0x31, 0x99, 0x7a, 0x00, 0xae, 0x81, 0x55, 0x01,
-	0xd5, 0x04, 0x9c, 0x29, 0x2f, 0x9b, 0xdf, 0x64, 0x04, 0x30, 0x54, 0x61, 0x34, 0x37, 0x63, 0x10,
-	0x97, 0xe4, 0xb0, 0xfe, 0xc6, 0x6a, 0xeb, 0xe6, 0xd1, 0x28, 0xf8, 0xb3, 0x05, 0xdd, 0xf0, 0x46,
-	0x9f, 0xda, 0x03, 0x27, 0x54, 0x42, 0x2d, 0x0b, 0xd3, 0x23, 0x06, 0xad, 0xcf, 0xad, 0x77, 0x5c,
-	0x26, 0xf6, 0xe6, 0x65, 0xa2, 0x2d, 0x6a, 0x37, 0x2d, 0x2a, 0x5f, 0x41, 0x9d, 0xc6, 0xc7, 0x03,
-	0xca, 0xc5, 0x31, 0xe6, 0x3b, 0x03, 0x9b, 0xe4, 0x22, 0xc0, 0x9d, 0x94, 0xb1, 0x2e, 0x7d, 0x54,
-	0xd1, 0x3a, 0xf8, 0x12, 0x9c, 0xcb, 0x97, 0xf8, 0x5a, 0xa6, 0x66, 0xaf, 0xbf, 0x4a, 0x2f, 0xf5,
-	0xdd, 0xfa, 0xdd, 0x08, 0x1c, 0x9d, 0x94, 0x63, 0xde, 0xdb, 0x06, 0x77, 0x98, 0xa7, 0x62, 0x76,
-	0x2a, 0x0a, 0xc5, 0x1e, 0x79, 0x5d, 0xb0, 0x27, 0x4b, 0xc5, 0x2c, 0x5c, 0x3c, 0x93, 0x8a, 0xb5,
-	0x3c, 0x00, 0xe7, 0x24, 0xcb, 0x64, 0x32, 0x63, 0x36, 0xae, 0xf5, 0xfd, 0xc6, 0xda, 0x47, 0xff,
-	0xb2, 0xe9, 0x73, 0x9c, 0x84, 0xb8, 0xd0, 0x79, 0x95, 0xa7, 0xc9, 0x9c, 0x3d, 0xf2, 0x7a, 0xe8,
-	0x49, 0x2c, 0x99, 0x85, 0x92, 0x27, 0xcb, 0xdb, 0x38, 0xc2, 0x29, 0xa4, 0xe5, 0xe8, 0xcf, 0x50,
-	0x66, 0xa3, 0xf0, 0xab, 0xf3, 0x90, 0xb5, 0xf1, 0x20, 0x36, 0x66, 0xc1, 0x3a, 0xde, 0x16, 0x8a,
-	0xc3, 0xda, 0x2c, 0x98, 0x43, 0x67, 0x4d, 0x33, 0x17, 0xac, 0x8b, 0xdb, 0xa8, 0x03, 0x18, 0x78,
-	0x7d, 0x6c, 0x81, 0x74, 0xba, 0x98, 0xa4, 0x05, 0xdb, 0x42, 0x54, 0xb6, 0x2f, 0xeb, 0x93, 0xf1,
-	0x69, 0xc1, 0xb6, 0x51, 0x97, 0x2e, 0x32, 0xb6, 0x83, 0xa2, 0x42, 0x35, 0x11, 0x2b, 0x8c, 0x14,
-	0xdb, 0xf5, 0x76, 0x68, 0x70, 0x9c, 0xcc, 0x66, 0x84, 0x19, 0x62, 0xcd, 0xc6, 0xe8, 0xb2, 0x0f,
-	0x70, 0xfb, 0x6f, 0xa4, 0xc8, 0xd5, 0x50, 0x0a, 0xc5, 0x1e, 0xa3, 0x02, 0x9a, 0x1c, 0x49, 0xa4,
-	0xd8, 0x47, 0xb8, 0x19, 0xd1, 0x75, 0xaa, 0xa2, 0xbb, 0x15, 0xdb, 0xc3, 0xcd, 0x88, 0x29, 0xe3,
-	0xec, 0x7b, 0xe5, 0xe6, 0x50, 0xa5, 0x19, 0xf3, 0x91, 0x89, 0xb6, 0xc5, 0x32, 0x99, 0x4b, 0xf6,
-	0x31, 0xda, 0xc4, 0x65, 0x26, 0xa2, 0x9c, 0xed, 0x7b, 0x1f, 0xc2, 0xee, 0xd9, 0x37, 0x4a, 0xe6,
-	0x89, 0x88, 0x4f, 0x66, 0xb3, 0x5c, 0x16, 0x05, 0xfb, 0x3e, 0x06, 0x20, 0x54, 0x69, 0x2e, 0xe6,
-	0x92, 0x1d, 0x20, 0x98, 0xe4, 0xe9, 0x57, 0xcb, 0x48, 0xb1, 0x4f, 0xd0, 0x7d, 0x9a, 0x89, 0xec,
-	0x53, 0x5c, 0x8e, 0xef, 0xee, 0x64, 0xce, 0x7e, 0x40, 0xca, 0x33, 0x0c, 0x59, 0x94, 0xcc, 0xd9,
-	0x00, 0x4f, 0x98, 0xba, 0x67, 0x3f, 0x44, 0x65, 0x17, 0xc9, 0x34, 0xbd, 0x97, 0xec, 0x27, 0x86,
-	0x11, 0x4f, 0xc4, 0x8a, 0x1d, 0x22, 0xb8, 0x12, 0x05, 0x3a, 0xcc, 0x3e, 0x27, 0x25, 0x69, 0x81,
-	0xaf, 0x33, 0x76, 0x44, 0xea, 0x65, 0x81, 0x1f, 0x69, 0xec, 0x89, 0xf7, 0x41, 0x79, 0x45, 0xe8,
-	0xa1, 0x5d, 0xb0, 0x2f, 0xd0, 0xb9, 0xe7, 0xe9, 0x5b, 0x89, 0x65, 0xc6, 0x7e, 0x7a, 0xf4, 0x0c,
-	0x3a, 0xf4, 0x20, 0x20, 0x83, 0xb2, 0xb3, 0x3c, 0x67, 0x8f, 0xf4, 0xf2, 0x64, 0x36, 0x63, 0x16,
-	0x6e, 0x1e, 0x67, 0xa6, 0x6c, 0x5a, 0x1a, 0x99, 0xc2, 0xb1, 0x35, 0xd2, 0x0f, 0x0e, 0xd6, 0xbe,
-	0x75, 0xe8, 0x5f, 0x9e, 0x9f, 0xff, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xf1, 0x53, 0x73, 0x9f, 0xf3,
-	0x11, 0x00, 0x00,
+	// 2755 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xcd, 0x8f, 0x23, 0x47,
+	0x15, 0x4f, 0xdb, 0xed, 0x8f, 0x7e, 0xf6, 0xcc, 0x56, 0x3a, 0x93, 0xc1, 0x59, 0x36, 0x61, 0x68,
+	0xa2, 0x64, 0xb2, 0x09, 0x4b, 0x32, 0x89, 0xf8, 0x3c, 0xed, 0x8c, 0x67, 0x93, 0xd1, 0x78, 0xd7,
+	0x4e, 0x79, 0x76, 0xb3, 0xc7, 0xd4, 0xd8, 0x35, 0xde, 0x66, 0x3c, 0xdd, 0xad, 0xee, 0xf6, 0x66,
+	0x8d, 0x84, 0x10, 0x12, 0x12, 0x17, 0xae, 0x20, 0x71, 0x80, 0x23, 0x7f, 0x02, 0x48, 0x20, 0xfe,
+	0x00, 0xce, 0xdc, 0x10, 0x17, 0xfe, 0x01, 0x6e, 0xdc, 0xd1, 0x7b, 0xaf, 0xaa, 0xbb, 0xed, 0xf9,
+	0x48, 0x24, 0x38, 0xb9, 0x7e, 0xef, 0xd5, 0xc7, 0xfb, 0xaa, 0xf7, 0x5e, 0xb5, 0x01, 0x2e, 0xf4,
+	0x59, 0x76, 0x2f, 0x49, 0xe3, 0x3c, 0xf6, 0x5b, 0x3c, 0x3e, 0x0d, 0x7e, 0xee, 0x40, 0xeb, 0x58,
+	0x2f, 0x1f, 0xea, 0x5c, 0xf9, 0x3d, 0x68, 0x3d, 0xd7, 0x69, 0x16, 0xc6, 0x51, 0xcf, 0xd9, 0x71,
+	0x76, 0x1b, 0xd2, 0x42, 0xff, 0x2e, 0xb4, 0xce, 0xf5, 0xf2, 0x64, 0x99, 0xe8, 0x5e, 0x6d, 0xc7,
+	0xd9, 0xdd, 0xdc, 0x13, 0xf7, 0xcc, 0x06, 0xf7, 0x8e, 0x99, 0x2e, 0xed, 0x04, 0x7f, 0x1b, 0x9a,
+	0x17, 0x2a, 0x8c, 0x8e, 0xfa, 0xbd, 0xfa, 0x8e, 0xb3, 0xeb, 0x49, 0x83, 0x70, 0xf7, 0x38, 0xc9,
+	0xc3, 0x38, 0xca, 0x7a, 0xee, 0x4e, 0x7d, 0xd7, 0x93, 0x16, 0x06, 0x8f, 0xa0, 0x29, 0xf5, 0x24,
+	0x4e, 0xa7, 0xbe, 0x80, 0xfa, 0xb9, 0x5e, 0xd2, 0xe9, 0x5d, 0x89, 0x43, 0x7f, 0x0b, 0x1a, 0xcf,
+	0xd5, 0x7c, 0xc1, 0xe7, 0x76, 0x25, 0x03, 0xff, 0x0e, 0x78, 0x59, 0x38, 0x8b, 0x54, 0xbe, 0x48,
+	0x35, 0x1d, 0xd3, 0x95, 0x25, 0x21, 0x78, 0x0a, 0xcd, 0xfd, 0xc1, 0xf8, 0x58, 0x2f, 0x6f, 0xd0,
+	0x68, 0x1b, 0x9a, 0xc9, 0xe2, 0xf4, 0x58, 0x2f, 0xcd, 0xc6, 0x06, 0xd1, 0xce, 0x7a, 0x92, 0xea,
+	0x1c, 0x59, 0x76, 0x67, 0x4b, 0x08, 0xfe, 0xe3, 0xc0, 0xad, 0xc7, 0x99, 0x4e, 0xf7, 0x07, 0xe3,
+	0x0f, 0xf6, 0x0e, 0xe2, 0xe8, 0x2c, 0x9c, 0xdd, 0x70, 0xc6, 0x1d, 0xf0, 0x92, 0xc5, 0xe9, 0xb9,
+	0x5e, 0xee, 0xcf, 0x33, 0x73, 0x4c, 0x49, 0xc0, 0x75, 0x0c, 0x3e, 0x36, 0xe7, 0x58, 0x58, 0x72,
+	0x1e, 0x93, 0xa5, 0x0a, 0xce, 0xe3, 0x92, 0xf3, 0x59, 0xaf, 0x51, 0xe5, 0x7c, 0x46, 0x67, 0xa5,
+	0xa1, 0x39, 0xab, 0x69, 0xce, 0xb2, 0x04, 0xbf, 0x0b, 0xce, 0xd3, 0x5e, 0x8b, 0xa8, 0xce, 0x53,
+	0xb4, 0xe9, 0x24, 0x5e, 0x44, 0x79, 0x0f, 0x48, 0x5e, 0x06, 0xfe, 0x6d, 0x68, 0xe7, 0x6a, 0x76,
+	0x40, 0x8c, 0x0e, 0x31, 0x0a, 0x1c, 0x3c, 0x01, 0xd8, 0x5f, 0x4c, 0xce, 0x75, 0x2e, 0xe3, 0x98,
+	0x66, 0x32, 0x3a, 0xea, 0x93, 0xca, 0x75, 0x59, 0x60, 0x94, 0x70, 0x98, 0xf0, 0x26, 0x35, 0x62,
+	0x59, 0xe8, 0xfb, 0xe0, 0xe2, 0x6a, 0xa3, 0x2c, 0x8d, 0x83, 0xcf, 0xa1, 0x35, 0x38, 0xcb, 0x68,
+	0xd3, 0x2d, 0x68, 0x1c, 0x9c, 0x84, 0x17, 0xda, 0xec, 0xc8, 0xa0, 0x58, 0x54, 0x2b, 0x17, 0xf9,
+	0xef, 0x42, 0x73, 0x1f, 0x07, 0x59, 0xaf, 0xbe, 0x53, 0xdf, 0xed, 0xec, 0xbd, 0x52, 0xc4, 0x62,
+	0x29, 0xa3, 0x34, 0x53, 0x82, 0x3f, 0x3a, 0xb0, 0x39, 0x5e, 0x24, 0x3a, 0xdd, 0x9f, 0xc7, 0x93,
+	0xf3, 0xa3, 0xe8, 0x2c, 0x46, 0x11, 0x9f, 0xac, 0x3a, 0xcc, 0x40, 0x7f, 0x17, 0x6e, 0xe1, 0x45,
+	0xd8, 0x57, 0x93, 0xf3, 0x45, 0x45, 0x89, 0x86, 0x5c, 0x27, 0x97, 0xd2, 0xd6, 0xab, 0xd2, 0x06,
+	0xd0, 0x7d, 0xa4, 0x5f, 0xe4, 0x85, 0x71, 0x5c, 0x62, 0xae, 0xd0, 0xfc, 0xb7, 0xa0, 0x31, 0x20,
+	0x95, 0x5a, 0x24, 0x7c, 0x79, 0x91, 0x8c, 0x21, 0x24, 0xb3, 0x83, 0xbf, 0xd4, 0x60, 0x83, 0x17,
+	0x0d, 0xf9, 0x9a, 0xdc, 0x20, 0xf7, 0x36, 0x34, 0x47, 0xf1, 0x3c, 0x9c, 0x2c, 0x8d, 0xb8, 0x06,
+	0x61, 0x50, 0xf4, 0x55, 0xae, 0x58, 0x93, 0x3a, 0xb1, 0x4a, 0x82, 0xbf, 0x03, 0x9d, 0x91, 0x4a,
+	0xc3, 0x7c, 0xc9, 0x7c, 0x97, 0xf8, 0x55, 0x12, 0x9e, 0x78, 0xa2, 0x66, 0x0f, 0xe6, 0x6a, 0xd6,
+	0x6b, 0xf0, 0x89, 0x06, 0xe2, 0xda, 0xb1, 0x9e, 0x5d, 0xe8, 0x28, 0x1f, 0x87, 0x3f, 0xd1, 0x14,
+	0x70, 0x0d, 0x59, 0x25, 0xa1, 0x2d, 0x0c, 0xe4, 0xed, 0x5b, 0x34, 0x65, 0x85, 0xe6, 0xbf, 0x01,
+	0x70, 0x18, 0x4d, 0xd2, 0x25, 0x29, 0xd8, 0x6b, 0xd3, 0x8c, 0x0a, 0x05, 0xf9, 0x83, 0x78, 0xa2,
+	0xe6, 0xbc, 0x83, 0xc7, 0xfc, 0x92, 0x82, 0xf2, 0x8d, 0xb5, 0x9a, 0x1f, 0xf5, 0xb3, 0x1e, 0x70,
+	0x4a, 0x31, 0x30, 0xf8, 0x43, 0xdd, 0x46, 0x2c, 0xb9, 0xdc, 0x07, 0xf7, 0x91, 0x32, 0xb1, 0xe5,
+	0x49, 0x1a, 0xaf, 0x44, 0x71, 0x6d, 0x2d, 0x8a, 0xaf, 0x76, 0xef, 0x7b, 0xd0, 0xd8, 0x1f, 0x26,
+	0x79, 0x46, 0xa6, 0xea, 0xec, 0x6d, 0xaf, 0xc5, 0x9d, 0xf1, 0x93, 0xe4, 0x49, 0xe8, 0x94, 0x81,
+	0x8e, 0x66, 0xf9, 0x33, 0xb2, 0x5d, 0x5d, 0x1a, 0x84, 0x7b, 0x3f, 0xa4, 0xbd, 0x5b, 0xbc, 0x37,
+	0x01, 0xff, 0x2e, 0x88, 0xe1, 0xe9, 0x8f, 0xf5, 0x24, 0xcf, 0x28, 0x50, 0xc9, 0xaa, 0x6d, 0x9a,
+	0x70, 0x89, 0x8e, 0x92, 0xf7, 0xf5, 0x5c, 0x93, 0xd1, 0xd0, 0x28, 0x6d, 0x59, 0x60, 0x1b, 0x82,
+	0xbc, 0xe6, 0xa8, 0xdf, 0x83, 0x32, 0x04, 0x2d, 0x0d, 0xd7, 0x13, 0x4e, 0x8e, 0xfa, 0x74, 0xd3,
+	0xeb, 0xb2, 0xc0, 0xc5, 0x85, 0xeb, 0x56, 0x2e, 0xdc, 0xfb, 0xd0, 0x1a, 0xce, 0xa7, 0xa4, 0xf9,
+	0xc6, 0x8d, 0x9a, 0xdb, 0x69, 0x78, 0x91, 0x4e, 0x52, 0x15, 0x65, 0x93, 0x78, 0xaa, 0xc7, 0x79,
+	0x1a, 0x26, 0xba, 0xb7, 0x49, 0x07, 0xad, 0x93, 0x83, 0x7f, 0x3b, 0x00, 0x46, 0x30, 0x74, 0xd4,
+	0xb7, 0xc0, 0xc5, 0x5f, 0x72, 0x54, 0x67, 0xef, 0x56, 0x71, 0x0e, 0x4f, 0x91, 0xc4, 0xac, 0x58,
+	0xb6, 0xb6, 0x6e, 0xd9, 0x2b, 0xbc, 0x56, 0xd8, 0xdb, 0xad, 0xda, 0xfb, 0x0e, 0x78, 0x23, 0x95,
+	0x9a, 0xd8, 0x64, 0x07, 0x95, 0x04, 0xb4, 0xc2, 0xe1, 0x89, 0x9a, 0x51, 0x5c, 0x7b, 0x92, 0xc6,
+	0x2b, 0x56, 0x6f, 0xad, 0x59, 0xfd, 0x1d, 0x68, 0xe0, 0x62, 0x0e, 0xc3, 0x6a, 0x46, 0x62, 0xb9,
+	0x91, 0x27, 0x79, 0x46, 0xf0, 0x9b, 0x1a, 0x34, 0x99, 0xfa, 0x7f, 0x8a, 0xca, 0xdb, 0xd0, 0x2e,
+	0xbc, 0xcd, 0x2a, 0x16, 0x18, 0xeb, 0x69, 0x3f, 0x4c, 0x49, 0xbf, 0xb6, 0xc4, 0x21, 0x5e, 0xdc,
+	0x83, 0x38, 0xca, 0x75, 0x94, 0x53, 0x35, 0xf7, 0xe8, 0xe8, 0x2a, 0xc9, 0xff, 0x01, 0xb4, 0x31,
+	0xdb, 0x4d, 0x55, 0xae, 0x8c, 0x3a, 0xaf, 0xaf, 0xa9, 0x73, 0xcf, 0xf2, 0x0f, 0xa3, 0x3c, 0x5d,
+	0xca, 0x62, 0xfa, 0xed, 0x1f, 0xc1, 0xc6, 0x0a, 0xab, 0x5a, 0xcf, 0xbd, 0x2b, 0xea, 0xb9, 0x67,
+	0xea, 0xf9, 0x0f, 0x6b, 0xdf, 0x77, 0x82, 0x7f, 0x14, 0x91, 0x80, 0x86, 0xba, 0xce, 0x38, 0x85,
+	0xaa, 0xb5, 0x35, 0x55, 0x31, 0x07, 0xaa, 0x34, 0x37, 0x6d, 0x47, 0x5d, 0x1a, 0x84, 0x07, 0x8e,
+	0x73, 0x95, 0xe6, 0xd6, 0xfd, 0x04, 0x6e, 0xba, 0x9c, 0x6c, 0xe2, 0xe6, 0x5a, 0x15, 0xa2, 0x70,
+	0x68, 0x55, 0xc2, 0xa1, 0x48, 0x06, 0xed, 0xaf, 0x90, 0x0c, 0x02, 0x09, 0x5d, 0x0a, 0x16, 0x7d,
+	0xb3, 0xeb, 0xaf, 0xd5, 0xce, 0x07, 0xb7, 0xe2, 0x79, 0x1a, 0x07, 0x9f, 0x43, 0x7b, 0x98, 0x98,
+	0xc6, 0xe9, 0x2d, 0x68, 0x0e, 0x13, 0xf2, 0xa8, 0x43, 0xfd, 0xd9, 0x66, 0xb5, 0xac, 0x0c, 0x13,
+	0x69, 0xb8, 0xb8, 0xcf, 0x30, 0x29, 0xf6, 0xa7, 0x31, 0x66, 0xd1, 0x91, 0x5a, 0xce, 0x63, 0x35,
+	0xb5, 0x8d, 0x88, 0x81, 0xc1, 0x03, 0x68, 0x1f, 0xa8, 0x68, 0xa2, 0xe7, 0xc3, 0xe4, 0x7f, 0x39,
+	0x21, 0xf8, 0x85, 0x03, 0x5d, 0x4a, 0x5f, 0xb6, 0x94, 0xa1, 0xf1, 0x62, 0x34, 0x9e, 0xf3, 0x25,
+	0xc6, 0xc3, 0x49, 0xa5, 0x0b, 0xb9, 0xba, 0x95, 0x2e, 0xc4, 0x56, 0xcc, 0xe4, 0x30, 0x4f, 0x1a,
+	0x84, 0xea, 0x7c, 0xba, 0xd0, 0xe9, 0xf2, 0xa8, 0x4f, 0x49, 0xcc, 0x93, 0x16, 0x06, 0xbf, 0xaf,
+	0x81, 0x37, 0x7e, 0xa6, 0x52, 0x3d, 0x08, 0xa3, 0xf3, 0xca, 0x7a, 0xe7, 0xba, 0xf5, 0xb5, 0x95,
+	0xf5, 0x58, 0x8e, 0x58, 0x3e, 0x72, 0x1d, 0x77, 0xb7, 0x15, 0x0a, 0xf2, 0xd9, 0x61, 0xc4, 0x77,
+	0x99, 0x5f, 0x52, 0x56, 0xee, 0x76, 0x63, 0xed, 0x6e, 0x17, 0xe1, 0xd4, 0xfc, 0x2a, 0xb5, 0xe5,
+	0x5d, 0x68, 0x0e, 0x39, 0xe1, 0xb4, 0xae, 0x4f, 0x38, 0x66, 0x0a, 0x2a, 0xda, 0xd7, 0x13, 0xec,
+	0x67, 0xdb, 0xdc, 0xea, 0x32, 0xc2, 0xcb, 0x79, 0x3c, 0xca, 0x8c, 0xf5, 0x70, 0x18, 0xfc, 0xcc,
+	0xb6, 0x1c, 0x26, 0x1f, 0xa0, 0xc4, 0x07, 0xcf, 0x16, 0xd1, 0xf9, 0xa3, 0xc5, 0x85, 0xe9, 0x39,
+	0x0a, 0xcc, 0xc5, 0x77, 0x46, 0x85, 0x8a, 0xfd, 0x62, 0x21, 0xae, 0x1a, 0xeb, 0x59, 0xb5, 0xeb,
+	0x28, 0x30, 0xe6, 0x5d, 0xce, 0xfc, 0xb8, 0x25, 0x5f, 0xc9, 0x92, 0x10, 0xfc, 0xd9, 0xc5, 0x03,
+	0xd5, 0xdc, 0xf6, 0x69, 0xd6, 0x11, 0xce, 0xaa, 0x23, 0x6e, 0x43, 0xfb, 0x58, 0xeb, 0x84, 0x9c,
+	0xc7, 0x3e, 0x2a, 0x30, 0x3a, 0x61, 0x94, 0xc6, 0xcf, 0xc3, 0x29, 0x71, 0x8d, 0x93, 0x4a, 0x4a,
+	0xc5, 0xed, 0xee, 0x8a, 0xdb, 0x6f, 0xf3, 0xc9, 0x74, 0xcb, 0x8c, 0x73, 0x2c, 0xc6, 0x3d, 0x71,
+	0x6c, 0x32, 0x06, 0xa7, 0x86, 0x0a, 0xc5, 0x7f, 0x13, 0x36, 0xc6, 0x8b, 0xc9, 0x44, 0x67, 0x99,
+	0x99, 0xc2, 0xa5, 0x7d, 0x95, 0x88, 0xa9, 0xf7, 0x24, 0xce, 0x8b, 0x6d, 0xb8, 0xba, 0x57, 0x49,
+	0x28, 0x1b, 0x5d, 0x93, 0xac, 0xe7, 0x51, 0x3b, 0x63, 0x10, 0xae, 0x7c, 0xa0, 0x16, 0xf3, 0xdc,
+	0x30, 0xb9, 0xd7, 0xa9, 0x92, 0x28, 0xb4, 0xe6, 0xd9, 0x28, 0x8d, 0xe3, 0x33, 0x72, 0x68, 0x57,
+	0x16, 0x18, 0xfd, 0x2c, 0x75, 0x46, 0x97, 0xa1, 0x2d, 0x71, 0x88, 0xfa, 0x9c, 0x93, 0xbd, 0xc6,
+	0xe1, 0x2c, 0xa2, 0x9a, 0xde, 0x95, 0x15, 0x0a, 0x3d, 0x33, 0xd2, 0x98, 0x98, 0x9b, 0xe6, 0x69,
+	0xc2, 0xb0, 0xd2, 0x69, 0x6e, 0xb1, 0xf5, 0x18, 0xe1, 0xf9, 0xd8, 0x2a, 0x90, 0xf5, 0x5e, 0x65,
+	0xeb, 0x59, 0x8c, 0xed, 0x03, 0x47, 0x55, 0xd6, 0xdb, 0xde, 0xa9, 0x5f, 0x11, 0xdc, 0x26, 0xda,
+	0xa4, 0x9d, 0x56, 0x84, 0xdd, 0x43, 0x95, 0xf4, 0x7a, 0xac, 0x8d, 0xc5, 0x28, 0xdb, 0x03, 0x15,
+	0xce, 0x91, 0xf5, 0x1a, 0xcb, 0x66, 0x60, 0x70, 0x04, 0xde, 0x28, 0xce, 0x72, 0x56, 0x9a, 0x63,
+	0x10, 0x5b, 0xcd, 0xcc, 0x3c, 0x27, 0x0b, 0xbc, 0x6e, 0xce, 0xda, 0x25, 0x73, 0x06, 0xbf, 0x72,
+	0xc0, 0x43, 0xff, 0xee, 0xab, 0x7c, 0xf2, 0x6c, 0x25, 0xdc, 0x9c, 0x1b, 0xc3, 0xad, 0x76, 0x29,
+	0xdc, 0xaa, 0x61, 0x55, 0x5f, 0x0b, 0xab, 0xb7, 0xa1, 0x81, 0x63, 0x7e, 0x0f, 0x77, 0xf6, 0x5e,
+	0x2e, 0xcc, 0x62, 0xaf, 0x80, 0x64, 0x7e, 0xf0, 0x3d, 0xf0, 0x06, 0x5a, 0x9d, 0xb1, 0x66, 0x5b,
+	0xd0, 0x38, 0x8a, 0xa6, 0xfa, 0x85, 0x7d, 0x28, 0x11, 0x40, 0x2a, 0x7b, 0xbf, 0x46, 0xef, 0x42,
+	0x06, 0xc1, 0x9f, 0x6a, 0xd0, 0x31, 0x19, 0x81, 0xd6, 0x5e, 0x97, 0xf3, 0x7c, 0x70, 0x1f, 0x64,
+	0x85, 0xfc, 0x34, 0xc6, 0xb9, 0xe8, 0xc2, 0xf2, 0x1d, 0xcf, 0xa8, 0x2c, 0x91, 0xee, 0x5a, 0x17,
+	0x72, 0x6d, 0x6e, 0xab, 0xbc, 0x09, 0x9b, 0xab, 0x6f, 0xc2, 0x37, 0xaa, 0xef, 0x4a, 0xf3, 0x40,
+	0xad, 0x50, 0xfc, 0x37, 0xa1, 0x3e, 0x4c, 0xb0, 0xc4, 0xa2, 0x7d, 0xfc, 0xb2, 0xe2, 0x58, 0x63,
+	0x48, 0x64, 0x63, 0x4e, 0x19, 0x9c, 0x65, 0x03, 0xad, 0x9e, 0xeb, 0x8c, 0x3a, 0x9a, 0xba, 0x2c,
+	0x09, 0xfe, 0x47, 0xd0, 0xe1, 0x1d, 0xd9, 0x3e, 0xb0, 0xe3, 0x5c, 0xb3, 0x57, 0x75, 0x5a, 0xf0,
+	0xdb, 0x3a, 0xb4, 0xe8, 0x86, 0xc7, 0xb3, 0x1b, 0x12, 0x51, 0x69, 0xcf, 0xda, 0x8a, 0x3d, 0xbf,
+	0x2c, 0x09, 0x55, 0x23, 0xca, 0x5d, 0x8b, 0xa8, 0x9b, 0x12, 0x11, 0x76, 0xad, 0x28, 0x5e, 0xa5,
+	0x45, 0x29, 0x09, 0x95, 0xcb, 0xd9, 0x5a, 0xb9, 0x9c, 0x3d, 0x56, 0x05, 0x33, 0x2e, 0x27, 0x1d,
+	0x0b, 0xf1, 0x2c, 0xca, 0x3f, 0xc8, 0x62, 0xc3, 0x15, 0x78, 0x2d, 0xe9, 0xc1, 0x97, 0x27, 0xbd,
+	0xce, 0x35, 0x49, 0xaf, 0x7a, 0xd7, 0xba, 0x97, 0x53, 0x97, 0x49, 0x4f, 0x1b, 0x2b, 0xe9, 0xe9,
+	0xb8, 0x4c, 0x4f, 0x9c, 0x81, 0x2a, 0x94, 0xe0, 0x43, 0xe8, 0x18, 0xd7, 0x0c, 0xc2, 0x0c, 0x83,
+	0xc4, 0x1d, 0xc4, 0x33, 0xbc, 0xe6, 0xab, 0x0f, 0x6a, 0x33, 0x47, 0x12, 0x37, 0xf8, 0xb5, 0x43,
+	0xab, 0xa2, 0x48, 0xcf, 0x29, 0x93, 0xdd, 0x01, 0xcf, 0xc0, 0xc2, 0xad, 0x25, 0x01, 0x83, 0xfc,
+	0x49, 0xf5, 0xb3, 0x13, 0x01, 0x14, 0x75, 0x1c, 0xce, 0x4c, 0x97, 0x84, 0x43, 0x32, 0x39, 0x7f,
+	0x46, 0x72, 0x89, 0x68, 0x90, 0xbf, 0x0b, 0x8d, 0x93, 0xf8, 0x5c, 0x47, 0xbd, 0xc6, 0x5a, 0xb8,
+	0x49, 0xad, 0xa6, 0xc4, 0x91, 0x3c, 0x21, 0xf8, 0x9d, 0x03, 0x5e, 0x41, 0xbc, 0xb9, 0xe6, 0xf1,
+	0x27, 0x8c, 0xbe, 0xcd, 0x58, 0x05, 0xa6, 0xab, 0xaa, 0xd5, 0x54, 0xa7, 0xc5, 0x55, 0x25, 0x84,
+	0xf4, 0xa3, 0x2c, 0x5b, 0xe8, 0xd4, 0xd6, 0x3a, 0x46, 0x48, 0x3f, 0x7c, 0x91, 0x84, 0xa9, 0x0d,
+	0x30, 0x83, 0x30, 0x0d, 0x90, 0xc9, 0xf9, 0xfb, 0x11, 0x8d, 0x83, 0xbf, 0x3a, 0xd0, 0x1a, 0x9f,
+	0xb0, 0xfe, 0xdb, 0xd0, 0x1c, 0xe7, 0x2a, 0x5f, 0x64, 0xa6, 0x19, 0x30, 0x68, 0xb5, 0x41, 0xbb,
+	0xa2, 0xc7, 0xae, 0xaf, 0xf7, 0xd8, 0x6c, 0x5b, 0xb7, 0x6a, 0x5b, 0xfb, 0xf0, 0x6c, 0x54, 0x1e,
+	0x9e, 0xb8, 0x2f, 0xf6, 0x6b, 0xbd, 0xe6, 0x4e, 0x9d, 0xf6, 0x45, 0x50, 0x48, 0xd9, 0xa2, 0x4c,
+	0xe7, 0xda, 0x8a, 0x65, 0xbf, 0x8d, 0xb4, 0x57, 0xbe, 0x8d, 0x04, 0xef, 0x43, 0xf3, 0xf8, 0x09,
+	0x7e, 0xf4, 0xa0, 0x7e, 0xa7, 0xfc, 0xb8, 0x78, 0xcc, 0x8f, 0x91, 0xcb, 0x5e, 0x0e, 0xf6, 0xa0,
+	0x39, 0x3e, 0xc1, 0xb4, 0x50, 0x74, 0xdd, 0x4e, 0xd9, 0x75, 0x93, 0x4c, 0x89, 0x9a, 0xe8, 0x42,
+	0x57, 0x04, 0xc1, 0x4f, 0xa1, 0x3d, 0x3e, 0x31, 0x09, 0x27, 0x80, 0xfa, 0x48, 0x2d, 0x4d, 0x6b,
+	0x5b, 0x86, 0xa3, 0x31, 0xa2, 0x44, 0xa6, 0xff, 0x36, 0x34, 0x79, 0x36, 0xf9, 0xb2, 0xfa, 0xd2,
+	0xe5, 0xa3, 0xa5, 0x61, 0x17, 0xc1, 0x5d, 0xbf, 0x31, 0xb8, 0x7f, 0x49, 0x4e, 0x3a, 0x98, 0xab,
+	0xf0, 0xa2, 0x74, 0x86, 0x73, 0xb5, 0x33, 0x6a, 0x57, 0x3b, 0xa3, 0x7e, 0x95, 0x33, 0xdc, 0x8a,
+	0x33, 0xac, 0x24, 0x8d, 0x1b, 0x25, 0xf9, 0x97, 0x03, 0x9d, 0x87, 0x2a, 0xc5, 0x96, 0xf5, 0xec,
+	0x4c, 0xa7, 0x6b, 0x99, 0xd0, 0xb9, 0x94, 0x09, 0xb1, 0x36, 0x9c, 0x9d, 0x55, 0x52, 0xa8, 0x85,
+	0x94, 0x07, 0x55, 0xa2, 0x26, 0x61, 0xbe, 0x2c, 0x2a, 0xa7, 0xc1, 0xc8, 0xeb, 0x2f, 0x52, 0x45,
+	0x6f, 0x71, 0xf3, 0xe6, 0xb5, 0x98, 0x2b, 0x61, 0x38, 0xd1, 0x26, 0x92, 0x18, 0xa0, 0xfe, 0x07,
+	0xa9, 0x9e, 0x86, 0xb6, 0x04, 0x19, 0x84, 0x3b, 0xe1, 0x07, 0xa5, 0xe2, 0x55, 0xef, 0xc9, 0x02,
+	0xe3, 0x9a, 0x61, 0x34, 0x0f, 0x23, 0xfe, 0x12, 0xd3, 0x96, 0x06, 0x05, 0xdf, 0x85, 0x26, 0xab,
+	0xe8, 0xbf, 0x07, 0x4d, 0x12, 0xd7, 0x26, 0x9f, 0xad, 0xc2, 0x2a, 0x15, 0x1b, 0x48, 0x33, 0x27,
+	0x78, 0x6c, 0x4d, 0xf3, 0xe9, 0x22, 0xce, 0x6d, 0x32, 0x27, 0x43, 0xf0, 0x7a, 0x4f, 0x96, 0x84,
+	0x52, 0x8d, 0x5a, 0x55, 0x0d, 0x1f, 0xdc, 0x83, 0x38, 0x2b, 0x3e, 0xa2, 0xe2, 0x38, 0xf8, 0xbb,
+	0x03, 0xde, 0x7e, 0x38, 0x9f, 0xf3, 0x93, 0x7b, 0x0b, 0x1a, 0xc3, 0x2f, 0x22, 0x9d, 0x1a, 0x5b,
+	0x33, 0x28, 0x22, 0xb9, 0x56, 0x89, 0x64, 0x1f, 0xdc, 0xe3, 0x30, 0x9a, 0x9a, 0x9c, 0x41, 0xe3,
+	0x6b, 0xee, 0x26, 0xe6, 0x91, 0x68, 0x12, 0x9b, 0x82, 0xd4, 0x96, 0x06, 0xe1, 0x0e, 0x23, 0xad,
+	0x53, 0xfb, 0x99, 0x04, 0xc7, 0xe4, 0xb6, 0x38, 0xca, 0x53, 0x35, 0xc9, 0xad, 0x41, 0x2d, 0xc6,
+	0xf9, 0x0f, 0xf5, 0x45, 0x4c, 0xe6, 0xf4, 0x24, 0x8d, 0x71, 0xef, 0x93, 0x17, 0x9f, 0xa8, 0xec,
+	0x99, 0xf9, 0x16, 0x61, 0x50, 0xf0, 0x37, 0xfc, 0xd4, 0x9e, 0x60, 0xd6, 0x0f, 0xa3, 0x99, 0x79,
+	0xe5, 0xee, 0x40, 0xa7, 0x20, 0x15, 0xd1, 0x54, 0x25, 0xa1, 0x4d, 0xef, 0x2f, 0xf2, 0x58, 0xea,
+	0x48, 0x7f, 0x41, 0xca, 0xb6, 0x65, 0x49, 0x40, 0x2e, 0x0d, 0xfa, 0x6a, 0x99, 0x99, 0x98, 0x2a,
+	0x09, 0x2b, 0x01, 0xe7, 0x5e, 0x0e, 0x38, 0x9a, 0x78, 0x18, 0x4d, 0x6d, 0x51, 0xb6, 0x18, 0xa5,
+	0xfa, 0x38, 0x55, 0x13, 0x3d, 0xd2, 0x69, 0x18, 0x4f, 0x4d, 0x7c, 0x55, 0x49, 0x77, 0xef, 0xdb,
+	0xb7, 0xb3, 0xbf, 0x01, 0xde, 0x7e, 0x1a, 0xab, 0xe9, 0x81, 0xca, 0x72, 0xf1, 0x92, 0xdf, 0x82,
+	0xfa, 0x68, 0x91, 0x0b, 0x07, 0x07, 0x1f, 0xeb, 0x5c, 0xd4, 0x7c, 0x80, 0xe6, 0xfd, 0x24, 0xd1,
+	0xd1, 0x54, 0xd4, 0x71, 0xcc, 0x1f, 0x0d, 0x84, 0x7b, 0xf7, 0x9f, 0x2e, 0xfd, 0x4f, 0x43, 0x9b,
+	0x78, 0xd0, 0xf8, 0x2c, 0x8d, 0xa3, 0x99, 0x78, 0xc9, 0x6f, 0xe3, 0x45, 0x9d, 0x6b, 0xe1, 0xe0,
+	0xce, 0xa3, 0xc5, 0xe9, 0x3c, 0xc4, 0xa7, 0x1d, 0xef, 0xc3, 0xff, 0x4f, 0x88, 0x3a, 0x6e, 0x3e,
+	0x78, 0x30, 0x16, 0x2e, 0x2e, 0xc4, 0x06, 0x25, 0x13, 0x0d, 0xbf, 0x03, 0x2d, 0xae, 0xa8, 0x99,
+	0x68, 0xd2, 0x5a, 0x1b, 0x78, 0xa2, 0x85, 0xd3, 0xa8, 0xb4, 0x08, 0xf0, 0xbb, 0xa6, 0xea, 0x8c,
+	0xe2, 0x4c, 0x74, 0x10, 0xd9, 0x56, 0x44, 0x74, 0x49, 0xf8, 0x38, 0x13, 0x1b, 0x78, 0x16, 0xb7,
+	0x4e, 0x62, 0x13, 0xb7, 0x1a, 0xe7, 0x23, 0xb5, 0xc4, 0xac, 0x2c, 0x6e, 0xf9, 0x9b, 0x74, 0xfd,
+	0xef, 0x4f, 0xa7, 0x84, 0x05, 0x62, 0x66, 0x63, 0x26, 0x17, 0x2f, 0xe3, 0xf4, 0x4f, 0xb4, 0x4a,
+	0xf3, 0x7d, 0xad, 0x72, 0xb1, 0x85, 0x07, 0x50, 0x07, 0x15, 0x85, 0xb9, 0x78, 0x15, 0x27, 0x23,
+	0x7a, 0x14, 0xe7, 0xe1, 0xd9, 0x52, 0x6c, 0xe3, 0x64, 0xc4, 0x94, 0xd0, 0xc4, 0xd7, 0xec, 0xe4,
+	0x71, 0x1e, 0x27, 0xa2, 0x87, 0x4c, 0x94, 0x6d, 0xae, 0xa3, 0x99, 0x16, 0xaf, 0xa1, 0x4c, 0x52,
+	0x27, 0x2a, 0x4c, 0xc5, 0x6d, 0xff, 0x15, 0xb8, 0x75, 0xf8, 0x22, 0xd7, 0x69, 0xa4, 0xe6, 0xf7,
+	0xa7, 0xd3, 0x54, 0x67, 0x99, 0xf8, 0x3a, 0x1a, 0x60, 0x9c, 0xc7, 0xa9, 0x9a, 0x69, 0x71, 0x07,
+	0xc1, 0x28, 0x8d, 0x3f, 0x5d, 0x84, 0xb9, 0x78, 0x1d, 0xd5, 0xa7, 0xa2, 0x2b, 0xde, 0xc0, 0x21,
+	0xdd, 0x5d, 0xf1, 0x0d, 0x3a, 0xdc, 0x06, 0x9a, 0xd8, 0xc1, 0x15, 0xa6, 0x5b, 0x10, 0xdf, 0xc4,
+	0xc3, 0xf8, 0x46, 0x88, 0xb7, 0x0d, 0x63, 0x3e, 0x52, 0x4b, 0xb1, 0x8b, 0x60, 0xa0, 0x32, 0x54,
+	0x58, 0xbc, 0x43, 0x87, 0xc4, 0x19, 0x7e, 0x20, 0x13, 0x77, 0xe9, 0x78, 0x9d, 0x61, 0x85, 0x12,
+	0xef, 0xfa, 0x2f, 0xdb, 0x77, 0x37, 0xbf, 0x84, 0x33, 0xf1, 0x1e, 0x2a, 0xf7, 0x30, 0x7e, 0xae,
+	0xb1, 0x70, 0x89, 0x6f, 0xdb, 0x4d, 0x07, 0xf1, 0x4c, 0xdc, 0xf3, 0x37, 0x2a, 0x6f, 0x13, 0xf1,
+	0x1d, 0x3c, 0x9c, 0xb3, 0x8a, 0x78, 0xdf, 0x17, 0xd0, 0x25, 0xf3, 0xf6, 0xc3, 0x2c, 0x59, 0xe4,
+	0x5a, 0x7c, 0x80, 0x2b, 0x31, 0x37, 0xa0, 0xd0, 0x7b, 0xb8, 0x72, 0x10, 0x67, 0xdc, 0x78, 0x89,
+	0x0f, 0x51, 0x3b, 0x0a, 0x62, 0xf1, 0xd1, 0xdd, 0xa7, 0xd0, 0xa0, 0xcf, 0x38, 0xa4, 0x71, 0x72,
+	0x98, 0xa6, 0xe2, 0x25, 0x1e, 0xde, 0x9f, 0x4e, 0x85, 0x83, 0xd2, 0x0c, 0x13, 0x13, 0x97, 0x35,
+	0x46, 0x26, 0x32, 0xeb, 0x8c, 0xf8, 0x33, 0x91, 0x70, 0xfd, 0x5b, 0xd0, 0x19, 0x26, 0xc5, 0x47,
+	0x5e, 0xd1, 0x38, 0x6d, 0xd2, 0x1f, 0x8e, 0x1f, 0xfe, 0x77, 0x00, 0x8c, 0xfc, 0x09, 0x17, 0x7e,
+	0x1c, 0x00, 0x00,
 }
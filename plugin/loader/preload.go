@@ -4,6 +4,8 @@ import (
 	"github.com/memoio/go-mefs/plugin"
 	pluginflatfs "github.com/memoio/go-mefs/plugin/plugins/flatfs"
 	pluginlevelds "github.com/memoio/go-mefs/plugin/plugins/levelds"
+	pluginmultidisk "github.com/memoio/go-mefs/plugin/plugins/multidisk"
+	pluginpebbleds "github.com/memoio/go-mefs/plugin/plugins/pebbleds"
 )
 
 // DO NOT EDIT THIS FILE
@@ -13,4 +15,6 @@ import (
 var preloadPlugins = []plugin.Plugin{
 	pluginflatfs.Plugins[0],
 	pluginlevelds.Plugins[0],
+	pluginmultidisk.Plugins[0],
+	pluginpebbleds.Plugins[0],
 }
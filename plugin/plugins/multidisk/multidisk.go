@@ -0,0 +1,135 @@
+package multidisk
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/memoio/go-mefs/plugin"
+	"github.com/memoio/go-mefs/repo"
+	"github.com/memoio/go-mefs/repo/fsrepo"
+
+	flatfs "github.com/memoio/go-mefs/source/go-ds-flatfs"
+	multidisk "github.com/memoio/go-mefs/source/go-ds-multidisk"
+)
+
+// Plugins is exported list of plugins that will be loaded
+var Plugins = []plugin.Plugin{
+	&multidiskPlugin{},
+}
+
+type multidiskPlugin struct{}
+
+var _ plugin.PluginDatastore = (*multidiskPlugin)(nil)
+
+func (*multidiskPlugin) Name() string {
+	return "ds-multidisk"
+}
+
+func (*multidiskPlugin) Version() string {
+	return "0.1.0"
+}
+
+func (*multidiskPlugin) Init() error {
+	return nil
+}
+
+func (*multidiskPlugin) DatastoreTypeName() string {
+	return "multidisk"
+}
+
+type diskConfig struct {
+	path     string
+	capacity uint64
+}
+
+type datastoreConfig struct {
+	disks     []diskConfig
+	shardFun  *flatfs.ShardIdV1
+	syncField bool
+}
+
+// DatastoreConfigParser returns a configuration stub for a multidisk datastore
+// from the given parameters; each disk is a flatfs with the same shardFunc
+func (*multidiskPlugin) DatastoreConfigParser() fsrepo.ConfigFromMap {
+	return func(params map[string]interface{}) (fsrepo.DatastoreConfig, error) {
+		var c datastoreConfig
+		var ok bool
+		var err error
+
+		disks, ok := params["disks"].([]interface{})
+		if !ok || len(disks) == 0 {
+			return nil, fmt.Errorf("'disks' field is missing or not a non-empty array")
+		}
+		for _, iface := range disks {
+			dcfg, ok := iface.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected map for disk")
+			}
+
+			var dc diskConfig
+			dc.path, ok = dcfg["path"].(string)
+			if !ok {
+				return nil, fmt.Errorf("'path' field of disk is missing or not string")
+			}
+
+			// capacity is in GB, numbers in json are float64
+			if cp, ok := dcfg["capacity"]; ok {
+				size, ok := cp.(float64)
+				if !ok || size < 0 {
+					return nil, fmt.Errorf("'capacity' field of disk is not a positive number")
+				}
+				dc.capacity = uint64(size) << 30
+			}
+			c.disks = append(c.disks, dc)
+		}
+
+		sshardFun, ok := params["shardFunc"].(string)
+		if !ok {
+			return nil, fmt.Errorf("'shardFunc' field is missing or not a string")
+		}
+		c.shardFun, err = flatfs.ParseShardFunc(sshardFun)
+		if err != nil {
+			return nil, err
+		}
+
+		c.syncField, ok = params["sync"].(bool)
+		if !ok {
+			return nil, fmt.Errorf("'sync' field is missing or not boolean")
+		}
+		return &c, nil
+	}
+}
+
+// DiskSpec has no paths of disks, so disks can be added in config
+func (c *datastoreConfig) DiskSpec() fsrepo.DiskSpec {
+	return map[string]interface{}{
+		"type":      "multidisk",
+		"shardFunc": c.shardFun.String(),
+	}
+}
+
+func (c *datastoreConfig) Create(path string) (repo.Datastore, error) {
+	disks := make([]multidisk.Disk, 0, len(c.disks))
+	for _, dc := range c.disks {
+		p := dc.path
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(path, p)
+		}
+
+		fds, err := flatfs.CreateOrOpen(p, c.shardFun, c.syncField)
+		if err != nil {
+			for _, dk := range disks {
+				dk.Datastore.Close()
+			}
+			return nil, err
+		}
+
+		disks = append(disks, multidisk.Disk{
+			Path:      p,
+			Capacity:  dc.capacity,
+			Datastore: fds,
+		})
+	}
+
+	return multidisk.New(disks)
+}
@@ -0,0 +1,113 @@
+package pebbleds
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/memoio/go-mefs/plugin"
+	"github.com/memoio/go-mefs/repo"
+	"github.com/memoio/go-mefs/repo/fsrepo"
+
+	pebbleds "github.com/memoio/go-mefs/source/go-ds-pebble"
+)
+
+// Plugins is exported list of plugins that will be loaded
+var Plugins = []plugin.Plugin{
+	&pebbledsPlugin{},
+}
+
+type pebbledsPlugin struct{}
+
+var _ plugin.PluginDatastore = (*pebbledsPlugin)(nil)
+
+func (*pebbledsPlugin) Name() string {
+	return "ds-pebble"
+}
+
+func (*pebbledsPlugin) Version() string {
+	return "0.1.0"
+}
+
+func (*pebbledsPlugin) Init() error {
+	return nil
+}
+
+func (*pebbledsPlugin) DatastoreTypeName() string {
+	return "pebbleds"
+}
+
+type datastoreConfig struct {
+	path         string
+	compression  pebble.Compression
+	cacheSize    int64
+	memTableSize int
+}
+
+// DatastoreConfigParser returns a configuration stub for a pebble datastore
+// from the given parameters
+func (*pebbledsPlugin) DatastoreConfigParser() fsrepo.ConfigFromMap {
+	return func(params map[string]interface{}) (fsrepo.DatastoreConfig, error) {
+		var c datastoreConfig
+		var ok bool
+
+		c.path, ok = params["path"].(string)
+		if !ok {
+			return nil, fmt.Errorf("'path' field is missing or not string")
+		}
+
+		switch cm := params["compression"].(string); cm {
+		case "none":
+			c.compression = pebble.NoCompression
+		case "snappy":
+			c.compression = pebble.SnappyCompression
+		case "":
+			c.compression = pebble.DefaultCompression
+		default:
+			return nil, fmt.Errorf("unrecognized value for compression: %s", cm)
+		}
+
+		// sizes are in MB, numbers in json are float64
+		if cs, ok := params["cacheSize"]; ok {
+			size, ok := cs.(float64)
+			if !ok || size < 0 {
+				return nil, fmt.Errorf("'cacheSize' field is not a positive number")
+			}
+			c.cacheSize = int64(size) << 20
+		}
+
+		if ms, ok := params["memTableSize"]; ok {
+			size, ok := ms.(float64)
+			if !ok || size < 0 {
+				return nil, fmt.Errorf("'memTableSize' field is not a positive number")
+			}
+			c.memTableSize = int(size) << 20
+		}
+
+		return &c, nil
+	}
+}
+
+func (c *datastoreConfig) DiskSpec() fsrepo.DiskSpec {
+	return map[string]interface{}{
+		"type": "pebbleds",
+		"path": c.path,
+	}
+}
+
+func (c *datastoreConfig) Create(path string) (repo.Datastore, error) {
+	p := c.path
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(path, p)
+	}
+
+	opts := &pebbleds.Options{
+		MemTableSize: c.memTableSize,
+	}
+	if c.cacheSize > 0 {
+		opts.Cache = pebble.NewCache(c.cacheSize)
+	}
+	opts.Levels = []pebble.LevelOptions{{Compression: c.compression}}
+
+	return pebbleds.NewDatastore(p, opts)
+}
@@ -0,0 +1,220 @@
+package fsrepo
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	lockfile "github.com/ipfs/go-fs-lock"
+	config "github.com/memoio/go-mefs/config"
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	dsq "github.com/memoio/go-mefs/source/go-datastore/query"
+)
+
+// entries put in a batch during migration
+const migrateBatchSize = 1024
+
+// mountSpecs returns specs of datastores by mountpoint
+func mountSpecs(spec map[string]interface{}) (map[string]map[string]interface{}, error) {
+	res := make(map[string]map[string]interface{})
+	if spec["type"] != "mount" {
+		res["/"] = spec
+		return res, nil
+	}
+
+	mounts, ok := spec["mounts"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'mounts' field is missing or not an array")
+	}
+	for _, iface := range mounts {
+		cfg, ok := iface.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected map for mountpoint")
+		}
+		prefix, ok := cfg["mountpoint"].(string)
+		if !ok {
+			return nil, fmt.Errorf("no 'mountpoint' on mount")
+		}
+		res[ds.NewKey(prefix).String()] = cfg
+	}
+	return res, nil
+}
+
+// diskPath returns path in disk spec of a datastore, wrappers are skipped
+func diskPath(spec DiskSpec) string {
+	if p, ok := spec["path"].(string); ok {
+		return p
+	}
+	if child, ok := spec["child"].(map[string]interface{}); ok {
+		return diskPath(child)
+	}
+	return ""
+}
+
+// MigrateDatastore converts the datastore of the repo at repoPath to spec.
+// Mountpoints must be the same as before; entries of datastores whose disk
+// spec changes are copied to new datastores, which must be at new paths.
+// Old datastores are kept on disk and can be removed after migration.
+func MigrateDatastore(repoPath string, spec map[string]interface{}, out io.Writer) error {
+	packageLock.Lock()
+	defer packageLock.Unlock()
+
+	r, err := newFSRepo(repoPath)
+	if err != nil {
+		return err
+	}
+
+	if err := checkInitialized(r.path); err != nil {
+		return err
+	}
+
+	// daemon cannot start during migration
+	r.lockfile, err = lockfile.Lock(r.path, LockFile)
+	if err != nil {
+		return err
+	}
+	defer r.lockfile.Close()
+
+	if err := r.openConfig(); err != nil {
+		return err
+	}
+
+	oldSpec, err := r.readSpec()
+	if err != nil {
+		return err
+	}
+
+	olddsc, err := AnyDatastoreConfig(r.config.Datastore.Spec)
+	if err != nil {
+		return err
+	}
+	if oldSpec != olddsc.DiskSpec().String() {
+		return fmt.Errorf("datastore configuration of '%s' does not match what is on disk '%s'",
+			oldSpec, olddsc.DiskSpec().String())
+	}
+
+	newdsc, err := AnyDatastoreConfig(spec)
+	if err != nil {
+		return err
+	}
+
+	oldMounts, err := mountSpecs(r.config.Datastore.Spec)
+	if err != nil {
+		return err
+	}
+	newMounts, err := mountSpecs(spec)
+	if err != nil {
+		return err
+	}
+	if len(oldMounts) != len(newMounts) {
+		return fmt.Errorf("mountpoints of datastores are changed")
+	}
+
+	for mp, nspec := range newMounts {
+		ospec, ok := oldMounts[mp]
+		if !ok {
+			return fmt.Errorf("no datastore is mounted at %s now", mp)
+		}
+
+		odsc, err := AnyDatastoreConfig(ospec)
+		if err != nil {
+			return err
+		}
+		ndsc, err := AnyDatastoreConfig(nspec)
+		if err != nil {
+			return err
+		}
+
+		if odsc.DiskSpec().String() == ndsc.DiskSpec().String() {
+			continue
+		}
+
+		if diskPath(odsc.DiskSpec()) == diskPath(ndsc.DiskSpec()) {
+			return fmt.Errorf("new datastore at %s must have a different path", mp)
+		}
+
+		fmt.Fprintf(out, "migrating datastore at %s from %s to %s\n", mp, odsc.DiskSpec(), ndsc.DiskSpec())
+
+		n, err := migrateMount(r.path, odsc, ndsc)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "migrated %d entries at %s, old datastore at %s can be removed\n", n, mp, diskPath(odsc.DiskSpec()))
+	}
+
+	r.config.Datastore.Spec = spec
+	if err := r.setConfigUnsynced(r.config); err != nil {
+		return err
+	}
+
+	fn, err := config.Path(r.path, specFn)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fn, newdsc.DiskSpec().Bytes(), 0600)
+}
+
+// migrateMount copies all entries from datastore of odsc to one of ndsc
+func migrateMount(path string, odsc, ndsc DatastoreConfig) (int, error) {
+	ods, err := odsc.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer ods.Close()
+
+	nds, err := ndsc.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer nds.Close()
+
+	// flatfs only lists keys
+	res, err := ods.Query(dsq.Query{KeysOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	defer res.Close()
+
+	b, err := nds.Batch()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for r := range res.Next() {
+		if r.Error != nil {
+			return count, r.Error
+		}
+
+		key := ds.NewKey(r.Key)
+		val, err := ods.Get(key)
+		if err != nil {
+			return count, err
+		}
+
+		err = b.Put(key, val)
+		if err != nil {
+			return count, err
+		}
+
+		count++
+		if count%migrateBatchSize == 0 {
+			err = b.Commit()
+			if err != nil {
+				return count, err
+			}
+			b, err = nds.Batch()
+			if err != nil {
+				return count, err
+			}
+		}
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return count, err
+	}
+
+	return count, nds.Sync(ds.NewKey("/"))
+}
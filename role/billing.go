@@ -0,0 +1,273 @@
+package role
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/memoio/go-mefs/contracts"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/source/data"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/address"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// kinds of billing entries
+const (
+	BillDeposit   = "deposit"   // money put into upkeeping by user
+	BillSpaceTime = "spacetime" // spacetime pay of upkeeping
+	BillChannel   = "channel"   // money put into read-payment channel, or paid by it
+	BillPledge    = "pledge"    // pledge of keeper or provider
+	BillGas       = "gas"       // gas of transactions
+)
+
+type billing struct {
+	sync.Mutex
+	ctx  context.Context
+	ds   data.Service
+	last int64
+}
+
+var ledger *billing
+
+// InitBilling stores billing entries of accounts on this node into ds, and
+// records gas of every transaction sent by them
+func InitBilling(ctx context.Context, ds data.Service) {
+	ledger = &billing{
+		ctx: ctx,
+		ds:  ds,
+	}
+	contracts.SetTxRecorder(recordGas)
+}
+
+func recordGas(from common.Address, tx *types.Transaction, receipt *types.Receipt) {
+	owner, err := address.GetIDFromAddress(from.Hex())
+	if err != nil {
+		return
+	}
+
+	cost := new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(receipt.GasUsed))
+	var contract string
+	if receipt.ContractAddress != (common.Address{}) {
+		contract, _ = address.GetIDFromAddress(receipt.ContractAddress.Hex())
+	} else if tx.To() != nil {
+		contract, _ = address.GetIDFromAddress(tx.To().Hex())
+	}
+
+	memo := "gas used " + strconv.FormatUint(receipt.GasUsed, 10)
+	if receipt.Status == 0 {
+		memo += ", execution failed"
+	}
+
+	recordBill(&mpb.BillEntry{
+		Owner:    owner,
+		Kind:     BillGas,
+		Value:    cost.Bytes(),
+		Contract: contract,
+		Memo:     memo,
+		TxHash:   tx.Hash().Hex(),
+	})
+}
+
+// RecordBill records a billing entry of owner's ledger; income is false for expense
+func RecordBill(owner, kind string, value *big.Int, income bool, peer, contract, memo string) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+
+	recordBill(&mpb.BillEntry{
+		Owner:    owner,
+		Kind:     kind,
+		Value:    value.Bytes(),
+		Income:   income,
+		Peer:     peer,
+		Contract: contract,
+		Memo:     memo,
+	})
+}
+
+func recordBill(be *mpb.BillEntry) {
+	l := ledger
+	if l == nil {
+		return
+	}
+
+	// time in nanosecond is key of entry, make it unique
+	l.Lock()
+	now := time.Now().UnixNano()
+	if now <= l.last {
+		now = l.last + 1
+	}
+	l.last = now
+	l.Unlock()
+
+	be.Time = now / int64(time.Second)
+
+	km, err := metainfo.NewKey(be.Owner, mpb.KeyType_Billing, strconv.FormatInt(now, 10))
+	if err != nil {
+		return
+	}
+
+	val, err := proto.Marshal(be)
+	if err != nil {
+		return
+	}
+
+	err = l.ds.PutKey(l.ctx, km.ToString(), val, nil, "local")
+	if err != nil {
+		utils.MLogger.Warnf("record %s bill of %s fails: %s", be.Kind, be.Owner, err)
+	}
+}
+
+// GetBills lists billing entries of owner between start and end
+func GetBills(owner string, start, end int64) ([]*mpb.BillEntry, error) {
+	l := ledger
+	if l == nil {
+		return nil, ErrServiceNotReady
+	}
+
+	km, err := metainfo.NewKey(owner, mpb.KeyType_Billing)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err := l.ds.Itererate(km.ToString())
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*mpb.BillEntry
+	for _, e := range es {
+		rec := new(mpb.Record)
+		err := proto.Unmarshal(e.Value, rec)
+		if err != nil {
+			continue
+		}
+
+		be := new(mpb.BillEntry)
+		err = proto.Unmarshal(rec.GetValue(), be)
+		if err != nil || be.GetOwner() != owner {
+			continue
+		}
+
+		if be.GetTime() < start || be.GetTime() > end {
+			continue
+		}
+		res = append(res, be)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].GetTime() < res[j].GetTime()
+	})
+	return res, nil
+}
+
+// BillItem is billing entry for display
+type BillItem struct {
+	Time     string
+	Kind     string
+	Income   bool
+	Value    string // wei
+	Peer     string `json:",omitempty"`
+	Contract string `json:",omitempty"`
+	TxHash   string `json:",omitempty"`
+	Memo     string `json:",omitempty"`
+}
+
+// BillSum sums billing entries of one kind
+type BillSum struct {
+	Income  string
+	Expense string
+}
+
+// BillReport is billing report of owner between From and To
+type BillReport struct {
+	Owner   string
+	From    string
+	To      string
+	Income  string
+	Expense string
+	Kinds   map[string]*BillSum
+	Entries []*BillItem
+}
+
+// FormatBills formats billing entries to "csv" or "json"
+func FormatBills(owner string, start, end int64, bes []*mpb.BillEntry, format string) (string, error) {
+	items := make([]*BillItem, 0, len(bes))
+	income := big.NewInt(0)
+	expense := big.NewInt(0)
+	kinds := make(map[string][2]*big.Int)
+	for _, be := range bes {
+		val := new(big.Int).SetBytes(be.GetValue())
+		sum, ok := kinds[be.GetKind()]
+		if !ok {
+			sum = [2]*big.Int{big.NewInt(0), big.NewInt(0)}
+			kinds[be.GetKind()] = sum
+		}
+		if be.GetIncome() {
+			income.Add(income, val)
+			sum[0].Add(sum[0], val)
+		} else {
+			expense.Add(expense, val)
+			sum[1].Add(sum[1], val)
+		}
+
+		items = append(items, &BillItem{
+			Time:     time.Unix(be.GetTime(), 0).Format(utils.SHOWTIME),
+			Kind:     be.GetKind(),
+			Income:   be.GetIncome(),
+			Value:    val.String(),
+			Peer:     be.GetPeer(),
+			Contract: be.GetContract(),
+			TxHash:   be.GetTxHash(),
+			Memo:     be.GetMemo(),
+		})
+	}
+
+	switch format {
+	case "json":
+		rep := &BillReport{
+			Owner:   owner,
+			From:    time.Unix(start, 0).Format(utils.SHOWTIME),
+			To:      time.Unix(end, 0).Format(utils.SHOWTIME),
+			Income:  income.String(),
+			Expense: expense.String(),
+			Kinds:   make(map[string]*BillSum, len(kinds)),
+			Entries: items,
+		}
+		for kind, sum := range kinds {
+			rep.Kinds[kind] = &BillSum{Income: sum[0].String(), Expense: sum[1].String()}
+		}
+		res, err := json.MarshalIndent(rep, "", "\t")
+		if err != nil {
+			return "", err
+		}
+		return string(res), nil
+	case "csv":
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"time", "kind", "direction", "value", "peer", "contract", "txhash", "memo"})
+		for _, item := range items {
+			direction := "expense"
+			if item.Income {
+				direction = "income"
+			}
+			w.Write([]string{item.Time, item.Kind, direction, item.Value, item.Peer, item.Contract, item.TxHash, item.Memo})
+		}
+		w.Flush()
+		return strings.TrimSuffix(buf.String(), "\n"), w.Error()
+	default:
+		return "", ErrInvalidInput
+	}
+}
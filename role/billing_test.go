@@ -0,0 +1,150 @@
+package role
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/source/data"
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	dsq "github.com/memoio/go-mefs/source/go-datastore/query"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/address"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// testLedgerService keeps local keys in a map datastore; prefix of query is
+// cleaned as node's datastore does
+type testLedgerService struct {
+	data.Service
+	dstore ds.Datastore
+}
+
+func (s *testLedgerService) PutKey(ctx context.Context, key string, val, sig []byte, to string) error {
+	rec, err := proto.Marshal(&mpb.Record{Key: []byte(key), Value: val, Signature: sig})
+	if err != nil {
+		return err
+	}
+	return s.dstore.Put(ds.NewKey(key), rec)
+}
+
+func (s *testLedgerService) Itererate(prefix string) ([]dsq.Entry, error) {
+	qr, err := s.dstore.Query(dsq.Query{Prefix: ds.NewKey(prefix).String()})
+	if err != nil {
+		return nil, err
+	}
+	return qr.Rest()
+}
+
+func TestBilling(t *testing.T) {
+	utils.StartLogger()
+
+	s := &testLedgerService{dstore: ds.NewMapDatastore()}
+	InitBilling(context.Background(), s)
+	defer func() {
+		ledger = nil
+	}()
+
+	from := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	owner, err := address.GetIDFromAddress(from.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// entry out of range
+	old, _ := proto.Marshal(&mpb.BillEntry{Owner: owner, Time: time.Now().Unix() - 3600, Kind: BillDeposit, Value: big.NewInt(1000).Bytes()})
+	km, _ := metainfo.NewKey(owner, mpb.KeyType_Billing, strconv.FormatInt(time.Now().Add(-time.Hour).UnixNano(), 10))
+	err = s.PutKey(context.Background(), km.ToString(), old, nil, "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now().Unix()
+	RecordBill(owner, BillDeposit, big.NewInt(100), false, "query1", "uk1", "deploy upkeeping")
+	RecordBill(owner, BillSpaceTime, big.NewInt(30), true, "", "", "storage income")
+	RecordBill(owner, BillChannel, big.NewInt(20), true, "user1", "chan1", "close channel")
+	RecordBill(owner, BillPledge, big.NewInt(0), false, "", "", "no value") // skipped
+	RecordBill("other", BillDeposit, big.NewInt(500), false, "", "", "other owner")
+
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(2), nil)
+	recordGas(from, tx, &types.Receipt{Status: 1, GasUsed: 21000})
+	end := time.Now().Unix()
+
+	bes, err := GetBills(owner, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var kinds []string
+	for _, be := range bes {
+		kinds = append(kinds, be.GetKind())
+	}
+	if strings.Join(kinds, ",") != "deposit,spacetime,channel,gas" {
+		t.Fatal("got bills of kinds: ", kinds)
+	}
+
+	gas := bes[3]
+	if new(big.Int).SetBytes(gas.GetValue()).Int64() != 42000 || gas.GetIncome() || gas.GetTxHash() != tx.Hash().Hex() {
+		t.Fatal("wrong gas bill: ", gas)
+	}
+
+	all, err := GetBills(owner, 0, end)
+	if err != nil || len(all) != 5 {
+		t.Fatal("got ", len(all), " bills in all: ", err)
+	}
+
+	res, err := FormatBills(owner, start, end, bes, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rep := new(BillReport)
+	err = json.Unmarshal([]byte(res), rep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rep.Owner != owner || rep.Income != "50" || rep.Expense != "42100" || len(rep.Entries) != 4 {
+		t.Fatal("wrong report: ", res)
+	}
+
+	if sum := rep.Kinds[BillDeposit]; sum == nil || sum.Income != "0" || sum.Expense != "100" {
+		t.Fatal("wrong sum of deposit: ", sum)
+	}
+
+	if sum := rep.Kinds[BillChannel]; sum == nil || sum.Income != "20" || sum.Expense != "0" {
+		t.Fatal("wrong sum of channel: ", sum)
+	}
+
+	res, err = FormatBills(owner, start, end, bes, "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(res)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 5 || rows[0][0] != "time" {
+		t.Fatal("wrong csv: ", res)
+	}
+
+	if rows[1][1] != BillDeposit || rows[1][2] != "expense" || rows[1][3] != "100" || rows[1][5] != "uk1" || rows[3][2] != "income" || rows[3][4] != "user1" {
+		t.Fatal("wrong csv rows: ", rows[1], rows[3])
+	}
+
+	_, err = FormatBills(owner, start, end, bes, "xml")
+	if err != ErrInvalidInput {
+		t.Fatal("format xml: ", err)
+	}
+}
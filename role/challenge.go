@@ -1,25 +1,172 @@
 package role
 
 import (
+	"math"
 	"strconv"
 	"strings"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/memoio/go-mefs/crypto/pdp"
 	mpb "github.com/memoio/go-mefs/pb"
 	"github.com/memoio/go-mefs/utils/bitset"
 	"github.com/memoio/go-mefs/utils/metainfo"
+	"github.com/memoio/go-mefs/utils/pos"
 )
 
+// challenge policies; besides these, a data challenge policy can be
+// a chunk count such as "200" or a percentage such as "5%"
+const (
+	ChalPolicySmart  = "smart"
+	ChalPolicyMeta   = "meta"
+	ChalPolicyRandom = "random100"
+	ChalPolicyPost   = "post" // challenge raw segments of post data
+)
+
+const (
+	// PostVerifyWords is the number of words sampled from each segment of post challenge
+	PostVerifyWords = 64
+	// PostChalNum is the number of blocks in post challenge, one segment of each is replied
+	PostChalNum = 128
+	// PostAttackCores is cores of a provider which regenerates challenged segments in parallel
+	PostAttackCores = 16
+	// PostProofTimeout is seconds in which post challenge should be replied; reading
+	// and replying PostChalNum raw segments takes about 0.4s (TestChallengePost),
+	// while regenerating them takes about 96s on one core and 6s on PostAttackCores
+	// cores (BenchmarkSealSegment, BenchmarkFill in utils/pos)
+	PostProofTimeout = int64(3)
+)
+
+// GetChalNum returns the number of chunks challenged from total chunks under policy,
+// false if policy is not a data challenge policy
+func GetChalNum(policy string, total uint) (uint, bool) {
+	switch policy {
+	case ChalPolicySmart:
+		if total/100 < 100 {
+			return 100, true
+		}
+		return total / 100, true
+	case ChalPolicyMeta:
+		return total, true
+	case ChalPolicyRandom, ChalPolicyPost, "":
+		return 0, false
+	}
+
+	if strings.HasSuffix(policy, "%") {
+		per, err := strconv.ParseFloat(strings.TrimSuffix(policy, "%"), 64)
+		if err != nil || per <= 0 || per > 100 {
+			return 0, false
+		}
+		return uint(float64(total) * per / 100), true
+	}
+
+	num, err := strconv.ParseUint(policy, 10, 0)
+	if err != nil || num == 0 {
+		return 0, false
+	}
+	return uint(num), true
+}
+
+// GetChalNumForProb returns the least number of chunks need to be challenged,
+// for detecting at least one fault chunk with probability prob,
+// when faultRate of all chunks are lost
+func GetChalNumForProb(prob, faultRate float64) uint {
+	if prob <= 0 || faultRate <= 0 {
+		return 1
+	}
+
+	if prob >= 1 || faultRate >= 1 {
+		return math.MaxUint32
+	}
+
+	return uint(math.Ceil(math.Log(1-prob) / math.Log(1-faultRate)))
+}
+
+// DetectProb returns the probability that challenging chalNum chunks out of total chunks
+// hits at least one fault chunk, when faultRate of all chunks are lost
+func DetectProb(chalNum, total uint, faultRate float64) float64 {
+	if total == 0 || faultRate <= 0 {
+		return 0
+	}
+
+	fault := uint(math.Ceil(float64(total) * faultRate))
+	if fault >= total || chalNum > total-fault {
+		return 1
+	}
+
+	// sample without replacement
+	miss := 1.0
+	for i := uint(0); i < chalNum; i++ {
+		miss *= float64(total-fault-i) / float64(total-i)
+	}
+
+	return 1 - miss
+}
+
 // VerifyChallenge verifies ChalInfo
 func VerifyChallenge(cr *mpb.ChalInfo, blsKey pdp.VerifyKey, strict bool) (bool, []string, []string, error) {
 	switch cr.GetPolicy() {
-	case "smart", "meta":
-		return VerifyChallengeData(cr, blsKey, strict)
-	case "random100":
+	case ChalPolicyRandom:
 		return VerifyChallengeRandom(cr, blsKey, strict)
-	default:
+	case ChalPolicyPost:
+		return VerifyChallengePost(cr, strict)
+	}
+
+	_, ok := GetChalNum(cr.GetPolicy(), 0)
+	if !ok {
 		return false, nil, nil, ErrEmptyData
 	}
+
+	return VerifyChallengeData(cr, blsKey, strict)
+}
+
+// VerifyChallengeBatch verifies challenges of groups on one provider, whose
+// proofs share one aggregated delta; each challenge is checked by VerifyChallenge
+// and pairings of all are computed in one pass. If the aggregated proof is wrong,
+// all challenges fail.
+func VerifyChallengeBatch(crs []*mpb.ChalInfo, blsKeys []pdp.VerifyKey, strict bool) ([]bool, [][]string, [][]string, error) {
+	if len(crs) != len(blsKeys) {
+		return nil, nil, nil, ErrInvalidInput
+	}
+
+	ress := make([]bool, len(crs))
+	sucCids := make([][]string, len(crs))
+	faultCids := make([][]string, len(crs))
+
+	bv := pdp.NewBatchVerifierV1()
+	for i, cr := range crs {
+		// key is nil if it cannot be batched, then cr fails
+		vk, _ := bv.Add(blsKeys[i])
+		res, sucCid, faultCid, err := VerifyChallenge(cr, vk, strict)
+		if err != nil {
+			faultCid = append(faultCid, sucCid...)
+			sucCid = nil
+		}
+		ress[i] = res
+		sucCids[i] = sucCid
+		faultCids[i] = faultCid
+	}
+
+	if bv.Len() == 0 {
+		return ress, sucCids, faultCids, nil
+	}
+
+	ok, err := bv.Result()
+	if err == nil && ok {
+		return ress, sucCids, faultCids, nil
+	}
+
+	for i, cr := range crs {
+		if !ress[i] {
+			continue
+		}
+		cr.Res = false
+		cr.SuccessLength = 0
+		ress[i] = false
+		faultCids[i] = append(faultCids[i], sucCids[i]...)
+		sucCids[i] = nil
+	}
+
+	return ress, sucCids, faultCids, err
 }
 
 func VerifyChallengeData(cr *mpb.ChalInfo, blsKey pdp.VerifyKey, strict bool) (bool, []string, []string, error) {
@@ -47,24 +194,12 @@ func VerifyChallengeData(cr *mpb.ChalInfo, blsKey pdp.VerifyKey, strict bool) (b
 	var chal pdp.ChallengeV1
 	chal.R = pdp.GenChallengeV1(cr)
 
-	chalNum := bset.Count()
 	startPost := uint(chal.R) % bset.Len()
-	meta := false
+	meta := cr.GetPolicy() == ChalPolicyMeta
 
-	switch cr.GetPolicy() {
-	case "100":
-		chalNum = 100
-	case "1%":
-		chalNum = chalNum / 100
-	case "smart":
-		if chalNum/100 < 100 {
-			chalNum = 100
-		} else {
-			chalNum = chalNum / 100
-		}
-	case "meta":
-		meta = true
-	default:
+	chalNum, ok := GetChalNum(cr.GetPolicy(), bset.Count())
+	if !ok {
+		return false, sucCid, faultCid, ErrInvalidInput
 	}
 
 	qid := cr.GetQueryID()
@@ -349,3 +484,121 @@ func VerifyChallengeRandom(cr *mpb.ChalInfo, blsKey pdp.VerifyKey, strict bool)
 	sucCid = nil
 	return false, sucCid, faultCid, nil
 }
+
+// VerifyChallengePost verifies raw segments of post data in BlsProof,
+// which are regenerated from provider's ID instead of tags
+func VerifyChallengePost(cr *mpb.ChalInfo, strict bool) (bool, []string, []string, error) {
+	var slength int64 //success length
+	var electedOffset int
+
+	var sucCid, faultCid []string
+
+	chalR := pdp.GenChallengeV1(cr)
+
+	// key: bucketid_stripeid_blockid_offset
+	set := make(map[string]struct{}, len(cr.GetFaultBlocks()))
+	for _, s := range cr.GetFaultBlocks() {
+		if len(s) == 0 {
+			continue
+		}
+		set[s] = struct{}{}
+		chcid, _, err := metainfo.GetBidAndOffset(s)
+		if err != nil {
+			continue
+		}
+
+		faultCid = append(faultCid, chcid)
+	}
+
+	res := true
+	segs := cr.GetBlsProof()
+	for _, index := range cr.GetBlocks() {
+		_, ok := set[index]
+		if ok {
+			continue
+		}
+
+		chcid, off, err := metainfo.GetBidAndOffset(index)
+		if err != nil || off < 0 {
+			continue
+		}
+
+		sucCid = append(sucCid, chcid)
+		slength += int64(off)
+
+		if off > 0 {
+			electedOffset = int(chalR) % off
+		} else {
+			electedOffset = 0
+		}
+
+		if len(segs) < pos.SegSize {
+			res = false
+			continue
+		}
+
+		// chcid is bucketid_stripeid_blockid, each block is a replica sealed differently
+		sid, chunkID, err := getPostIndex(chcid)
+		if err != nil || !pos.VerifySegment(segs[:pos.SegSize], cr.GetProviderID(), sid, chunkID, electedOffset, PostVerifyWords) {
+			res = false
+		}
+		segs = segs[pos.SegSize:]
+	}
+
+	// recheck the status again
+	if len(sucCid) == 0 {
+		return false, sucCid, faultCid, ErrEmptyData
+	}
+
+	if res {
+		cr.Res = true
+		cr.SuccessLength = int64((float64(slength) / float64(cr.ChalLength)) * float64(cr.TotalLength))
+		return true, sucCid, faultCid, nil
+	}
+
+	cr.Res = false
+	cr.SuccessLength = 0
+	faultCid = append(faultCid, sucCid...)
+	sucCid = nil
+	return false, sucCid, faultCid, nil
+}
+
+// getPostIndex returns stripe and chunk of post block bucketid_stripeid_blockid
+func getPostIndex(chcid string) (int, int, error) {
+	bids := strings.Split(chcid, metainfo.BlockDelimiter)
+	if len(bids) < 3 {
+		return 0, 0, ErrWrongValue
+	}
+
+	sid, err := strconv.Atoi(bids[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	chunkID, err := strconv.Atoi(bids[2])
+	if err != nil {
+		return 0, 0, err
+	}
+	return sid, chunkID, nil
+}
+
+// EncodePostProof encodes raw segments of post challenge and blocks which are failed to read
+func EncodePostProof(segs []byte, faultBlocks []string) ([]byte, error) {
+	return proto.Marshal(&mpb.PostProof{
+		Segments:    segs,
+		FaultBlocks: faultBlocks,
+	})
+}
+
+// DecodePostProof sets raw segments and fault blocks of post challenge from its reply
+func DecodePostProof(cr *mpb.ChalInfo, value []byte) error {
+	pp := new(mpb.PostProof)
+	err := proto.Unmarshal(value, pp)
+	if err != nil {
+		return err
+	}
+
+	cr.BlsProof = pp.GetSegments()
+	cr.FaultBlocks = pp.GetFaultBlocks()
+	return nil
+}
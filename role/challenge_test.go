@@ -0,0 +1,144 @@
+package role
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/memoio/go-mefs/crypto/pdp"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/utils/pos"
+)
+
+// TestChallengePost runs one honest post challenge from reply to verification
+func TestChallengePost(t *testing.T) {
+	cr := &mpb.ChalInfo{
+		QueryID:     "8MGxCuiT75bje883b7uFb6eMrJt5cQ",
+		KeeperID:    "8MGxCuiT75bje883b7uFb6eMrJt5cK",
+		ProviderID:  "8MGxCuiT75bje883b7uFb6eMrJt5cP",
+		UserID:      "8MGxCuiT75bje883b7uFb6eMrJt5cQ",
+		ChalTime:    time.Now().Unix(),
+		Policy:      ChalPolicyPost,
+		ChalLength:  PostChalNum * 128,
+		TotalLength: PostChalNum * 128,
+	}
+
+	// all challenged blocks are replicas of stripe 1 with the same length,
+	// so they share one elected segment; blocks repeat over replicas to
+	// seal only one segment of each replica
+	for i := 0; i < PostChalNum; i++ {
+		cr.Blocks = append(cr.Blocks, "0_1_"+strconv.Itoa(i%pos.Reps)+"_128")
+	}
+
+	electedOffset := int(pdp.GenChallengeV1(cr)) % 128
+	reps := make([][]byte, pos.Reps)
+	for j := range reps {
+		reps[j] = make([]byte, pos.SegSize)
+		err := pos.GenPlainSegment(reps[j], cr.GetProviderID(), 1, electedOffset)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = pos.SealSegment(reps[j], cr.GetProviderID(), 1, j, electedOffset)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now()
+	var segs []byte
+	for i := 0; i < PostChalNum; i++ {
+		segs = append(segs, reps[i%pos.Reps]...)
+	}
+
+	value, err := EncodePostProof(segs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chalResult := &mpb.ChalInfo{
+		QueryID:     cr.GetQueryID(),
+		KeeperID:    cr.GetKeeperID(),
+		ProviderID:  cr.GetProviderID(),
+		UserID:      cr.GetUserID(),
+		ChalTime:    cr.GetChalTime(),
+		Policy:      cr.GetPolicy(),
+		ChalLength:  cr.GetChalLength(),
+		TotalLength: cr.GetTotalLength(),
+		Blocks:      cr.GetBlocks(),
+	}
+	err = DecodePostProof(chalResult, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, sucCids, faultCids, err := VerifyChallengePost(chalResult, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	elapsed := time.Since(start)
+	if !res || len(sucCids) != PostChalNum || len(faultCids) != 0 {
+		t.Fatal("honest post challenge fails, success: ", len(sucCids), ", fault: ", len(faultCids))
+	}
+
+	if chalResult.GetSuccessLength() != cr.GetTotalLength() {
+		t.Fatal("wrong success length: ", chalResult.GetSuccessLength())
+	}
+
+	if elapsed > time.Duration(PostProofTimeout)*time.Second {
+		t.Fatal("honest post challenge takes ", elapsed, ", longer than timeout")
+	}
+	t.Log("honest post challenge takes ", elapsed)
+
+	// a segment which is not sealed fails the challenge, words of
+	// segments are sampled so a few modified bytes may be missed
+	err = pos.GenPlainSegment(segs[pos.SegSize*3:pos.SegSize*4], cr.GetProviderID(), 1, electedOffset)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err = EncodePostProof(segs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = DecodePostProof(chalResult, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, _, _, err = VerifyChallengePost(chalResult, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res {
+		t.Fatal("modified post segments are verified")
+	}
+
+	// a provider which keeps one replica for all fails the challenge
+	segs = segs[:0]
+	for i := 0; i < PostChalNum; i++ {
+		segs = append(segs, reps[0]...)
+	}
+
+	value, err = EncodePostProof(segs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = DecodePostProof(chalResult, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, _, _, err = VerifyChallengePost(chalResult, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res {
+		t.Fatal("one replica is verified for all")
+	}
+}
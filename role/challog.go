@@ -0,0 +1,95 @@
+package role
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gogo/protobuf/proto"
+	id "github.com/memoio/go-mefs/crypto/identity"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/utils"
+)
+
+// GetHashForChalLog returns hash of challenge log without keeper's signature
+func GetHashForChalLog(cl *mpb.ChalLog) ([]byte, error) {
+	sign := cl.KeeperSign
+	cl.KeeperSign = nil
+	data, err := proto.Marshal(cl)
+	cl.KeeperSign = sign
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(data), nil
+}
+
+// SignChalLog signs challenge log by keeper
+func SignChalLog(cl *mpb.ChalLog, hexKey string) error {
+	hash, err := GetHashForChalLog(cl)
+	if err != nil {
+		return err
+	}
+
+	sig, err := id.Sign(hexKey, hash)
+	if err != nil {
+		return err
+	}
+
+	cl.KeeperSign = sig
+	return nil
+}
+
+// VerifyChalLog checks challenge log is signed by its keeper
+func VerifyChalLog(cl *mpb.ChalLog) error {
+	if len(cl.GetKeeperSign()) != crypto.SignatureLength {
+		return ErrWrongSign
+	}
+
+	hash, err := GetHashForChalLog(cl)
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := crypto.Ecrecover(hash, cl.GetKeeperSign())
+	if err != nil {
+		return ErrWrongSign
+	}
+
+	gotID, err := id.GetIDFromPubKey(pubKey)
+	if err != nil || gotID != cl.GetKeeperID() {
+		return ErrWrongSign
+	}
+
+	return nil
+}
+
+// FormatChalLog formats challenge log for display
+func FormatChalLog(cl *mpb.ChalLog) string {
+	var buf strings.Builder
+	buf.WriteString("time: ")
+	buf.WriteString(time.Unix(cl.GetChalTime(), 0).Format(utils.SHOWTIME))
+	buf.WriteString(", provider: ")
+	buf.WriteString(cl.GetProviderID())
+	buf.WriteString(", keeper: ")
+	buf.WriteString(cl.GetKeeperID())
+	buf.WriteString(", policy: ")
+	buf.WriteString(cl.GetPolicy())
+	buf.WriteString(", challenged: ")
+	buf.WriteString(strconv.FormatInt(cl.GetChalNum(), 10))
+	buf.WriteString("/")
+	buf.WriteString(strconv.FormatInt(cl.GetTotalNum(), 10))
+	buf.WriteString(", faults: ")
+	buf.WriteString(strconv.Itoa(len(cl.GetFaultBlocks())))
+	if cl.GetProofTime() == 0 {
+		buf.WriteString(", result: no proof")
+	} else if cl.GetRes() {
+		buf.WriteString(", result: success")
+	} else {
+		buf.WriteString(", result: fail")
+	}
+	buf.WriteString(", successLength: ")
+	buf.WriteString(utils.FormatBytes(cl.GetSuccessLength()))
+	return buf.String()
+}
@@ -2,6 +2,8 @@ package role
 
 import (
 	"errors"
+
+	"github.com/memoio/go-mefs/utils"
 )
 
 const (
@@ -9,6 +11,19 @@ const (
 	SessionExpTime = int64(30 * 60)
 )
 
+// GracePeriod is how long data is kept after upkeeping ends, during which users
+// can export or renew it; user sends it to keepers of each group. unit: second
+var GracePeriod = utils.DefaultGracePeriod * 24 * 60 * 60
+
+// SetGracePeriod sets GracePeriod in days
+func SetGracePeriod(days int64) error {
+	if days <= 0 || days > utils.MaxGracePeriod {
+		return ErrInvalidInput
+	}
+	GracePeriod = days * 24 * 60 * 60
+	return nil
+}
+
 var (
 	ErrNotEnoughBalance = errors.New("balance is insufficient")
 	ErrNotMyUser        = errors.New("Not my user")
@@ -21,6 +36,9 @@ var (
 
 	ErrUkExpire = errors.New("Upkeeping is expired")
 
+	ErrNotEnoughProvider = errors.New("Eligible providers are not enough")
+	ErrNotEnoughKeeper   = errors.New("Replied keepers are not enough")
+
 	ErrWrongMoney           = errors.New("money is not right")
 	ErrWrongSign            = errors.New("signature is not right")
 	ErrWrongContarctContent = errors.New("Contract content is wrong")
@@ -103,6 +103,16 @@ var kpMap sync.Map
 
 const retryGetInfoSleepTime = time.Minute
 
+// SetChainBackend makes contracts work on b, e.g. a simulated chain in tests;
+// cached kpmap is dropped since it belongs to the former chain
+func SetChainBackend(b contracts.Backend) {
+	contracts.SetBackend(b)
+	kpMap.Range(func(key, value interface{}) bool {
+		kpMap.Delete(key)
+		return true
+	})
+}
+
 func QueryBalance(localID string) (*big.Int, error) {
 	localAddress, err := address.GetAddressFromID(localID)
 	if err != nil {
@@ -377,15 +387,7 @@ func DeployUpKeeping(userID, queryID, hexSk string, ks, ps []string, storeDays,
 		providers = append(providers, providerAddress)
 	}
 
-	weiPrice := new(big.Float).SetInt(storePrice)
-	weiPrice.Quo(weiPrice, contracts.GetMemoPrice())
-	newPrice := big.NewInt(0)
-	weiPrice.Int(newPrice)
-
-	moneyAccount := big.NewInt(24)
-	moneyAccount.Mul(moneyAccount, newPrice)
-	moneyAccount.Mul(moneyAccount, big.NewInt(storeSize))
-	moneyAccount.Mul(moneyAccount, big.NewInt(storeDays))
+	moneyAccount := GetStoreCost(storePrice, storeSize, storeDays)
 
 	// getbalance
 	balance, err := QueryBalance(userID)
@@ -421,11 +423,117 @@ func DeployUpKeeping(userID, queryID, hexSk string, ks, ps []string, storeDays,
 		return ukID, err
 	}
 
+	RecordBill(userID, BillDeposit, moneyAccount, false, queryID, ukID, "deploy upkeeping")
+
 	utils.MLogger.Info("Finish deploy upkeeping contract: ", ukID)
 
 	return ukID, nil
 }
 
+// GetStoreCost returns money(wei) for storing storeSize(MB) in storeDays at storePrice
+func GetStoreCost(storePrice *big.Int, storeSize, storeDays int64) *big.Int {
+	weiPrice := new(big.Float).SetInt(storePrice)
+	weiPrice.Quo(weiPrice, contracts.GetMemoPrice())
+	newPrice := big.NewInt(0)
+	weiPrice.Int(newPrice)
+
+	moneyAccount := big.NewInt(24)
+	moneyAccount.Mul(moneyAccount, newPrice)
+	moneyAccount.Mul(moneyAccount, big.NewInt(storeSize))
+	moneyAccount.Mul(moneyAccount, big.NewInt(storeDays))
+	return moneyAccount
+}
+
+// TopUpUpKeeping tops up upkeeping of user's queryID with money(wei)
+func TopUpUpKeeping(userID, queryID, hexSk string, money *big.Int, memo string) error {
+	localAddress, err := address.GetAddressFromID(userID)
+	if err != nil {
+		return err
+	}
+
+	queryAddress, err := address.GetAddressFromID(queryID)
+	if err != nil {
+		return err
+	}
+
+	uItem, err := GetUpKeeping(userID, queryID)
+	if err != nil {
+		return err
+	}
+
+	balance, err := QueryBalance(userID)
+	if err != nil {
+		return err
+	}
+
+	if money.Cmp(balance) > 0 {
+		utils.MLogger.Errorf("%s (%s) has balance: %s, need %d to %s", userID, localAddress.String(), balance, money, memo)
+		return ErrNotEnoughBalance
+	}
+
+	utils.MLogger.Infof("Begin to top up upkeeping %s with %d to %s", uItem.UpKeepingID, money, memo)
+
+	u := contracts.NewCU(localAddress, hexSk)
+	err = u.TopUp(localAddress, queryAddress.String(), money)
+	if err != nil {
+		return err
+	}
+
+	RecordBill(userID, BillDeposit, money, false, queryID, uItem.UpKeepingID, memo)
+
+	utils.MLogger.Info("Finish top up upkeeping contract: ", uItem.UpKeepingID)
+	return nil
+}
+
+// ExtendUpKeeping extends end time of upkeeping of user's queryID by addTime(second);
+// money for the added time should be topped up before
+func ExtendUpKeeping(userID, queryID, hexSk string, addTime int64) error {
+	if addTime <= 0 {
+		return ErrInvalidInput
+	}
+
+	localAddress, err := address.GetAddressFromID(userID)
+	if err != nil {
+		return err
+	}
+
+	queryAddress, err := address.GetAddressFromID(queryID)
+	if err != nil {
+		return err
+	}
+
+	u := contracts.NewCU(localAddress, hexSk)
+	err = u.ExtendTime(localAddress, queryAddress.String(), addTime)
+	if err != nil {
+		return err
+	}
+
+	utils.MLogger.Infof("Finish extend upkeeping of user %s fsID %s by %d seconds", userID, queryID, addTime)
+	return nil
+}
+
+// DestructUpKeeping destructs upkeeping of user's queryID after it ends, the
+// money left is returned to user; anyone can call it, keepers use localID and hexSk
+func DestructUpKeeping(userID, queryID, localID, hexSk string) error {
+	userAddress, err := address.GetAddressFromID(userID)
+	if err != nil {
+		return err
+	}
+
+	queryAddress, err := address.GetAddressFromID(queryID)
+	if err != nil {
+		return err
+	}
+
+	localAddress, err := address.GetAddressFromID(localID)
+	if err != nil {
+		return err
+	}
+
+	u := contracts.NewCU(localAddress, hexSk)
+	return u.DestructUpKeeping(userAddress, queryAddress.String())
+}
+
 // GetUpkeepingInfo get Upkeeping-contract's params by ukID
 func GetUpkeepingInfo(localID, ukID string) (UpKeepingItem, error) {
 	var item UpKeepingItem
@@ -614,6 +722,18 @@ func GetLatestMerkleRoot(rootID string) (int64, [32]byte, error) {
 	return r.GetLatestMerkleRoot(rootAddr)
 }
 
+// GetMerkleRoot gets merkle root set at key time
+func GetMerkleRoot(rootID string, key int64) ([32]byte, error) {
+	var val [32]byte
+	rootAddr, err := address.GetAddressFromID(rootID)
+	if err != nil {
+		return val, err
+	}
+
+	r := contracts.NewCRoot(rootAddr, "")
+	return r.GetMerkleRoot(rootAddr, key)
+}
+
 // DeployChannel is
 func DeployChannel(userID, queryID, proID, hexSk string, storeDays, storeSize int64, redo bool) (string, error) {
 	utils.MLogger.Info("Begin to deploy channel contract...")
@@ -666,6 +786,8 @@ func DeployChannel(userID, queryID, proID, hexSk string, storeDays, storeSize in
 		return chanAddr, err
 	}
 
+	RecordBill(userID, BillChannel, moneyToChannel, false, proID, chanID, "deploy channel")
+
 	utils.MLogger.Info("Finish deploy channel contract: ", chanID)
 
 	return chanID, nil
@@ -844,6 +966,10 @@ func SignForChannel(channelID, hexKey string, value *big.Int) (sig []byte, err e
 
 //VerifyChannelSign provider used to verify user's signature for channel-contract
 func VerifyChannelSign(cSign *mpb.ChannelSign) (verify bool) {
+	if len(cSign.GetSig()) < 64 {
+		return false
+	}
+
 	channelAddr, err := address.GetAddressFromID(cSign.GetChannelID())
 	if err != nil {
 		return false
@@ -926,7 +1052,8 @@ func SaveKpMap(peerID string) error {
 	return nil
 }
 
-// BuildSignMessage builds sign message for test or repair
+// BuildSignMessage builds sign message for test; it is accepted only in test build,
+// see AllowTestSign
 func BuildSignMessage() ([]byte, error) {
 	message := &mpb.ChannelSign{
 		Value:     []byte("123"),
@@ -0,0 +1,103 @@
+package role
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gogo/protobuf/proto"
+	id "github.com/memoio/go-mefs/crypto/identity"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// ReadTokenTTL is how long a read token is valid; unit: second
+const ReadTokenTTL = int64(30 * 60)
+
+// GetHashForReadToken returns hash of read token without issuer's signature
+func GetHashForReadToken(rt *mpb.ReadToken) ([]byte, error) {
+	sign := rt.Sign
+	rt.Sign = nil
+	data, err := proto.Marshal(rt)
+	rt.Sign = sign
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(data), nil
+}
+
+// BuildReadTokenMessage builds sign message which authorizes reader to get blockIDs
+// of queryID without paying; it is signed by issuer with hexKey
+func BuildReadTokenMessage(queryID, reader, issuer, hexKey string, blockIDs []string) ([]byte, error) {
+	rt := &mpb.ReadToken{
+		QueryID:  queryID,
+		BlockIDs: blockIDs,
+		Reader:   reader,
+		Issuer:   issuer,
+		Expire:   time.Now().Unix() + ReadTokenTTL,
+	}
+
+	hash, err := GetHashForReadToken(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	rt.Sign, err = id.Sign(hexKey, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	mes, err := proto.Marshal(&mpb.ChannelSign{Token: rt})
+	if err != nil {
+		utils.MLogger.Error("protoMarshal failed: ", err)
+		return nil, err
+	}
+	return mes, nil
+}
+
+// VerifyReadToken checks read token is signed by its issuer, not expired, and
+// allows reader to get blockID; caller checks whether issuer can authorize it
+func VerifyReadToken(rt *mpb.ReadToken, blockID, reader string) error {
+	if rt.GetReader() != reader || rt.GetExpire() < time.Now().Unix() {
+		return ErrWrongSign
+	}
+
+	if len(rt.GetSign()) != crypto.SignatureLength {
+		return ErrWrongSign
+	}
+
+	bids := strings.SplitN(blockID, metainfo.BlockDelimiter, 2)
+	if bids[0] != rt.GetQueryID() {
+		return ErrWrongSign
+	}
+
+	found := false
+	for _, bid := range rt.GetBlockIDs() {
+		if bid == blockID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrWrongSign
+	}
+
+	hash, err := GetHashForReadToken(rt)
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := crypto.Ecrecover(hash, rt.GetSign())
+	if err != nil {
+		return ErrWrongSign
+	}
+
+	gotID, err := id.GetIDFromPubKey(pubKey)
+	if err != nil || gotID != rt.GetIssuer() {
+		return ErrWrongSign
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package role
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/memoio/go-mefs/contracts"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/utils"
+	mt "gitlab.com/NebulousLabs/merkletree"
+)
+
+// STValueVersion is version of spacetime pay sent to keepers for signing,
+// whose Root is built by GetSTRoot
+const STValueVersion = int32(1)
+
+// FillSTLeaves sorts challenge results between start and end, and fills them
+// to at least one leaf per hour; gaps are filled with zero space
+func FillSTLeaves(tsl []*mpb.STLeaf, start, end int64) []*mpb.STLeaf {
+	if len(tsl) <= 1 {
+		return nil
+	}
+
+	sort.Slice(tsl, func(i, j int) bool {
+		return tsl[i].Time < tsl[j].Time
+	})
+
+	var newTsl []*mpb.STLeaf
+
+	if tsl[0].Time > start && tsl[0].Time < start+3600 {
+		newTsl = append(newTsl, &mpb.STLeaf{Time: start, Space: tsl[0].Space})
+	} else {
+		newTsl = append(newTsl, &mpb.STLeaf{Time: start, Space: 0})
+	}
+
+	// at least once per hour
+	ftime := start + 3600
+	i := 0
+	for {
+		if i >= len(tsl) || ftime < tsl[i].Time {
+			newTsl = append(newTsl, &mpb.STLeaf{Time: ftime, Space: 0})
+			ftime += 3600
+		} else {
+			newTsl = append(newTsl, tsl[i])
+			ftime = tsl[i].Time + 3600
+			i++
+		}
+
+		if ftime > end && i == len(tsl) {
+			break
+		}
+	}
+
+	tLen := len(newTsl)
+	if newTsl[tLen-1].Time < end && newTsl[tLen-1].Time > end-3600 {
+		newTsl = append(newTsl, &mpb.STLeaf{Time: end, Space: newTsl[tLen-1].Space})
+	} else {
+		newTsl = append(newTsl, &mpb.STLeaf{Time: end, Space: 0})
+	}
+
+	return newTsl
+}
+
+// GetSTAmount returns money(wei) of spacetime of filled leaves at price
+func GetSTAmount(price *big.Int, leaves []*mpb.STLeaf) *big.Int {
+	spacetime := big.NewInt(0)
+	if len(leaves) <= 1 {
+		return spacetime
+	}
+
+	timepre := leaves[0].Time
+	lengthpre := leaves[0].Space
+	for _, tv := range leaves[1:] {
+		spacetime.Add(spacetime, big.NewInt((tv.Time-timepre)*(lengthpre+tv.Space)/2))
+		timepre = tv.Time
+		lengthpre = tv.Space
+	}
+
+	spacetime.Mul(spacetime, price)
+	spacetime.Quo(spacetime, big.NewInt(1024*1024*60*60))
+
+	stWei := new(big.Float).SetInt(spacetime)
+	stWei.Quo(stWei, contracts.GetMemoPrice())
+	stWei.Int(spacetime)
+
+	return spacetime
+}
+
+// GetSTRoot returns merkle root of filled leaves except the first one
+func GetSTRoot(leaves []*mpb.STLeaf) []byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+
+	mtree := mt.New(sha256.New())
+	mtree.SetIndex(0)
+
+	for _, tv := range leaves[1:] {
+		buf := make([]byte, 16)
+		binary.BigEndian.PutUint64(buf[:8], uint64(tv.Time))
+		binary.BigEndian.PutUint64(buf[8:], uint64(tv.Space))
+		mtree.Push(buf)
+	}
+
+	return mtree.Root()
+}
+
+// BuildSTClaim builds spacetime pay of provider between start and end from
+// challenge logs signed by keepers; logs not signed by keepers of group and
+// repeated logs of a keeper are skipped, and the median success length of
+// keepers is taken for the same challenge time, so that one keeper cannot
+// raise or lower it alone
+func BuildSTClaim(logs []*mpb.ChalLog, keepers []string, queryID, proID string, price *big.Int, start, end int64) (*mpb.STClaim, error) {
+	lens := make(map[int64][]int64)
+	signed := make(map[string]struct{})
+	var valid []*mpb.ChalLog
+	for _, cl := range logs {
+		if cl.GetQueryID() != queryID || cl.GetProviderID() != proID {
+			continue
+		}
+
+		if cl.GetChalTime() < start || cl.GetChalTime() >= end {
+			continue
+		}
+
+		if utils.CheckDup(keepers, cl.GetKeeperID()) {
+			continue
+		}
+
+		kt := cl.GetKeeperID() + strconv.FormatInt(cl.GetChalTime(), 10)
+		if _, ok := signed[kt]; ok {
+			continue
+		}
+
+		if VerifyChalLog(cl) != nil {
+			utils.MLogger.Infof("challenge log of %s at %d from keeper %s has wrong sign", proID, cl.GetChalTime(), cl.GetKeeperID())
+			continue
+		}
+
+		signed[kt] = struct{}{}
+		valid = append(valid, cl)
+		lens[cl.GetChalTime()] = append(lens[cl.GetChalTime()], cl.GetSuccessLength())
+	}
+
+	spaces := make(map[int64]int64, len(lens))
+	for t, ls := range lens {
+		spaces[t] = medianLength(ls)
+	}
+
+	var tsl []*mpb.STLeaf
+	for t, s := range spaces {
+		tsl = append(tsl, &mpb.STLeaf{Time: t, Space: s})
+	}
+
+	leaves := FillSTLeaves(tsl, start, end)
+	if len(leaves) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	sort.Slice(valid, func(i, j int) bool {
+		if valid[i].GetChalTime() == valid[j].GetChalTime() {
+			return valid[i].GetKeeperID() < valid[j].GetKeeperID()
+		}
+		return valid[i].GetChalTime() < valid[j].GetChalTime()
+	})
+
+	return &mpb.STClaim{
+		Start:  start,
+		Length: end - start,
+		Value:  GetSTAmount(price, leaves).Bytes(),
+		Root:   GetSTRoot(leaves),
+		Logs:   valid,
+	}, nil
+}
+
+// medianLength returns median of lengths; the lower one is taken for even count
+func medianLength(lens []int64) int64 {
+	sort.Slice(lens, func(i, j int) bool {
+		return lens[i] < lens[j]
+	})
+	return lens[(len(lens)-1)/2]
+}
+
+// VerifySTClaim re-builds claim from logs collected by verifier from keepers,
+// not logs chosen by provider in claim, and checks its value and root
+func VerifySTClaim(claim *mpb.STClaim, logs []*mpb.ChalLog, keepers []string, queryID, proID string, price *big.Int) error {
+	if claim == nil || claim.GetLength() <= 0 {
+		return ErrInvalidInput
+	}
+
+	res, err := BuildSTClaim(logs, keepers, queryID, proID, price, claim.GetStart(), claim.GetStart()+claim.GetLength())
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(res.GetValue(), claim.GetValue()) || !bytes.Equal(res.GetRoot(), claim.GetRoot()) {
+		return ErrWrongValue
+	}
+
+	return nil
+}
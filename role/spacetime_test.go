@@ -0,0 +1,195 @@
+package role
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/memoio/go-mefs/contracts"
+	id "github.com/memoio/go-mefs/crypto/identity"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/utils"
+)
+
+func TestFillSTLeaves(t *testing.T) {
+	start := int64(1600000000)
+	end := start + 10000
+
+	if FillSTLeaves([]*mpb.STLeaf{{Time: start + 600, Space: 100}}, start, end) != nil {
+		t.Fatal("leaves are filled from one challenge")
+	}
+
+	tsl := []*mpb.STLeaf{
+		{Time: start + 4000, Space: 200},
+		{Time: start + 600, Space: 100},
+	}
+
+	// challenge in the first hour covers start; hours without challenge
+	// and end after the last hour are filled with zero space
+	want := []*mpb.STLeaf{
+		{Time: start, Space: 100},
+		{Time: start + 600, Space: 100},
+		{Time: start + 4000, Space: 200},
+		{Time: start + 7600, Space: 0},
+		{Time: end, Space: 0},
+	}
+
+	leaves := FillSTLeaves(tsl, start, end)
+	if len(leaves) != len(want) {
+		t.Fatal("got ", len(leaves), " leaves: ", leaves)
+	}
+
+	for i := range want {
+		if leaves[i].GetTime() != want[i].GetTime() || leaves[i].GetSpace() != want[i].GetSpace() {
+			t.Fatal("leaf ", i, " is ", leaves[i], ", want ", want[i])
+		}
+	}
+}
+
+func TestSTAmountAndRoot(t *testing.T) {
+	start := int64(1600000000)
+	leaves := []*mpb.STLeaf{
+		{Time: start, Space: 100},
+		{Time: start + 600, Space: 100},
+		{Time: start + 4000, Space: 200},
+		{Time: start + 7600, Space: 0},
+	}
+
+	// price of 1 wei for 1MB*1h makes amount spacetime in Byte*second
+	price := big.NewInt(1024 * 1024 * 60 * 60)
+	st := new(big.Float).SetInt64(600*200/2 + 3400*300/2 + 3600*200/2)
+	st.Quo(st, contracts.GetMemoPrice())
+	want, _ := st.Int(nil)
+
+	if GetSTAmount(price, leaves).Cmp(want) != 0 {
+		t.Fatal("amount is ", GetSTAmount(price, leaves), ", want ", want)
+	}
+
+	if GetSTAmount(price, leaves[:1]).Sign() != 0 || GetSTRoot(leaves[:1]) != nil {
+		t.Fatal("one leaf has spacetime")
+	}
+
+	root := GetSTRoot(leaves)
+	if len(root) != 32 {
+		t.Fatal("wrong root length: ", len(root))
+	}
+
+	// first leaf is not in root
+	other := []*mpb.STLeaf{{Time: start, Space: 0}}
+	other = append(other, leaves[1:]...)
+	if !bytes.Equal(GetSTRoot(other), root) {
+		t.Fatal("root changes with the first leaf")
+	}
+
+	other[2] = &mpb.STLeaf{Time: start + 4000, Space: 201}
+	if bytes.Equal(GetSTRoot(other), root) {
+		t.Fatal("root does not change with space")
+	}
+}
+
+type testKeeper struct {
+	id string
+	sk string
+}
+
+func newTestKeeper(t *testing.T) testKeeper {
+	sk, err := id.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kid, err := id.GetIDFromPubKey(crypto.FromECDSAPub(&sk.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testKeeper{id: kid, sk: id.ECDSAByteToString(id.ToECDSAByte(sk))}
+}
+
+func testChalLog(t *testing.T, k testKeeper, queryID, proID string, chalTime, length int64) *mpb.ChalLog {
+	cl := &mpb.ChalLog{
+		QueryID:       queryID,
+		ProviderID:    proID,
+		KeeperID:      k.id,
+		ChalTime:      chalTime,
+		SuccessLength: length,
+		Res:           true,
+	}
+
+	err := SignChalLog(cl, k.sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cl
+}
+
+func TestSTClaim(t *testing.T) {
+	utils.StartLogger()
+
+	queryID := "8MGxCuiT75bje883b7uFb6eMrJt5cQ"
+	proID := "8MGxCuiT75bje883b7uFb6eMrJt5cP"
+	price := big.NewInt(1024 * 1024 * 60 * 60)
+	start := int64(1600000000)
+	end := start + 3*3600
+
+	k1, k2, k3, other := newTestKeeper(t), newTestKeeper(t), newTestKeeper(t), newTestKeeper(t)
+	keepers := []string{k1.id, k2.id, k3.id}
+
+	t1, t2 := start+1800, start+5400
+	forged := testChalLog(t, k2, queryID, proID, t2, 5000)
+	forged.SuccessLength = 6000
+
+	logs := []*mpb.ChalLog{
+		testChalLog(t, k1, queryID, proID, t1, 100),
+		testChalLog(t, k2, queryID, proID, t1, 100),
+		testChalLog(t, k3, queryID, proID, t1, 1000), // one keeper cannot raise it
+		testChalLog(t, k3, queryID, proID, t1, 2000), // repeated log of keeper
+		testChalLog(t, k1, queryID, proID, t2, 200),
+		testChalLog(t, k2, queryID, proID, t2, 300), // lower median of even count
+		forged,
+		testChalLog(t, other, queryID, proID, t2, 5000),                       // not keeper of group
+		testChalLog(t, k1, queryID, proID, end, 5000),                         // out of period
+		testChalLog(t, k1, "8MGxCuiT75bje883b7uFb6eMrJt5cO", proID, t2, 5000), // other group
+	}
+
+	claim, err := BuildSTClaim(logs, keepers, queryID, proID, price, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaves := FillSTLeaves([]*mpb.STLeaf{{Time: t1, Space: 100}, {Time: t2, Space: 200}}, start, end)
+	if !bytes.Equal(claim.GetValue(), GetSTAmount(price, leaves).Bytes()) || !bytes.Equal(claim.GetRoot(), GetSTRoot(leaves)) {
+		t.Fatal("claim value ", new(big.Int).SetBytes(claim.GetValue()), " is not of median lengths ", GetSTAmount(price, leaves))
+	}
+
+	if len(claim.GetLogs()) != 5 || claim.GetStart() != start || claim.GetLength() != end-start {
+		t.Fatal("wrong claim: ", claim.GetStart(), claim.GetLength(), len(claim.GetLogs()))
+	}
+
+	err = VerifySTClaim(claim, logs, keepers, queryID, proID, price)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claim.Value = new(big.Int).Add(new(big.Int).SetBytes(claim.GetValue()), big.NewInt(1)).Bytes()
+	err = VerifySTClaim(claim, logs, keepers, queryID, proID, price)
+	if err != ErrWrongValue {
+		t.Fatal("claim with wrong value is verified: ", err)
+	}
+
+	// logs chosen by provider are not used
+	claim, err = BuildSTClaim(logs[2:5], keepers, queryID, proID, price, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = VerifySTClaim(claim, logs, keepers, queryID, proID, price)
+	if err != ErrWrongValue {
+		t.Fatal("claim from chosen logs is verified: ", err)
+	}
+
+	_, err = BuildSTClaim(logs[:1], keepers, queryID, proID, price, start, end)
+	if err != ErrEmptyData {
+		t.Fatal("claim is built from one log: ", err)
+	}
+}
@@ -0,0 +1,7 @@
+// +build mefstest
+
+package role
+
+// AllowTestSign is set in test build, where providers take the test sign message
+// built by BuildSignMessage as paid
+const AllowTestSign = true
@@ -0,0 +1,216 @@
+// +build simchain
+
+package role
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/memoio/go-mefs/contracts"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/address"
+)
+
+type simAccount struct {
+	sk   string
+	id   string
+	addr common.Address
+}
+
+func newSimAccount(t *testing.T) simAccount {
+	sk, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := crypto.PubkeyToAddress(sk.PublicKey)
+	localID, err := address.GetIDFromAddress(addr.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return simAccount{sk: hex.EncodeToString(crypto.FromECDSA(sk)), id: localID, addr: addr}
+}
+
+// TestSimulatedUpKeeping runs upkeeping of a user on simulated chain as nodes
+// do: user deploys it, keepers sign spacetime of a provider from its leaves
+// and one of them pays it, user tops it up and extends it, and it is destructed
+// after it ends
+func TestSimulatedUpKeeping(t *testing.T) {
+	utils.StartLogger()
+
+	admin := newSimAccount(t)
+	user := newSimAccount(t)
+	var keepers, providers []simAccount
+	var kids, pids []string
+	accounts := []common.Address{user.addr}
+	for i := 0; i < 2; i++ {
+		keepers = append(keepers, newSimAccount(t))
+		providers = append(providers, newSimAccount(t))
+		kids = append(kids, keepers[i].id)
+		pids = append(pids, providers[i].id)
+		accounts = append(accounts, keepers[i].addr, providers[i].addr)
+	}
+
+	balance := new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil)
+	sc, err := contracts.NewSimulatedChain(admin.sk, accounts, balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sc.Close()
+
+	days, size := int64(10), int64(1000)
+	price := big.NewInt(utils.STOREPRICE)
+	cycle := int64(24 * 60 * 60)
+
+	queryAddr, err := contracts.NewCM(user.addr, user.sk).DeployQuery(size, days, price, len(keepers), len(providers), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queryID, err := address.GetIDFromAddress(queryAddr.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ukID, err := DeployUpKeeping(user.id, queryID, user.sk, kids, pids, days, size, price, cycle, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uk, err := GetUpKeeping(user.id, queryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deposit := GetStoreCost(price, size, days)
+	if uk.UpKeepingID != ukID || uk.Capacity != size || uk.Money.Cmp(deposit) != 0 {
+		t.Fatal("got upkeeping: ", uk.UpKeepingID, uk.Capacity, uk.Money, ", expected: ", ukID, size, deposit)
+	}
+
+	if uk.EndTime-uk.StartTime != days*24*60*60 || len(uk.Keepers) != 2 || len(uk.Providers) != 2 {
+		t.Fatal("got upkeeping from ", uk.StartTime, " to ", uk.EndTime, " with keepers: ", len(uk.Keepers), ", providers: ", len(uk.Providers))
+	}
+
+	// provider stores 100MB in the first cycle
+	start, end := uk.StartTime, uk.StartTime+cycle
+	leaves := FillSTLeaves([]*mpb.STLeaf{
+		{Time: start + 600, Space: 100 * 1024 * 1024},
+		{Time: start + 4000, Space: 100 * 1024 * 1024},
+	}, start, end)
+	value := GetSTAmount(uk.Price, leaves)
+	var root [32]byte
+	copy(root[:], GetSTRoot(leaves))
+
+	ukAddr, err := address.GetAddressFromID(ukID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pro := providers[0]
+	share := []int64{50, 50, 100}
+	var signs [][]byte
+	for _, k := range keepers {
+		sig, err := SignForStPay(ukAddr, pro.addr, k.sk, big.NewInt(start), big.NewInt(cycle), value, root, share)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signs = append(signs, sig)
+	}
+
+	err = contracts.NewCU(keepers[0].addr, keepers[0].sk).SpaceTimePay(ukAddr, pro.addr, big.NewInt(start), big.NewInt(cycle), value, root, share, signs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uk, err = GetUpkeepingInfo(user.id, ukID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uk.NeedPay.Cmp(value) != 0 || len(uk.Proofs) != 1 {
+		t.Fatal("got paid: ", uk.NeedPay, ", proofs: ", len(uk.Proofs), ", expected: ", value)
+	}
+
+	// user renews it for one more day
+	money := GetStoreCost(price, size, 1)
+	err = TopUpUpKeeping(user.id, queryID, user.sk, money, "renew")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ExtendUpKeeping(user.id, queryID, user.sk, cycle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renewed, err := GetUpkeepingInfo(user.id, ukID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if renewed.EndTime != uk.EndTime+cycle || renewed.Money.Cmp(new(big.Int).Add(uk.Money, money)) != 0 {
+		t.Fatal("got renewed upkeeping to ", renewed.EndTime, " with ", renewed.Money)
+	}
+
+	// spacetime paid is sent to provider and keepers, and money left is
+	// returned to user after it ends
+	err = sc.AdjustTime(time.Duration(renewed.EndTime-uk.StartTime+cycle) * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc.Commit()
+
+	before := make(map[string]*big.Int)
+	for _, a := range []simAccount{user, keepers[0], keepers[1], pro} {
+		before[a.id], err = QueryBalance(a.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// only owner of upkeeping can destruct it
+	err = DestructUpKeeping(user.id, queryID, user.id, user.sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ukMoney, err := sc.BalanceAt(context.Background(), ukAddr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ukMoney.Sign() != 0 {
+		t.Fatal("upkeeping has money left: ", ukMoney)
+	}
+
+	income := new(big.Int)
+	for _, a := range []simAccount{keepers[0], keepers[1], pro} {
+		after, err := QueryBalance(a.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := new(big.Int).Sub(after, before[a.id])
+		if got.Sign() <= 0 {
+			t.Fatal(a.id, " is not paid")
+		}
+		income.Add(income, got)
+	}
+
+	if income.Cmp(value) > 0 {
+		t.Fatal("paid ", income, " for spacetime of ", value)
+	}
+
+	after, err := QueryBalance(user.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Cmp(before[user.id]) <= 0 {
+		t.Fatal("money left is not returned to user")
+	}
+}
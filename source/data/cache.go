@@ -5,7 +5,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/memoio/go-mefs/crypto/pdp"
 	dataformat "github.com/memoio/go-mefs/data-format"
 	bf "github.com/memoio/go-mefs/source/go-block-format"
 	cid "github.com/memoio/go-mefs/source/go-cid"
@@ -136,29 +135,7 @@ func (c *Cache) Get(k string, start, length int) ([]byte, error) {
 		defer val.RUnlock()
 		for i := 0; i < len(val.Segs); i++ {
 			if start >= val.Segs[i].Start && start+length <= val.Segs[i].Start+val.Segs[i].Length {
-				pre, preLen, err := bf.PrefixDecode(val.Segs[i].Value)
-				if err != nil {
-					return nil, err
-				}
-
-				tagSize, ok := pdp.TagMap[int(pre.Bopts.TagFlag)]
-				if !ok {
-					return nil, dataformat.ErrWrongTagFlag
-				}
-
-				tagNum := 2 + (pre.Bopts.ParityCount-1)/pre.Bopts.DataCount
-				fieldSize := int(pre.Bopts.SegmentSize + tagNum*int32(tagSize))
-
-				pre.Start = int32(start)
-				prebuf, npreLen, err := bf.PrefixEncode(pre)
-				if err != nil {
-					return nil, err
-				}
-
-				res := make([]byte, npreLen+fieldSize*length)
-				copy(res, prebuf)
-				copy(res[npreLen:], val.Segs[i].Value[preLen+(start-val.Segs[i].Start)*fieldSize:preLen+(start-val.Segs[i].Start+length)*fieldSize])
-				return res, nil
+				return dataformat.GetFields(val.Segs[i].Value, start, length)
 			}
 			if start < val.Segs[i].Start {
 				break
@@ -170,6 +147,21 @@ func (c *Cache) Get(k string, start, length int) ([]byte, error) {
 	return nil, ErrRetry
 }
 
+// GetRange gets length fields from field start of block k, from cache if
+// they are not flushed, otherwise from blockstore
+func (c *Cache) GetRange(k string, start, length int) ([]byte, error) {
+	res, err := c.Get(k, start, length)
+	if err == nil {
+		return res, nil
+	}
+
+	b, err := c.bstore.GetRange(cid.NewCidV2([]byte(k)), start, length)
+	if err != nil {
+		return nil, err
+	}
+	return b.RawData(), nil
+}
+
 func (c *Cache) Set(k string, val []byte, start, length int) error {
 	utils.MLogger.Infof("add to cache %s has seg start at %d, length %d", k, start, length)
 	e := time.Now().Add(defaultExpiration).UnixNano()
@@ -54,6 +54,7 @@ type impl struct {
 	bstore   bs.Blockstore
 	dstore   ds.Datastore
 	aCache   *Cache
+	rCache   *ReadCache
 	rt       routing.Routing
 	ph       p2phost.Host
 	pubKeys  *lru.ARCCache
@@ -95,7 +96,7 @@ type measure struct {
 }
 
 // New returns data.Service
-func New(id string, b bs.Blockstore, d ds.Datastore, host p2phost.Host, r routing.Routing) Service {
+func New(id string, b bs.Blockstore, d ds.Datastore, host p2phost.Host, r routing.Routing, rc config.ReadCache) Service {
 	if r == nil {
 		log.Println("network is not running.")
 	}
@@ -158,6 +159,7 @@ func New(id string, b bs.Blockstore, d ds.Datastore, host p2phost.Host, r routin
 		dstore:  d,
 		bstore:  b,
 		aCache:  NewCache(b),
+		rCache:  NewReadCache(rc),
 		pubKeys: pcache,
 		ms:      mea,
 	}
@@ -464,7 +466,7 @@ func (n *impl) DeleteKey(ctx context.Context, key string, to string) error {
 
 // GetBlock retrieves a particular partial block from the service,
 // Getting it from the datastore using the key (hash).
-// key: blockID/"Block"/start/length (todo)
+// key: blockID/"Block"/start/length, only fields in range are read
 func (n *impl) GetBlock(ctx context.Context, key string, sig []byte, to string) (blocks.Block, error) {
 	if n.ph == nil || n.rt == nil {
 		return nil, errNoRouting
@@ -484,16 +486,16 @@ func (n *impl) GetBlock(ctx context.Context, key string, sig []byte, to string)
 			if err != nil {
 				return nil, err
 			}
-			// get from cache
-			res, err := n.aCache.Get(skey[0], s, len)
+			// get from cache or read fields from blockstore
+			res, err := n.aCache.GetRange(skey[0], s, len)
 			if err == nil {
-				c := cid.NewCidV2([]byte(skey[0]))
-				b, err := blocks.NewBlockWithCid(res, c)
-				if err != nil {
-					return nil, err
-				}
-				return b, nil
+				return blocks.NewBlockWithCid(res, cid.NewCidV2([]byte(skey[0])))
+			}
+			if err == dataformat.ErrDataTooShort {
+				go n.aCache.Summit(skey[0])
 			}
+			n.ms.getBlockErr.Inc()
+			return nil, err
 		}
 
 		block, err := n.bstore.Get(cid.NewCidV2([]byte(key)))
@@ -550,6 +552,7 @@ func (n *impl) PutBlock(ctx context.Context, key string, data []byte, to string)
 	bids := strings.Split(key, metainfo.DELIMITER)
 	if to == "local" {
 		n.ms.putLocalBlock.Inc()
+		n.rCache.Remove(bids[0])
 		bcid := cid.NewCidV2([]byte(bids[0]))
 		b, err := blocks.NewBlockWithCid(data, bcid)
 		if err != nil {
@@ -592,6 +595,7 @@ func (n *impl) AppendBlock(ctx context.Context, key string, data []byte, to stri
 	}
 	if to == "local" {
 		n.ms.appendLocalBlock.Inc()
+		n.rCache.Remove(skey[0])
 		s, err := strconv.Atoi(skey[2])
 		if err != nil {
 			return err
@@ -637,6 +641,7 @@ func (n *impl) DeleteBlock(ctx context.Context, key, to string) error {
 	bids := strings.Split(key, metainfo.DELIMITER)
 	if to == "local" {
 		n.ms.delLocalBlock.Inc()
+		n.rCache.Remove(bids[0])
 		bcid := cid.NewCidV2([]byte(bids[0]))
 		err := n.bstore.DeleteBlock(bcid)
 		if err != nil {
@@ -995,3 +1000,7 @@ func (n *impl) BlockStore() bs.Blockstore {
 func (n *impl) DataStore() ds.Datastore {
 	return n.dstore
 }
+
+func (n *impl) ReadCache() *ReadCache {
+	return n.rCache
+}
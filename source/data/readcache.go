@@ -0,0 +1,199 @@
+package data
+
+import (
+	"container/list"
+	"sync"
+
+	metrics "github.com/ipfs/go-metrics-interface"
+	"github.com/memoio/go-mefs/config"
+	"github.com/memoio/go-mefs/utils"
+)
+
+const (
+	defaultReadCacheSize int64 = 256 * 1024 * 1024
+	// frequencies are halved after so many reads, at least
+	minFreqWindow = 4096
+)
+
+type rcEntry struct {
+	group string
+	key   string
+	value []byte
+}
+
+// ReadCache is a size-bounded cache of hot data read from disks or network,
+// entries are grouped, e.g. by block, so that they are removed together when
+// the block changes. Least recently used entries are evicted; with lfu
+// admission, new entry is admitted only if it is read more often than
+// entries evicted by it, so that reads of cold data do not flush hot data.
+// A nil ReadCache caches nothing.
+type ReadCache struct {
+	sync.Mutex
+	size  int64
+	used  int64
+	lfu   bool
+	ll    *list.List // front is the most recent
+	items map[string]map[string]*list.Element
+
+	freq  map[string]uint32 // key is group/key
+	reads int
+
+	hits    metrics.Counter
+	misses  metrics.Counter
+	rejects metrics.Counter
+	usage   metrics.Gauge
+}
+
+// NewReadCache creates read cache from config, it returns nil if cache is disabled
+func NewReadCache(cfg config.ReadCache) *ReadCache {
+	if cfg.Size < 0 {
+		return nil
+	}
+
+	size := cfg.Size * 1024 * 1024
+	if size == 0 {
+		size = defaultReadCacheSize
+	}
+
+	lfu := true
+	switch cfg.Policy {
+	case "", "lfu":
+	case "lru":
+		lfu = false
+	default:
+		utils.MLogger.Warnf("unknown read cache policy %s, use lfu", cfg.Policy)
+	}
+
+	return &ReadCache{
+		size:  size,
+		lfu:   lfu,
+		ll:    list.New(),
+		items: make(map[string]map[string]*list.Element),
+		freq:  make(map[string]uint32),
+
+		hits:    metrics.New("data.readcache.hits_total", "Total number of read cache hits").Counter(),
+		misses:  metrics.New("data.readcache.misses_total", "Total number of read cache misses").Counter(),
+		rejects: metrics.New("data.readcache.rejects_total", "Total number of entries not admitted by read cache").Counter(),
+		usage:   metrics.New("data.readcache.used_bytes", "Bytes used by read cache").Gauge(),
+	}
+}
+
+// touch counts a read of group/key; frequencies are halved periodically,
+// so that old hot data become cold
+func (c *ReadCache) touch(fk string) {
+	c.freq[fk]++
+	c.reads++
+
+	window := 8 * c.ll.Len()
+	if window < minFreqWindow {
+		window = minFreqWindow
+	}
+	if c.reads < window {
+		return
+	}
+
+	c.reads = 0
+	for k, f := range c.freq {
+		if f <= 1 {
+			delete(c.freq, k)
+			continue
+		}
+		c.freq[k] = f / 2
+	}
+}
+
+// Get returns value of key in group; returned value must not be modified
+func (c *ReadCache) Get(group, key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.touch(group + "/" + key)
+
+	e, ok := c.items[group][key]
+	if !ok {
+		c.misses.Inc()
+		return nil, false
+	}
+
+	c.hits.Inc()
+	c.ll.MoveToFront(e)
+	return e.Value.(*rcEntry).value, true
+}
+
+// Add puts value of key in group, value must not be modified after it
+func (c *ReadCache) Add(group, key string, value []byte) {
+	if c == nil || int64(len(value)) > c.size {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if e, ok := c.items[group][key]; ok {
+		en := e.Value.(*rcEntry)
+		c.used += int64(len(value) - len(en.value))
+		en.value = value
+		c.ll.MoveToFront(e)
+	} else {
+		need := c.used + int64(len(value)) - c.size
+		if c.lfu && need > 0 {
+			// admit only if it is hotter than all victims
+			f := c.freq[group+"/"+key]
+			for e := c.ll.Back(); e != nil && need > 0; e = e.Prev() {
+				en := e.Value.(*rcEntry)
+				if c.freq[en.group+"/"+en.key] >= f {
+					c.rejects.Inc()
+					return
+				}
+				need -= int64(len(en.value))
+			}
+		}
+
+		en := &rcEntry{
+			group: group,
+			key:   key,
+			value: value,
+		}
+		if c.items[group] == nil {
+			c.items[group] = make(map[string]*list.Element)
+		}
+		c.items[group][key] = c.ll.PushFront(en)
+		c.used += int64(len(value))
+	}
+
+	for c.used > c.size {
+		c.removeElement(c.ll.Back())
+	}
+	c.usage.Set(float64(c.used))
+}
+
+// Remove removes all entries of group
+func (c *ReadCache) Remove(group string) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	for _, e := range c.items[group] {
+		c.removeElement(e)
+	}
+	c.usage.Set(float64(c.used))
+}
+
+func (c *ReadCache) removeElement(e *list.Element) {
+	en := e.Value.(*rcEntry)
+	c.ll.Remove(e)
+	c.used -= int64(len(en.value))
+
+	g := c.items[en.group]
+	delete(g, en.key)
+	if len(g) == 0 {
+		delete(c.items, en.group)
+	}
+}
@@ -0,0 +1,133 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/memoio/go-mefs/config"
+)
+
+const testEntrySize = 300 * 1024
+
+func TestReadCacheConfig(t *testing.T) {
+	var c *ReadCache
+	if NewReadCache(config.ReadCache{Size: -1}) != c {
+		t.Fatal("disabled cache is created")
+	}
+
+	// nil cache caches nothing
+	c.Add("b1", "k1", make([]byte, 10))
+	if _, ok := c.Get("b1", "k1"); ok {
+		t.Fatal("nil cache has value")
+	}
+	c.Remove("b1")
+
+	c = NewReadCache(config.ReadCache{})
+	if c.size != defaultReadCacheSize || !c.lfu {
+		t.Fatal("default cache is ", c.size, " bytes, lfu: ", c.lfu)
+	}
+
+	c = NewReadCache(config.ReadCache{Size: 1, Policy: "lru"})
+	if c.size != 1024*1024 || c.lfu {
+		t.Fatal("lru cache is ", c.size, " bytes, lfu: ", c.lfu)
+	}
+
+	// value larger than cache is not added
+	c.Add("b1", "k1", make([]byte, c.size+1))
+	if _, ok := c.Get("b1", "k1"); ok || c.used != 0 {
+		t.Fatal("value larger than cache is added")
+	}
+}
+
+func TestReadCacheEvict(t *testing.T) {
+	c := NewReadCache(config.ReadCache{Size: 1, Policy: "lru"})
+
+	keys := []string{"k1", "k2", "k3", "k4", "k5"}
+	for _, k := range keys {
+		c.Add("b1", k, make([]byte, testEntrySize))
+		if c.used > c.size {
+			t.Fatal("cache uses ", c.used, " bytes over ", c.size)
+		}
+	}
+
+	// least recently used are evicted
+	for i, k := range keys {
+		_, ok := c.Get("b1", k)
+		if ok != (i >= 2) {
+			t.Fatal("key ", k, " is cached: ", ok)
+		}
+	}
+
+	if c.used != 3*testEntrySize || c.ll.Len() != 3 {
+		t.Fatal("cache uses ", c.used, " bytes by ", c.ll.Len(), " entries")
+	}
+
+	// value of key is replaced
+	c.Add("b1", "k5", make([]byte, 10))
+	if val, ok := c.Get("b1", "k5"); !ok || len(val) != 10 || c.used != 2*testEntrySize+10 {
+		t.Fatal("value is not replaced, cache uses ", c.used)
+	}
+}
+
+func TestReadCacheAdmission(t *testing.T) {
+	c := NewReadCache(config.ReadCache{Size: 1})
+
+	for _, k := range []string{"k1", "k2", "k3"} {
+		c.Get("b1", k)
+		c.Get("b1", k)
+		c.Add("b1", k, make([]byte, testEntrySize))
+	}
+
+	// cold value does not evict hot ones
+	c.Add("b1", "cold", make([]byte, testEntrySize))
+	if _, ok := c.Get("b1", "cold"); ok {
+		t.Fatal("cold value is admitted")
+	}
+
+	for _, k := range []string{"k1", "k2", "k3"} {
+		if _, ok := c.Get("b1", k); !ok {
+			t.Fatal("hot value ", k, " is evicted")
+		}
+	}
+
+	// value read more often than victims is admitted
+	for i := 0; i < 5; i++ {
+		c.Get("b1", "hot")
+	}
+	c.Add("b1", "hot", make([]byte, testEntrySize))
+	if _, ok := c.Get("b1", "hot"); !ok {
+		t.Fatal("hot value is not admitted")
+	}
+
+	if _, ok := c.Get("b1", "k1"); ok || c.used > c.size {
+		t.Fatal("least recently used value is not evicted")
+	}
+}
+
+func TestReadCacheRemove(t *testing.T) {
+	c := NewReadCache(config.ReadCache{Size: 1})
+
+	c.Add("b1", "k1", make([]byte, 100))
+	c.Add("b1", "k2", make([]byte, 200))
+	c.Add("b2", "k1", make([]byte, 300))
+
+	c.Remove("b1")
+	for _, k := range []string{"k1", "k2"} {
+		if _, ok := c.Get("b1", k); ok {
+			t.Fatal("key ", k, " of removed group is cached")
+		}
+	}
+
+	if _, ok := c.Get("b2", "k1"); !ok {
+		t.Fatal("key of other group is removed")
+	}
+
+	if c.used != 300 || c.ll.Len() != 1 || len(c.items) != 1 {
+		t.Fatal("cache uses ", c.used, " bytes by ", c.ll.Len(), " entries after removing")
+	}
+
+	// removing unknown group does nothing
+	c.Remove("b3")
+	if c.used != 300 {
+		t.Fatal("cache uses ", c.used, " bytes")
+	}
+}
@@ -43,4 +43,5 @@ type Service interface {
 	GetPublicAddr(ctx context.Context, need string) (ma.Multiaddr, error)
 	BlockStore() bs.Blockstore
 	DataStore() ds.Datastore
+	ReadCache() *ReadCache
 }
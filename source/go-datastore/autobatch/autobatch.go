@@ -168,6 +168,19 @@ func (d *Datastore) GetSize(k ds.Key) (int, error) {
 	return d.child.GetSize(k)
 }
 
+// GetRange implements Datastore.GetRange
+func (d *Datastore) GetRange(k ds.Key, begin, count int) ([]byte, error) {
+	o, ok := d.buffer[k]
+	if ok {
+		if o.delete {
+			return nil, ds.ErrNotFound
+		}
+		return nil, ds.ErrRangeUnsupported
+	}
+
+	return d.child.GetRange(k, begin, count)
+}
+
 // Query performs a query
 func (d *Datastore) Query(q dsq.Query) (dsq.Results, error) {
 	err := d.Flush()
@@ -64,6 +64,14 @@ func (d *MapDatastore) GetSize(key Key) (size int, err error) {
 	return -1, ErrNotFound
 }
 
+// GetRange implements Datastore.GetRange
+func (d *MapDatastore) GetRange(key Key, begin, count int) (value []byte, err error) {
+	if _, found := d.values[key]; found {
+		return nil, ErrRangeUnsupported
+	}
+	return nil, ErrNotFound
+}
+
 // Delete implements Datastore.Delete
 func (d *MapDatastore) Delete(key Key) (err error) {
 	delete(d.values, key)
@@ -133,6 +141,11 @@ func (d *NullDatastore) GetSize(key Key) (size int, err error) {
 	return -1, ErrNotFound
 }
 
+// GetRange implements Datastore.GetRange
+func (d *NullDatastore) GetRange(key Key, begin, count int) (value []byte, err error) {
+	return nil, ErrNotFound
+}
+
 // Delete implements Datastore.Delete
 func (d *NullDatastore) Delete(key Key) (err error) {
 	return nil
@@ -214,6 +227,12 @@ func (d *LogDatastore) GetSize(key Key) (size int, err error) {
 	return d.child.GetSize(key)
 }
 
+// GetRange implements Datastore.GetRange
+func (d *LogDatastore) GetRange(key Key, begin, count int) (value []byte, err error) {
+	log.Printf("%s: GetRange %s %d %d\n", d.Name, key, begin, count)
+	return d.child.GetRange(key, begin, count)
+}
+
 // Delete implements Datastore.Delete
 func (d *LogDatastore) Delete(key Key) (err error) {
 	log.Printf("%s: Delete %s\n", d.Name, key)
@@ -81,6 +81,13 @@ type Read interface {
 	// value rather than retrieving the value itself.
 	GetSize(key Key) (size int, err error)
 
+	// GetRange retrieves count fields from field begin of the block named by
+	// `key`, with block prefix whose start is begin. It is much cheaper than
+	// Get when only a few segments of a large block are needed.
+	// Datastores which do not know the format of blocks return
+	// ErrRangeUnsupported, callers should Get the whole block instead.
+	GetRange(key Key, begin, count int) (value []byte, err error)
+
 	// Query searches the datastore and returns a query result. This function
 	// may return before the query actually runs. To wait for the query:
 	//
@@ -214,6 +221,10 @@ func (e *dsError) NotFound() bool {
 // given key to a value.
 var ErrNotFound error = &dsError{error: errors.New("datastore: key not found"), isNotFound: true}
 
+// ErrRangeUnsupported is returned by GetRange when a datastore cannot read
+// fields of blocks.
+var ErrRangeUnsupported = errors.New("datastore: range read unsupported")
+
 // GetBackedHas provides a default Datastore.Has implementation.
 // It exists so Datastore.Has implementations can use it, like so:
 //
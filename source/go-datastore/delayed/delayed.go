@@ -59,6 +59,12 @@ func (dds *Delayed) GetSize(key ds.Key) (size int, err error) {
 	return dds.ds.GetSize(key)
 }
 
+// GetRange implements the ds.Datastore interface.
+func (dds *Delayed) GetRange(key ds.Key, begin, count int) (value []byte, err error) {
+	dds.delay.Wait()
+	return dds.ds.GetRange(key, begin, count)
+}
+
 // Delete implements the ds.Datastore interface.
 func (dds *Delayed) Delete(key ds.Key) (err error) {
 	dds.delay.Wait()
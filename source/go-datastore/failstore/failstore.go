@@ -76,6 +76,16 @@ func (d *Failstore) GetSize(k ds.Key) (int, error) {
 	return d.child.GetSize(k)
 }
 
+// GetRange returns fields of the block in the datastore, if present.
+func (d *Failstore) GetRange(k ds.Key, begin, count int) ([]byte, error) {
+	err := d.errfunc("getrange")
+	if err != nil {
+		return nil, err
+	}
+
+	return d.child.GetRange(k, begin, count)
+}
+
 // Delete removes a key/value from the datastore.
 func (d *Failstore) Delete(k ds.Key) error {
 	err := d.errfunc("delete")
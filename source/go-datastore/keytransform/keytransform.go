@@ -64,6 +64,12 @@ func (d *Datastore) GetSize(key ds.Key) (size int, err error) {
 	return d.child.GetSize(d.ConvertKey(key))
 }
 
+// GetRange returns fields of the block named by the given key, transforming
+// the key first.
+func (d *Datastore) GetRange(key ds.Key, begin, count int) (value []byte, err error) {
+	return d.child.GetRange(d.ConvertKey(key), begin, count)
+}
+
 // Delete removes the value for given key
 func (d *Datastore) Delete(key ds.Key) (err error) {
 	return d.child.Delete(d.ConvertKey(key))
@@ -237,6 +237,14 @@ func (d *Datastore) GetSize(key ds.Key) (size int, err error) {
 	return cds.GetSize(k)
 }
 
+func (d *Datastore) GetRange(key ds.Key, begin, count int) ([]byte, error) {
+	cds, _, k := d.lookup(key)
+	if cds == nil {
+		return nil, ds.ErrNotFound
+	}
+	return cds.GetRange(k, begin, count)
+}
+
 func (d *Datastore) Delete(key ds.Key) error {
 	cds, _, k := d.lookup(key)
 	if cds == nil {
@@ -54,6 +54,17 @@ func (d *Datastore) DiskUsage() (uint64, error) {
 	return size, err
 }
 
+// GetRange returns fields of the block in the datastore, if present.
+func (d *Datastore) GetRange(k ds.Key, begin, count int) ([]byte, error) {
+	var val []byte
+	err := d.runOp(func() error {
+		var err error
+		val, err = d.Batching.GetRange(k, begin, count)
+		return err
+	})
+	return val, err
+}
+
 // Get retrieves a value given a key.
 func (d *Datastore) Get(k ds.Key) ([]byte, error) {
 	var val []byte
@@ -68,6 +68,13 @@ func (d *MutexDatastore) GetSize(key ds.Key) (size int, err error) {
 	return d.child.GetSize(key)
 }
 
+// GetRange implements Datastore.GetRange
+func (d *MutexDatastore) GetRange(key ds.Key, begin, count int) (value []byte, err error) {
+	d.RLock()
+	defer d.RUnlock()
+	return d.child.GetRange(key, begin, count)
+}
+
 // Delete implements Datastore.Delete
 func (d *MutexDatastore) Delete(key ds.Key) (err error) {
 	d.Lock()
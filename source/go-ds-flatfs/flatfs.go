@@ -561,7 +561,7 @@ func (fs *Datastore) doAppend(key datastore.Key, fields []byte, begin, length in
 	if err != nil {
 		return err
 	}
-	tagCount := 2 + (pre.Bopts.ParityCount-1)/pre.Bopts.DataCount
+	tagCount := int32(dataformat.TagCount(pre.Bopts))
 
 	tagSize, ok := pdp.TagMap[int(pre.Bopts.TagFlag)]
 	if !ok {
@@ -754,9 +754,7 @@ func (fs *Datastore) Get(key datastore.Key) (value []byte, err error) {
 		}
 		return data, nil
 	case 4:
-		bkey = datastore.NewKey(sval[0])
-
-		segStart, err := strconv.ParseInt(sval[2], 10, 0)
+		segStart, err := strconv.Atoi(sval[2])
 		if err != nil {
 			return nil, err
 		}
@@ -766,64 +764,85 @@ func (fs *Datastore) Get(key datastore.Key) (value []byte, err error) {
 			return nil, err
 		}
 
-		_, path := fs.encode(bkey)
-		f, err := os.OpenFile(path, os.O_RDONLY, 0666)
-		if err != nil {
-			return nil, err
-		}
-		defer f.Close()
+		return fs.getRange(datastore.NewKey(sval[0]), segStart, segLength)
+	default:
+		return nil, ErrNotExist
+	}
+}
 
-		fInfo, err := f.Stat()
-		if err != nil {
-			return nil, err
-		}
-		fileSize := fInfo.Size()
+// GetRange reads count fields from field begin of the block; only the
+// prefix and these fields are read from the file.
+func (fs *Datastore) GetRange(key datastore.Key, begin, count int) (value []byte, err error) {
+	fs.shutdownLock.RLock()
+	defer fs.shutdownLock.RUnlock()
+	if fs.shutdown {
+		return nil, ErrClosed
+	}
 
-		fReader := bufio.NewReader(f)
-		prefix := make([]byte, 4096)
-		_, err = fReader.Read(prefix)
-		if err != nil {
-			return nil, err
-		}
+	bkey := datastore.NewKey(strings.Split(key.String()[1:], metainfo.DELIMITER)[0])
+	return fs.getRange(bkey, begin, count)
+}
 
-		pre, preLen, err := bf.PrefixDecode(prefix)
-		if err != nil {
-			return nil, err
-		}
+func (fs *Datastore) getRange(key datastore.Key, begin, count int) ([]byte, error) {
+	if begin < 0 || count < 0 {
+		return nil, dataformat.ErrCannotGetSegment
+	}
 
-		tagSize, ok := pdp.TagMap[int(pre.Bopts.TagFlag)]
-		if !ok {
-			return nil, dataformat.ErrWrongTagFlag
+	_, path := fs.encode(key)
+	f, err := os.OpenFile(path, os.O_RDONLY, 0666)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, datastore.ErrNotFound
 		}
+		return nil, err
+	}
+	defer f.Close()
+
+	fInfo, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	fileSize := fInfo.Size()
 
-		tagNum := 2 + (pre.Bopts.ParityCount-1)/pre.Bopts.DataCount
-		fieldSize := int(pre.Bopts.SegmentSize + tagNum*int32(tagSize))
+	fReader := bufio.NewReader(f)
+	prefix := make([]byte, 4096)
+	_, err = fReader.Read(prefix)
+	if err != nil {
+		return nil, err
+	}
 
-		start := preLen + int(segStart)*fieldSize
-		if fileSize < int64(start+fieldSize*segLength) {
-			return nil, dataformat.ErrDataTooShort
-		}
+	pre, preLen, err := bf.PrefixDecode(prefix)
+	if err != nil {
+		return nil, err
+	}
 
-		pre.Start = int32(segStart)
-		prebuf, preLen, err := bf.PrefixEncode(pre)
-		if err != nil {
-			return nil, err
-		}
+	fieldSize, err := dataformat.FieldSize(pre.Bopts)
+	if err != nil {
+		return nil, err
+	}
 
-		res := make([]byte, preLen+fieldSize*segLength)
-		copy(res, prebuf)
-		n, err := f.ReadAt(res[preLen:], int64(start))
-		if err != nil {
-			return nil, err
-		}
-		if n != int(fieldSize*segLength) {
-			return nil, dataformat.ErrCannotGetSegment
-		}
+	start := preLen + begin*fieldSize
+	if fileSize < int64(start+fieldSize*count) {
+		return nil, dataformat.ErrDataTooShort
+	}
 
-		return res, nil
-	default:
-		return nil, ErrNotExist
+	pre.Start = int32(begin)
+	prebuf, preLen, err := bf.PrefixEncode(pre)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, preLen+fieldSize*count)
+	copy(res, prebuf)
+	n, err := f.ReadAt(res[preLen:], int64(start))
+	if err != nil {
+		return nil, err
 	}
+	if n != fieldSize*count {
+		return nil, dataformat.ErrCannotGetSegment
+	}
+
+	return res, nil
 }
 
 func (fs *Datastore) Has(key datastore.Key) (exists bool, err error) {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 
+	dataformat "github.com/memoio/go-mefs/data-format"
 	ds "github.com/memoio/go-mefs/source/go-datastore"
 	dsq "github.com/memoio/go-mefs/source/go-datastore/query"
 	"github.com/memoio/go-mefs/source/goleveldb/leveldb"
@@ -107,6 +108,14 @@ func (d *accessor) GetSize(key ds.Key) (size int, err error) {
 	return ds.GetBackedSize(d, key)
 }
 
+func (a *accessor) GetRange(key ds.Key, begin, count int) (value []byte, err error) {
+	val, err := a.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return dataformat.GetFields(val, begin, count)
+}
+
 func (a *accessor) Delete(key ds.Key) (err error) {
 	return a.ldb.Delete(key.Bytes(), &opt.WriteOptions{Sync: a.syncWrites})
 }
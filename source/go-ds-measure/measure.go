@@ -52,6 +52,12 @@ func New(prefix string, ds datastore.Datastore) *measure {
 		getsizeErr: metrics.New(prefix+".getsize.errors_total", "Number of errored Datastore.GetSize calls").Counter(),
 		getsizeLatency: metrics.New(prefix+".getsize.latency_seconds",
 			"Latency distribution of Datastore.GetSize calls").Histogram(datastoreLatencyBuckets),
+		getrangeNum: metrics.New(prefix+".getrange_total", "Total number of Datastore.GetRange calls").Counter(),
+		getrangeErr: metrics.New(prefix+".getrange.errors_total", "Number of errored Datastore.GetRange calls").Counter(),
+		getrangeLatency: metrics.New(prefix+".getrange.latency_seconds",
+			"Latency distribution of Datastore.GetRange calls").Histogram(datastoreLatencyBuckets),
+		getrangeSize: metrics.New(prefix+".getrange.size_bytes",
+			"Size distribution of retrieved ranges").Histogram(datastoreSizeBuckets),
 
 		deleteNum: metrics.New(prefix+".delete_total", "Total number of Datastore.Delete calls").Counter(),
 		deleteErr: metrics.New(prefix+".delete.errors_total", "Number of errored Datastore.Delete calls").Counter(),
@@ -128,6 +134,11 @@ type measure struct {
 	getsizeErr     metrics.Counter
 	getsizeLatency metrics.Histogram
 
+	getrangeNum     metrics.Counter
+	getrangeErr     metrics.Counter
+	getrangeLatency metrics.Histogram
+	getrangeSize    metrics.Histogram
+
 	deleteNum     metrics.Counter
 	deleteErr     metrics.Counter
 	deleteLatency metrics.Histogram
@@ -241,6 +252,21 @@ func (m *measure) GetSize(key datastore.Key) (size int, err error) {
 	return size, err
 }
 
+func (m *measure) GetRange(key datastore.Key, begin, count int) (value []byte, err error) {
+	defer recordLatency(m.getrangeLatency, time.Now())
+	m.getrangeNum.Inc()
+	value, err = m.backend.GetRange(key, begin, count)
+	switch err {
+	case nil:
+		m.getrangeSize.Observe(float64(len(value)))
+	case datastore.ErrNotFound, datastore.ErrRangeUnsupported:
+		// Not really an error.
+	default:
+		m.getrangeErr.Inc()
+	}
+	return value, err
+}
+
 func (m *measure) Delete(key datastore.Key) error {
 	defer recordLatency(m.deleteLatency, time.Now())
 	m.deleteNum.Inc()
@@ -0,0 +1,595 @@
+// Package multidisk is a Datastore implementation which spreads values over
+// datastores on several disks. New values are placed on healthy disks weighted
+// by their free capacity, and keys lost on a failed disk are reported, so that
+// they can be repaired from other replicas.
+package multidisk
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log"
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	dsq "github.com/memoio/go-mefs/source/go-datastore/query"
+	"github.com/memoio/go-mefs/utils"
+)
+
+var log = logging.Logger("multidisk")
+
+const (
+	// a disk is failed after so many continuous io errors
+	maxDiskErrors = 3
+	// interval of checking and refreshing disks
+	checkInterval = time.Minute
+	// file written to check whether a disk is writable
+	checkFile = ".diskcheck"
+)
+
+var (
+	ErrNoDisk  = errors.New("multidisk: no disk is given")
+	ErrNoSpace = errors.New("multidisk: no healthy disk has enough space")
+)
+
+var _ ds.Datastore = (*Datastore)(nil)
+var _ ds.Batching = (*Datastore)(nil)
+var _ ds.PersistentDatastore = (*Datastore)(nil)
+var _ ds.CheckedDatastore = (*Datastore)(nil)
+
+// Disk describes a datastore on one disk
+type Disk struct {
+	// Path is the directory of datastore, used for checking the disk
+	Path string
+	// Capacity is max bytes used on the disk; 0 means the whole disk
+	Capacity uint64
+	// Datastore must list all its keys with a keys only query
+	Datastore ds.Batching
+}
+
+// DiskStat is the status of a disk
+type DiskStat struct {
+	Path     string
+	Capacity uint64
+	Used     uint64
+	Free     uint64
+	Keys     int
+	Failed   bool
+	Error    string
+}
+
+// Failure reports keys which are lost on a failed disk
+type Failure struct {
+	Path string
+	Err  error
+	Keys []ds.Key
+}
+
+type disk struct {
+	Disk
+	used   uint64 // bytes used by the datastore
+	fsFree uint64 // free bytes of filesystem
+	keys   int
+	errs   int
+	failed bool
+	err    error
+}
+
+// free returns bytes can be put on the disk
+func (d *disk) free() uint64 {
+	if d.failed || d.used >= d.Capacity {
+		return 0
+	}
+	free := d.Capacity - d.used
+	if free > d.fsFree {
+		free = d.fsFree
+	}
+	return free
+}
+
+// Datastore keeps an index of keys to disks, which is built from keys of all
+// disks when it is opened.
+type Datastore struct {
+	lk       sync.RWMutex
+	disks    []*disk
+	index    map[string]int
+	rnd      *rand.Rand
+	failures chan *Failure
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+var (
+	openedLk sync.Mutex
+	opened   = make(map[*Datastore]struct{})
+)
+
+// Opened returns multidisk datastores which are opened in this process,
+// so that their owner can watch failures without unwrapping datastores.
+func Opened() []*Datastore {
+	openedLk.Lock()
+	defer openedLk.Unlock()
+
+	res := make([]*Datastore, 0, len(opened))
+	for d := range opened {
+		res = append(res, d)
+	}
+	return res
+}
+
+// New creates a multidisk datastore over disks; it lists keys of all disks
+func New(disks []Disk) (*Datastore, error) {
+	if len(disks) == 0 {
+		return nil, ErrNoDisk
+	}
+
+	d := &Datastore{
+		disks:    make([]*disk, 0, len(disks)),
+		index:    make(map[string]int),
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		failures: make(chan *Failure, len(disks)),
+		done:     make(chan struct{}),
+	}
+
+	for i, dk := range disks {
+		d.disks = append(d.disks, &disk{Disk: dk})
+
+		res, err := dk.Datastore.Query(dsq.Query{KeysOnly: true})
+		if err != nil {
+			return nil, err
+		}
+
+		for r := range res.Next() {
+			if r.Error != nil {
+				res.Close()
+				return nil, r.Error
+			}
+			if j, ok := d.index[r.Key]; ok {
+				log.Warningf("key %s is on both %s and %s", r.Key, disks[j].Path, dk.Path)
+				continue
+			}
+			d.index[r.Key] = i
+			d.disks[i].keys++
+		}
+		res.Close()
+	}
+
+	for _, dk := range d.disks {
+		d.refresh(dk)
+	}
+
+	openedLk.Lock()
+	opened[d] = struct{}{}
+	openedLk.Unlock()
+
+	go d.checkLoop()
+
+	return d, nil
+}
+
+// baseKey returns key stored in disks; for key with fields, such as
+// /blockID/begin/count, it is /blockID
+func baseKey(key ds.Key) string {
+	l := key.List()
+	if len(l) == 0 {
+		return key.String()
+	}
+	return "/" + l[0]
+}
+
+// lookup returns the disk which has key
+func (d *Datastore) lookup(key ds.Key) (int, *disk, bool) {
+	d.lk.RLock()
+	defer d.lk.RUnlock()
+
+	i, ok := d.index[baseKey(key)]
+	if !ok || d.disks[i].failed {
+		return 0, nil, false
+	}
+	return i, d.disks[i], true
+}
+
+// place chooses a disk for size bytes weighted by free capacity of disks
+func (d *Datastore) place(size int) (int, error) {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+
+	var total uint64
+	for _, dk := range d.disks {
+		if dk.free() > uint64(size) {
+			total += dk.free()
+		}
+	}
+	if total == 0 {
+		return 0, ErrNoSpace
+	}
+
+	w := uint64(d.rnd.Int63n(int64(total)))
+	for i, dk := range d.disks {
+		if dk.free() <= uint64(size) {
+			continue
+		}
+		if w < dk.free() {
+			return i, nil
+		}
+		w -= dk.free()
+	}
+	return 0, ErrNoSpace
+}
+
+// record counts io errors of disk i, and fails it after too many errors
+func (d *Datastore) record(i int, err error) error {
+	if err == ds.ErrNotFound || err == ds.ErrRangeUnsupported {
+		return err
+	}
+
+	d.lk.Lock()
+	dk := d.disks[i]
+	if err == nil {
+		dk.errs = 0
+		d.lk.Unlock()
+		return nil
+	}
+	dk.errs++
+	fail := dk.errs >= maxDiskErrors
+	d.lk.Unlock()
+
+	log.Warningf("disk %s has error: %s", dk.Path, err)
+	if fail {
+		d.fail(i, err)
+	}
+	return err
+}
+
+// fail marks disk i failed and reports its keys
+func (d *Datastore) fail(i int, err error) {
+	d.lk.Lock()
+	dk := d.disks[i]
+	if dk.failed {
+		d.lk.Unlock()
+		return
+	}
+	dk.failed = true
+	dk.err = err
+
+	lost := make([]ds.Key, 0, dk.keys)
+	for k, j := range d.index {
+		if j == i {
+			lost = append(lost, ds.RawKey(k))
+			delete(d.index, k)
+		}
+	}
+	dk.keys = 0
+	d.lk.Unlock()
+
+	log.Errorf("disk %s is failed: %s, %d keys are lost", dk.Path, err, len(lost))
+
+	// each disk fails once, so it never blocks
+	d.failures <- &Failure{
+		Path: dk.Path,
+		Err:  err,
+		Keys: lost,
+	}
+}
+
+// Failures returns failures of disks
+func (d *Datastore) Failures() <-chan *Failure {
+	return d.failures
+}
+
+// sizeOf returns size of value of key on disk i, 0 if it is unknown
+func (d *Datastore) sizeOf(i int, key ds.Key) uint64 {
+	size, err := d.disks[i].Datastore.GetSize(key)
+	if err != nil || size < 0 {
+		return 0
+	}
+	return uint64(size)
+}
+
+// resize changes bytes used by a value on the disk from old to size
+func (dk *disk) resize(old, size uint64) {
+	if dk.used < old {
+		dk.used = 0
+	} else {
+		dk.used -= old
+	}
+	dk.used += size
+}
+
+func (d *Datastore) Put(key ds.Key, value []byte) error {
+	var old uint64
+	i, _, ok := d.lookup(key)
+	if ok {
+		// value is overwritten
+		old = d.sizeOf(i, key)
+	} else {
+		var err error
+		i, err = d.place(len(value))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := d.record(i, d.disks[i].Datastore.Put(key, value))
+	if err != nil {
+		return err
+	}
+
+	k := baseKey(key)
+	d.lk.Lock()
+	if _, has := d.index[k]; !has {
+		d.index[k] = i
+		d.disks[i].keys++
+	}
+	d.disks[i].resize(old, uint64(len(value)))
+	d.lk.Unlock()
+	return nil
+}
+
+func (d *Datastore) Append(key ds.Key, value []byte, begin, length int) error {
+	i, _, ok := d.lookup(key)
+	if !ok {
+		return ds.ErrNotFound
+	}
+
+	err := d.record(i, d.disks[i].Datastore.Append(key, value, begin, length))
+	if err != nil {
+		return err
+	}
+
+	d.lk.Lock()
+	d.disks[i].used += uint64(len(value))
+	d.lk.Unlock()
+	return nil
+}
+
+func (d *Datastore) Get(key ds.Key) ([]byte, error) {
+	i, dk, ok := d.lookup(key)
+	if !ok {
+		return nil, ds.ErrNotFound
+	}
+
+	val, err := dk.Datastore.Get(key)
+	return val, d.record(i, err)
+}
+
+func (d *Datastore) Has(key ds.Key) (bool, error) {
+	_, _, ok := d.lookup(key)
+	return ok, nil
+}
+
+func (d *Datastore) GetSize(key ds.Key) (int, error) {
+	i, dk, ok := d.lookup(key)
+	if !ok {
+		return -1, ds.ErrNotFound
+	}
+
+	size, err := dk.Datastore.GetSize(key)
+	return size, d.record(i, err)
+}
+
+func (d *Datastore) GetRange(key ds.Key, begin, count int) ([]byte, error) {
+	i, dk, ok := d.lookup(key)
+	if !ok {
+		return nil, ds.ErrNotFound
+	}
+
+	val, err := dk.Datastore.GetRange(key, begin, count)
+	return val, d.record(i, err)
+}
+
+func (d *Datastore) Delete(key ds.Key) error {
+	i, dk, ok := d.lookup(key)
+	if !ok {
+		return ds.ErrNotFound
+	}
+
+	old := d.sizeOf(i, key)
+	err := d.record(i, dk.Datastore.Delete(key))
+	if err != nil && err != ds.ErrNotFound {
+		return err
+	}
+
+	k := baseKey(key)
+	d.lk.Lock()
+	if _, has := d.index[k]; has {
+		delete(d.index, k)
+		d.disks[i].keys--
+	}
+	if err == nil {
+		d.disks[i].resize(old, 0)
+	}
+	d.lk.Unlock()
+	return err
+}
+
+// Query lists keys in the index, values are read from disks
+func (d *Datastore) Query(q dsq.Query) (dsq.Results, error) {
+	d.lk.RLock()
+	keys := make([]string, 0, len(d.index))
+	for k := range d.index {
+		keys = append(keys, k)
+	}
+	d.lk.RUnlock()
+	sort.Strings(keys)
+
+	pos := 0
+	r := dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			for pos < len(keys) {
+				k := keys[pos]
+				pos++
+				if q.KeysOnly {
+					return dsq.Result{Entry: dsq.Entry{Key: k}}, true
+				}
+
+				val, err := d.Get(ds.RawKey(k))
+				switch err {
+				case nil:
+					return dsq.Result{Entry: dsq.Entry{Key: k, Value: val, Size: len(val)}}, true
+				case ds.ErrNotFound:
+					// deleted or lost after listing
+				default:
+					return dsq.Result{Error: err}, true
+				}
+			}
+			return dsq.Result{}, false
+		},
+	})
+	return dsq.NaiveQueryApply(q, r), nil
+}
+
+func (d *Datastore) Sync(prefix ds.Key) error {
+	for i, dk := range d.disks {
+		if dk.failed {
+			continue
+		}
+		err := d.record(i, dk.Datastore.Sync(prefix))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiskUsage returns bytes used on healthy disks
+func (d *Datastore) DiskUsage() (uint64, error) {
+	d.lk.RLock()
+	defer d.lk.RUnlock()
+
+	var du uint64
+	for _, dk := range d.disks {
+		if !dk.failed {
+			du += dk.used
+		}
+	}
+	return du, nil
+}
+
+// Capacity returns bytes which can be used on healthy disks
+func (d *Datastore) Capacity() uint64 {
+	d.lk.RLock()
+	defer d.lk.RUnlock()
+
+	var c uint64
+	for _, dk := range d.disks {
+		if !dk.failed {
+			c += dk.Capacity
+		}
+	}
+	return c
+}
+
+// Stat returns status of disks
+func (d *Datastore) Stat() []DiskStat {
+	d.lk.RLock()
+	defer d.lk.RUnlock()
+
+	res := make([]DiskStat, 0, len(d.disks))
+	for _, dk := range d.disks {
+		st := DiskStat{
+			Path:     dk.Path,
+			Capacity: dk.Capacity,
+			Used:     dk.used,
+			Free:     dk.free(),
+			Keys:     dk.keys,
+			Failed:   dk.failed,
+		}
+		if dk.err != nil {
+			st.Error = dk.err.Error()
+		}
+		res = append(res, st)
+	}
+	return res
+}
+
+// refresh updates usage of disk, disk is failed if it cannot be read
+func (d *Datastore) refresh(dk *disk) error {
+	st, err := utils.DiskStatus(dk.Path)
+	if err != nil {
+		return err
+	}
+
+	used, err := ds.DiskUsage(dk.Datastore)
+	if err != nil {
+		return err
+	}
+
+	d.lk.Lock()
+	if dk.Capacity == 0 {
+		dk.Capacity = st.Total
+	}
+	dk.fsFree = st.Free
+	dk.used = used
+	d.lk.Unlock()
+	return nil
+}
+
+// Check checks all healthy disks are readable and writable; failed disks
+// are reported by Failures
+func (d *Datastore) Check() error {
+	var res error
+	for i, dk := range d.disks {
+		d.lk.RLock()
+		failed := dk.failed
+		d.lk.RUnlock()
+		if failed {
+			continue
+		}
+
+		err := d.refresh(dk)
+		if err == nil {
+			p := filepath.Join(dk.Path, checkFile)
+			err = ioutil.WriteFile(p, []byte(p), 0644)
+			if err == nil {
+				err = os.Remove(p)
+			}
+		}
+		if err != nil {
+			d.fail(i, err)
+			res = err
+		}
+	}
+	return res
+}
+
+func (d *Datastore) checkLoop() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+			d.Check()
+		}
+	}
+}
+
+func (d *Datastore) Batch() (ds.Batch, error) {
+	return ds.NewBasicBatch(d), nil
+}
+
+func (d *Datastore) Close() error {
+	var res error
+	d.closeOnce.Do(func() {
+		close(d.done)
+
+		openedLk.Lock()
+		delete(opened, d)
+		openedLk.Unlock()
+
+		for _, dk := range d.disks {
+			err := dk.Datastore.Close()
+			if err != nil {
+				res = err
+			}
+		}
+	})
+	return res
+}
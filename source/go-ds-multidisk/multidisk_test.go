@@ -0,0 +1,210 @@
+package multidisk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	dsq "github.com/memoio/go-mefs/source/go-datastore/query"
+)
+
+const testCapacity = 1024 * 1024
+
+// newDisks returns disks backed by map datastores in temporary dirs,
+// and a function to call on exit.
+func newDisks(t *testing.T, capacities ...uint64) ([]Disk, func()) {
+	var disks []Disk
+	for _, c := range capacities {
+		path, err := ioutil.TempDir("", "testing_multidisk_")
+		if err != nil {
+			t.Fatal(err)
+		}
+		disks = append(disks, Disk{
+			Path:      path,
+			Capacity:  c,
+			Datastore: ds.NewMapDatastore(),
+		})
+	}
+	return disks, func() {
+		for _, dk := range disks {
+			os.RemoveAll(dk.Path)
+		}
+	}
+}
+
+func putKeys(t *testing.T, d *Datastore, n int) []ds.Key {
+	var keys []ds.Key
+	for i := 0; i < n; i++ {
+		k := ds.NewKey(fmt.Sprintf("key%d", i))
+		if err := d.Put(k, []byte(k.String())); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestPutGet(t *testing.T) {
+	disks, cleanup := newDisks(t, testCapacity, testCapacity)
+	defer cleanup()
+
+	d, err := New(disks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := putKeys(t, d, 100)
+
+	for _, k := range keys {
+		val, err := d.Get(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(val) != k.String() {
+			t.Fatalf("%s has wrong value %s", k, val)
+		}
+	}
+
+	if _, err := d.Get(ds.NewKey("nokey")); err != ds.ErrNotFound {
+		t.Fatal("expected ErrNotFound, got:", err)
+	}
+
+	st := d.Stat()
+	if st[0].Keys+st[1].Keys != len(keys) {
+		t.Fatal("wrong number of keys on disks:", st[0].Keys, st[1].Keys)
+	}
+
+	if err := d.Delete(keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	if has, _ := d.Has(keys[0]); has {
+		t.Fatal("deleted key should not exist")
+	}
+
+	// index is built again from disks
+	nd, err := New(disks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := nd.Query(dsq.Query{KeysOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := res.Rest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(keys)-1 {
+		t.Fatal("wrong number of keys after reopen:", len(all))
+	}
+}
+
+func TestPlacement(t *testing.T) {
+	disks, cleanup := newDisks(t, 16, testCapacity)
+	defer cleanup()
+
+	d, err := New(disks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	putKeys(t, d, 100)
+
+	st := d.Stat()
+	if st[0].Keys != 0 || st[1].Keys != 100 {
+		t.Fatal("keys should be put on disk with space:", st[0].Keys, st[1].Keys)
+	}
+
+	err = d.Put(ds.NewKey("large"), make([]byte, 2*testCapacity))
+	if err != ErrNoSpace {
+		t.Fatal("expected ErrNoSpace, got:", err)
+	}
+}
+
+func TestOverwrite(t *testing.T) {
+	disks, cleanup := newDisks(t, testCapacity)
+	defer cleanup()
+
+	d, err := New(disks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := d.DiskUsage()
+
+	k := ds.NewKey("key")
+	for _, size := range []int{1000, 3000, 500} {
+		if err := d.Put(k, make([]byte, size)); err != nil {
+			t.Fatal(err)
+		}
+
+		// usage counts the last value only
+		du, _ := d.DiskUsage()
+		if du != base+uint64(size) {
+			t.Fatalf("usage is %d after putting %d bytes, want %d", du, size, base+uint64(size))
+		}
+	}
+
+	if st := d.Stat(); st[0].Keys != 1 {
+		t.Fatal("overwritten key is counted again:", st[0].Keys)
+	}
+
+	if err := d.Delete(k); err != nil {
+		t.Fatal(err)
+	}
+	if du, _ := d.DiskUsage(); du != base {
+		t.Fatalf("usage is %d after deleting, want %d", du, base)
+	}
+}
+
+func TestFailure(t *testing.T) {
+	disks, cleanup := newDisks(t, testCapacity, testCapacity)
+	defer cleanup()
+
+	d, err := New(disks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := putKeys(t, d, 100)
+
+	var lost []ds.Key
+	for _, k := range keys {
+		if has, _ := disks[0].Datastore.Has(k); has {
+			lost = append(lost, k)
+		}
+	}
+
+	os.RemoveAll(disks[0].Path)
+	if err := d.Check(); err == nil {
+		t.Fatal("removed disk should fail")
+	}
+
+	f := <-d.Failures()
+	if f.Path != disks[0].Path || len(f.Keys) != len(lost) {
+		t.Fatal("wrong failure:", f.Path, len(f.Keys), len(lost))
+	}
+
+	for _, k := range keys {
+		_, err := d.Get(k)
+		has, _ := disks[0].Datastore.Has(k)
+		if has && err != ds.ErrNotFound {
+			t.Fatal("lost key should not be found, got:", err)
+		}
+		if !has && err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	st := d.Stat()
+	if !st[0].Failed || st[1].Failed {
+		t.Fatal("wrong failed disks")
+	}
+	if d.Capacity() != testCapacity {
+		t.Fatal("failed disk should not be counted")
+	}
+
+	// new keys are put on healthy disk
+	putKeys(t, d, 10)
+	if d.Stat()[0].Keys != 0 {
+		t.Fatal("keys should not be put on failed disk")
+	}
+}
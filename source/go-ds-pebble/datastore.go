@@ -0,0 +1,239 @@
+// Package pebbleds is a Datastore implementation backed by pebble, a LSM
+// key-value store which has better write throughput than leveldb for large
+// number of small keys.
+package pebbleds
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	dataformat "github.com/memoio/go-mefs/data-format"
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	dsq "github.com/memoio/go-mefs/source/go-datastore/query"
+)
+
+type Datastore struct {
+	DB         *pebble.DB
+	path       string
+	syncWrites bool
+}
+
+var _ ds.Datastore = (*Datastore)(nil)
+var _ ds.Batching = (*Datastore)(nil)
+
+// Options is an alias of pebble.Options which might be extended
+// in the future.
+type Options pebble.Options
+
+// NewDatastore returns a new datastore backed by pebble
+//
+// for path == "", an in memory bachend will be chosen
+func NewDatastore(path string, opts *Options) (*Datastore, error) {
+	var nopts pebble.Options
+	if opts != nil {
+		nopts = pebble.Options(*opts)
+	}
+
+	if path == "" {
+		nopts.FS = vfs.NewMem()
+	}
+
+	db, err := pebble.Open(path, &nopts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Datastore{
+		DB:         db,
+		path:       path,
+		syncWrites: true,
+	}, nil
+}
+
+func (d *Datastore) writeOptions() *pebble.WriteOptions {
+	if d.syncWrites {
+		return pebble.Sync
+	}
+	return pebble.NoSync
+}
+
+func (d *Datastore) Put(key ds.Key, value []byte) (err error) {
+	return d.DB.Set(key.Bytes(), value, d.writeOptions())
+}
+
+func (d *Datastore) Append(key ds.Key, value []byte, begin, length int) (err error) {
+	return nil
+}
+
+func (d *Datastore) Sync(prefix ds.Key) error {
+	return nil
+}
+
+func (d *Datastore) Get(key ds.Key) (value []byte, err error) {
+	val, err := d.DB.Get(key.Bytes())
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return nil, ds.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// val is only valid until next operation
+	buf := make([]byte, len(val))
+	copy(buf, val)
+	return buf, nil
+}
+
+func (d *Datastore) Has(key ds.Key) (exists bool, err error) {
+	return ds.GetBackedHas(d, key)
+}
+
+func (d *Datastore) GetSize(key ds.Key) (size int, err error) {
+	return ds.GetBackedSize(d, key)
+}
+
+func (d *Datastore) GetRange(key ds.Key, begin, count int) (value []byte, err error) {
+	val, err := d.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return dataformat.GetFields(val, begin, count)
+}
+
+func (d *Datastore) Delete(key ds.Key) (err error) {
+	return d.DB.Delete(key.Bytes(), d.writeOptions())
+}
+
+// bytesPrefix returns iterator options of keys with prefix
+func bytesPrefix(prefix []byte) *pebble.IterOptions {
+	var limit []byte
+	for i := len(prefix) - 1; i >= 0; i-- {
+		c := prefix[i]
+		if c < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			break
+		}
+	}
+	return &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: limit,
+	}
+}
+
+func (d *Datastore) Query(q dsq.Query) (dsq.Results, error) {
+	var iopts *pebble.IterOptions
+
+	// make a copy of the query for the fallback naive query implementation.
+	// don't modify the original so res.Query() returns the correct results.
+	qNaive := q
+	if q.Prefix != "" {
+		iopts = bytesPrefix([]byte(q.Prefix))
+		qNaive.Prefix = ""
+	}
+	i := d.DB.NewIter(iopts)
+	closed := false
+	var next func() bool
+	next = func() bool {
+		next = i.Next
+		return i.First()
+	}
+	if len(q.Orders) > 0 {
+		switch q.Orders[0].(type) {
+		case dsq.OrderByKey, *dsq.OrderByKey:
+			qNaive.Orders = nil
+		case dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+			next = func() bool {
+				next = i.Prev
+				return i.Last()
+			}
+			qNaive.Orders = nil
+		default:
+		}
+	}
+	r := dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			if !next() {
+				return dsq.Result{}, false
+			}
+			k := string(i.Key())
+			e := dsq.Entry{Key: k, Size: len(i.Value())}
+
+			if !q.KeysOnly {
+				buf := make([]byte, len(i.Value()))
+				copy(buf, i.Value())
+				e.Value = buf
+			}
+			return dsq.Result{Entry: e}, true
+		},
+		Close: func() error {
+			// results may be closed more than once
+			if closed {
+				return nil
+			}
+			closed = true
+			return i.Close()
+		},
+	})
+	return dsq.NaiveQueryApply(qNaive, r), nil
+}
+
+// DiskUsage returns the current disk size used by this pebble.
+// For in-mem datastores, it will return 0.
+func (d *Datastore) DiskUsage() (uint64, error) {
+	if d.path == "" { // in-mem
+		return 0, nil
+	}
+
+	var du uint64
+
+	err := filepath.Walk(d.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		du += uint64(info.Size())
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return du, nil
+}
+
+// Pebble needs to be closed.
+func (d *Datastore) Close() (err error) {
+	return d.DB.Close()
+}
+
+type pebbleBatch struct {
+	b  *pebble.Batch
+	wo *pebble.WriteOptions
+}
+
+func (d *Datastore) Batch() (ds.Batch, error) {
+	return &pebbleBatch{
+		b:  d.DB.NewBatch(),
+		wo: d.writeOptions(),
+	}, nil
+}
+
+func (b *pebbleBatch) Put(key ds.Key, value []byte) error {
+	return b.b.Set(key.Bytes(), value, nil)
+}
+
+func (b *pebbleBatch) Append(key ds.Key, value []byte, begin, length int) error {
+	return nil
+}
+
+func (b *pebbleBatch) Commit() error {
+	return b.b.Commit(b.wo)
+}
+
+func (b *pebbleBatch) Delete(key ds.Key) error {
+	return b.b.Delete(key.Bytes(), nil)
+}
@@ -0,0 +1,284 @@
+package pebbleds
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	dsq "github.com/memoio/go-mefs/source/go-datastore/query"
+	dstest "github.com/memoio/go-mefs/source/go-datastore/test"
+)
+
+var testcases = map[string]string{
+	"/a":     "a",
+	"/a/b":   "ab",
+	"/a/b/c": "abc",
+	"/a/b/d": "a/b/d",
+	"/a/c":   "ac",
+	"/a/d":   "ad",
+	"/e":     "e",
+	"/f":     "f",
+}
+
+// returns datastore, and a function to call on exit.
+// (this garbage collects). So:
+//
+//	d, close := newDS(t)
+//	defer close()
+func newDS(t *testing.T) (*Datastore, func()) {
+	path, err := ioutil.TempDir("", "testing_pebble_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := NewDatastore(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, func() {
+		os.RemoveAll(path)
+		d.Close()
+	}
+}
+
+// newDSMem returns an in-memory datastore.
+func newDSMem(t *testing.T) *Datastore {
+	d, err := NewDatastore("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func addTestCases(t *testing.T, d *Datastore, testcases map[string]string) {
+	for k, v := range testcases {
+		dsk := ds.NewKey(k)
+		if err := d.Put(dsk, []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for k, v := range testcases {
+		dsk := ds.NewKey(k)
+		v2, err := d.Get(dsk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(v2) != v {
+			t.Errorf("%s values differ: %s != %s", k, v, v2)
+		}
+	}
+
+}
+
+func testQuery(t *testing.T, d *Datastore) {
+	addTestCases(t, d, testcases)
+
+	rs, err := d.Query(dsq.Query{Prefix: "/a/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectMatches(t, []string{
+		"/a/b",
+		"/a/b/c",
+		"/a/b/d",
+		"/a/c",
+		"/a/d",
+	}, rs)
+
+	// test offset and limit
+
+	rs, err = d.Query(dsq.Query{Prefix: "/a/", Offset: 2, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectMatches(t, []string{
+		"/a/b/d",
+		"/a/c",
+	}, rs)
+
+	// test order
+
+	rs, err = d.Query(dsq.Query{Orders: []dsq.Order{dsq.OrderByKey{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([]string, 0, len(testcases))
+	for k := range testcases {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	expectOrderedMatches(t, keys, rs)
+
+	rs, err = d.Query(dsq.Query{Orders: []dsq.Order{dsq.OrderByKeyDescending{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// reverse
+	for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+		keys[i], keys[j] = keys[j], keys[i]
+	}
+
+	expectOrderedMatches(t, keys, rs)
+}
+
+func TestQuery(t *testing.T) {
+	d, close := newDS(t)
+	defer close()
+	testQuery(t, d)
+}
+func TestQueryMem(t *testing.T) {
+	d := newDSMem(t)
+	testQuery(t, d)
+}
+
+func TestQueryRespectsProcess(t *testing.T) {
+	d, close := newDS(t)
+	defer close()
+	addTestCases(t, d, testcases)
+}
+
+func TestQueryRespectsProcessMem(t *testing.T) {
+	d := newDSMem(t)
+	addTestCases(t, d, testcases)
+}
+
+func expectMatches(t *testing.T, expect []string, actualR dsq.Results) {
+	t.Helper()
+	actual, err := actualR.Rest()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(actual) != len(expect) {
+		t.Error("not enough", expect, actual)
+	}
+	for _, k := range expect {
+		found := false
+		for _, e := range actual {
+			if e.Key == k {
+				found = true
+			}
+		}
+		if !found {
+			t.Error(k, "not found")
+		}
+	}
+}
+
+func expectOrderedMatches(t *testing.T, expect []string, actualR dsq.Results) {
+	t.Helper()
+	actual, err := actualR.Rest()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(actual) != len(expect) {
+		t.Error("not enough", expect, actual)
+	}
+	for i := range expect {
+		if expect[i] != actual[i].Key {
+			t.Errorf("expected %q, got %q", expect[i], actual[i].Key)
+		}
+	}
+}
+
+func testBatching(t *testing.T, d *Datastore) {
+	b, err := d.Batch()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range testcases {
+		err := b.Put(ds.NewKey(k), []byte(v))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = b.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range testcases {
+		val, err := d.Get(ds.NewKey(k))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v != string(val) {
+			t.Fatal("got wrong data!")
+		}
+	}
+}
+
+func TestBatching(t *testing.T) {
+	d, done := newDS(t)
+	defer done()
+	testBatching(t, d)
+}
+
+func TestBatchingMem(t *testing.T) {
+	d := newDSMem(t)
+	testBatching(t, d)
+}
+
+func TestDiskUsage(t *testing.T) {
+	d, done := newDS(t)
+	addTestCases(t, d, testcases)
+	du, err := d.DiskUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if du == 0 {
+		t.Fatal("expected some disk usage")
+	}
+
+	k := ds.NewKey("more")
+	err = d.Put(k, []byte("value"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wal is preallocated, flush to write a table
+	err = d.DB.Flush()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	du2, err := d.DiskUsage()
+	if du2 <= du {
+		t.Fatal("size should have increased")
+	}
+
+	done()
+
+	// This should fail
+	_, err = d.DiskUsage()
+	if err == nil {
+		t.Fatal("DiskUsage should fail when we cannot walk path")
+	}
+}
+
+func TestDiskUsageInMem(t *testing.T) {
+	d := newDSMem(t)
+	du, _ := d.DiskUsage()
+	if du != 0 {
+		t.Fatal("inmem dbs have 0 disk usage")
+	}
+}
+
+func TestSuite(t *testing.T) {
+	d := newDSMem(t)
+	defer d.Close()
+	dstest.SubtestAll(t, d)
+}
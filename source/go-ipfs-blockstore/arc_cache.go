@@ -124,6 +124,18 @@ func (b *arccache) Get(k cid.Cid) (blocks.Block, error) {
 	return bl, err
 }
 
+func (b *arccache) GetRange(k cid.Cid, begin, count int) (blocks.Block, error) {
+	if has, _, ok := b.hasCached(k); ok && !has {
+		return nil, ErrNotFound
+	}
+
+	bl, err := b.blockstore.GetRange(k, begin, count)
+	if bl == nil && err == ErrNotFound {
+		b.cacheHave(k, false)
+	}
+	return bl, err
+}
+
 func (b *arccache) Put(bl blocks.Block) error {
 	if has, _, ok := b.hasCached(bl.Cid()); ok && has {
 		return nil
@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 
 	logging "github.com/ipfs/go-log"
+	dataformat "github.com/memoio/go-mefs/data-format"
 	blocks "github.com/memoio/go-mefs/source/go-block-format"
 	cid "github.com/memoio/go-mefs/source/go-cid"
 	ds "github.com/memoio/go-mefs/source/go-datastore"
@@ -39,6 +40,10 @@ type Blockstore interface {
 	// GetSize returns the CIDs mapped BlockSize
 	GetSize(cid.Cid) (int, error)
 
+	// GetRange returns count fields from field begin of the block, with
+	// prefix whose start is begin
+	GetRange(c cid.Cid, begin, count int) (blocks.Block, error)
+
 	// Put puts a given block to the underlying datastore
 	Put(blocks.Block) error
 
@@ -145,6 +150,30 @@ func (bs *blockstore) Get(k cid.Cid) (blocks.Block, error) {
 	return blocks.NewBlockWithCid(bdata, k)
 }
 
+func (bs *blockstore) GetRange(k cid.Cid, begin, count int) (blocks.Block, error) {
+	if !k.Defined() {
+		log.Error("undefined cid in blockstore")
+		return nil, ErrNotFound
+	}
+
+	dk := dshelp.CidToDsKey(k)
+	bdata, err := bs.datastore.GetRange(dk, begin, count)
+	if err == ds.ErrRangeUnsupported {
+		// read whole block and cut fields out
+		bdata, err = bs.datastore.Get(dk)
+		if err == nil {
+			bdata, err = dataformat.GetFields(bdata, begin, count)
+		}
+	}
+	if err == ds.ErrNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return blocks.NewBlockWithCid(bdata, k)
+}
+
 func (bs *blockstore) Put(block blocks.Block) error {
 	k := dshelp.CidToDsKey(block.Cid())
 
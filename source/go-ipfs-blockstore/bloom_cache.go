@@ -159,6 +159,14 @@ func (b *bloomcache) Get(k cid.Cid) (blocks.Block, error) {
 	return b.blockstore.Get(k)
 }
 
+func (b *bloomcache) GetRange(k cid.Cid, begin, count int) (blocks.Block, error) {
+	if has, ok := b.hasCached(k); ok && !has {
+		return nil, ErrNotFound
+	}
+
+	return b.blockstore.GetRange(k, begin, count)
+}
+
 func (b *bloomcache) Put(bl blocks.Block) error {
 	// See comment in PutMany
 	err := b.blockstore.Put(bl)
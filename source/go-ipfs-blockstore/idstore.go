@@ -61,6 +61,14 @@ func (b *idstore) Get(k cid.Cid) (blocks.Block, error) {
 	return b.bs.Get(k)
 }
 
+func (b *idstore) GetRange(k cid.Cid, begin, count int) (blocks.Block, error) {
+	isId, bdata := extractContents(k)
+	if isId {
+		return blocks.NewBlockWithCid(bdata, k)
+	}
+	return b.bs.GetRange(k, begin, count)
+}
+
 func (b *idstore) Put(bl blocks.Block) error {
 	isId, _ := extractContents(bl.Cid())
 	if isId {
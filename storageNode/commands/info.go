@@ -40,6 +40,11 @@ type pInfoOutput struct {
 	DownloadIncome  string
 	PostIncome       string
 	PostPreIncome    string
+	ScrubRound       int
+	ScrubProgress    string
+	ScrubLastFinish  string
+	ScrubCorrupt     int
+	ScrubRecent      []string
 }
 
 type StringList struct {
@@ -63,9 +68,11 @@ var InfoCmd = &cmds.Command{
 	},
 
 	Subcommands: map[string]*cmds.Command{
-		"self":  SelfCmd, //命令行操作写法示例
-		"users": userCmd,
-		"group": gpInfoCmd,
+		"self":       SelfCmd, //命令行操作写法示例
+		"users":      userCmd,
+		"group":      gpInfoCmd,
+		"channels":   chanIncomeCmd,
+		"st_dispute": stDisputeCmd,
 	},
 }
 
@@ -116,6 +123,7 @@ var SelfCmd = &cmds.Command{
 		reachable := false
 		stime := time.Unix(0, 0)
 		uTime := int64(0)
+		var scrub provider.ScrubInfo
 		providerIns, ok := node.Inst.(*provider.Info)
 		if !ok { //service is not ready, 从链上获取depositCapacity
 			providerItem, err := role.GetProviderInfo(node.Identity.Pretty(), node.Identity.Pretty())
@@ -157,6 +165,7 @@ var SelfCmd = &cmds.Command{
 			ready = providerIns.Online()
 			stime = providerIns.StartTime
 			uTime = time.Now().Unix() - stime.Unix()
+			scrub = providerIns.GetScrubInfo()
 		}
 
 		offerAddr, err := address.GetAddressFromID(oItem.OfferID)
@@ -169,6 +178,12 @@ var SelfCmd = &cmds.Command{
 			return err
 		}
 
+		// blocks may be stored on multiple disks
+		localFree := lsinfo.Free
+		if ok {
+			localFree = providerIns.LocalStorageFree
+		}
+
 		output := &pInfoOutput{
 			Wallet:          localAddr.String(),
 			StartTime:       stime.In(time.Local).Format(utils.SHOWTIME),
@@ -179,7 +194,7 @@ var SelfCmd = &cmds.Command{
 			PledgeBytes:     utils.FormatBytes(int64(depositCapacity)),
 			UsedBytes:       utils.FormatBytes(int64(usedCapacity)),
 			PostBytes:        utils.FormatBytes(int64(postCapacity)),
-			LocalFreeBytes:  utils.FormatBytes(int64(lsinfo.Free)),
+			LocalFreeBytes:  utils.FormatBytes(int64(localFree)),
 			OfferAddress:    offerAddr.String(),
 			OfferDuration:   utils.FormatSecond(oItem.Duration),
 			OfferStartTime:  time.Unix(oItem.CreateDate, 0).In(time.Local).Format(utils.SHOWTIME),
@@ -191,6 +206,13 @@ var SelfCmd = &cmds.Command{
 			StorageIncome:   utils.FormatWei(si),
 			PostIncome:       utils.FormatWei(pi),
 			PostPreIncome:    utils.FormatWei(prei),
+			ScrubRound:       scrub.Round,
+			ScrubProgress:    fmt.Sprintf("%d/%d", scrub.Scanned, scrub.Total),
+			ScrubCorrupt:     scrub.Corrupt,
+			ScrubRecent:      scrub.Recent,
+		}
+		if !scrub.FinishTime.IsZero() {
+			output.ScrubLastFinish = scrub.FinishTime.In(time.Local).Format(utils.SHOWTIME)
 		}
 		return cmds.EmitOnce(res, output)
 	},
@@ -292,3 +314,84 @@ var userCmd = &cmds.Command{
 		}),
 	},
 }
+
+var chanIncomeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline:          "show read income of channels per user",
+		ShortDescription: `show settled and unsettled read income of read-payment channels per user; channels are closed automatically before timeout`,
+	},
+
+	Arguments: []cmds.Argument{},
+	Options:   []cmds.Option{},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		n, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		providerIns, ok := n.Inst.(*provider.Info)
+		if !ok {
+			return role.ErrServiceNotReady
+		}
+
+		list := &StringList{
+			ChildLists: providerIns.ShowChannelIncome(),
+		}
+
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, list *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", list)
+			return err
+		}),
+	},
+}
+
+var stDisputeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "check spacetime pay of group and dispute it",
+		ShortDescription: `
+'mefs-provider info st_dispute' checks spacetime pay proposed by keeper of group against
+challenge logs signed by keepers; if logs show more spacetime than the pay, a counter claim
+is sent to keepers, who re-verify it before signing the pay.
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("uid", true, false, "The user's id"),
+		cmds.StringArg("qid", true, false, "The user's query id"),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+
+		providerIns, ok := node.Inst.(*provider.Info)
+		if !ok || providerIns == nil {
+			return role.ErrServiceNotReady
+		}
+
+		output, err := providerIns.DisputeSTPay(req.Context, req.Arguments[0], req.Arguments[1])
+		if err != nil {
+			return err
+		}
+
+		list := &StringList{
+			ChildLists: output,
+		}
+
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, list *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", list)
+			return err
+		}),
+	},
+}
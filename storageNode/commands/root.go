@@ -48,6 +48,7 @@ TOOL COMMANDS
   test          Some test functions
   list          List keepers and providers
   sys           Print system diagnostic information
+  billing       Report billing of local accounts
   commands      List all available commands
 
 Use 'mefs-provider <command> --help' to learn more about each command.
@@ -101,6 +102,7 @@ var rootSubcommands = map[string]*cmds.Command{
 	"test":      newcmd.TestCmd,
 	"list":      newcmd.ListCmd,
 	"sys":       newcmd.SysDiagCmd,
+	"billing":   newcmd.BillingCmd,
 	"quit":      QuitCmd,
 }
 
@@ -1,7 +1,9 @@
 package provider
 
 import (
+	"context"
 	"math/big"
+	"strconv"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
@@ -100,6 +102,21 @@ func (p *Info) handleGetBlock(km *metainfo.Key, metaValue, sig []byte, from stri
 	ctx := p.context
 
 	if gp.userID != gp.groupID {
+		ok, err := p.verifyReadToken(gp, sig, splitedNcid[0], from)
+		if err != nil {
+			utils.MLogger.Errorf("verify read token for block %s from %s failed: %s", splitedNcid[0], from, err)
+			return nil, err
+		}
+
+		if ok {
+			b, err := p.getLocalBlock(ctx, kms, splitedNcid[0])
+			if err != nil {
+				utils.MLogger.Errorf("get block %s from local fail: %s", splitedNcid[0], err)
+				return nil, err
+			}
+			return b, nil
+		}
+
 		res, chanGot, value, err := verifyChanSign(sig)
 		if err != nil {
 			utils.MLogger.Errorf("verify sig for block %s failed, err is : %s", splitedNcid[0], err)
@@ -115,6 +132,10 @@ func (p *Info) handleGetBlock(km *metainfo.Key, metaValue, sig []byte, from stri
 			}
 
 			cItem = gotItem.(*role.ChannelItem)
+			if p.isChanSettled(cItem) {
+				utils.MLogger.Errorf("read block %s on settled channel %s", splitedNcid[0], chanGot)
+				return nil, ErrChannelSettled
+			}
 		}
 
 		if value != nil {
@@ -126,14 +147,22 @@ func (p *Info) handleGetBlock(km *metainfo.Key, metaValue, sig []byte, from stri
 
 		if res {
 			utils.MLogger.Infof("try to get block %s form local", splitedNcid[0])
-			b, err := p.ds.GetBlock(ctx, kms, nil, "local")
+			b, err := p.getLocalBlock(ctx, kms, splitedNcid[0])
 			if err != nil {
 				utils.MLogger.Errorf("get block %s from local fail: %s", splitedNcid[0], err)
 				return nil, err
 			}
 
-			readLen := len(b.RawData())
+			readLen := len(b)
 			if value != nil {
+				// channel may be closed while reading
+				cs := p.getChanSettle(cItem)
+				cs.Lock()
+				defer cs.Unlock()
+				if cs.settled != nil {
+					return nil, ErrChannelSettled
+				}
+
 				ok := verifyChanValue(cItem.Value, value, readLen)
 				if !ok {
 					return nil, role.ErrWrongMoney
@@ -150,19 +179,37 @@ func (p *Info) handleGetBlock(km *metainfo.Key, metaValue, sig []byte, from stri
 				p.ds.PutKey(ctx, key.ToString(), sig, nil, "local")
 			}
 
-			return b.RawData(), nil
+			return b, nil
 		}
 		utils.MLogger.Warnf("sign verify is false for %s", splitedNcid[0])
 		return nil, role.ErrWrongSign
 	}
 
 	utils.MLogger.Infof("try to get block %s form local", splitedNcid[0])
-	b, err := p.ds.GetBlock(ctx, kms, nil, "local")
+	b, err := p.getLocalBlock(ctx, kms, splitedNcid[0])
 	if err != nil {
 		utils.MLogger.Errorf("get block %s from local fail: %s", splitedNcid[0], err)
 		return nil, err
 	}
 
+	return b, nil
+}
+
+// getLocalBlock gets fields of block in key from read cache or local,
+// key is blockID/"Block"/start/length
+func (p *Info) getLocalBlock(ctx context.Context, key, blockID string) ([]byte, error) {
+	rc := p.ds.ReadCache()
+	res, ok := rc.Get(blockID, key)
+	if ok {
+		return res, nil
+	}
+
+	b, err := p.ds.GetBlock(ctx, key, nil, "local")
+	if err != nil {
+		return nil, err
+	}
+
+	rc.Add(blockID, key, b.RawData())
 	return b.RawData(), nil
 }
 
@@ -175,7 +222,7 @@ func verifyChanSign(mes []byte) (bool, string, *big.Int, error) {
 		return false, "", nil, err
 	}
 
-	if cSign.GetChannelID() == "test" && string(cSign.GetValue()) == "123" {
+	if role.AllowTestSign && cSign.GetChannelID() == "test" && string(cSign.GetValue()) == "123" {
 		utils.MLogger.Debug("sign for test and repair")
 		return true, "", nil, nil
 	}
@@ -193,6 +240,49 @@ func verifyChanSign(mes []byte) (bool, string, *big.Int, error) {
 	return res, chanGot, valueGot, nil
 }
 
+// verifyReadToken verifies read token in sig, which is issued by keeper of
+// group, or by user for its own meta blocks; it returns false if sig has no token
+func (p *Info) verifyReadToken(gp *groupInfo, sig []byte, blockID, from string) (bool, error) {
+	cSign := &mpb.ChannelSign{}
+	err := proto.Unmarshal(sig, cSign)
+	if err != nil || cSign.GetToken() == nil {
+		return false, nil
+	}
+
+	rt := cSign.GetToken()
+	if rt.GetQueryID() != gp.groupID {
+		return false, role.ErrWrongSign
+	}
+
+	issuer := rt.GetIssuer()
+	if issuer == gp.userID {
+		if !isMetaBlock(blockID) {
+			return false, role.ErrWrongSign
+		}
+	} else if utils.CheckDup(gp.keepers, issuer) {
+		return false, role.ErrWrongSign
+	}
+
+	err = role.VerifyReadToken(rt, blockID, from)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isMetaBlock returns whether blockID belongs to superblock or bucket meta,
+// which are stored in bucket 0 and negative buckets
+func isMetaBlock(blockID string) bool {
+	bid, _, _, err := metainfo.GetIDsFromBlock(blockID)
+	if err != nil {
+		return false
+	}
+
+	bucketID, err := strconv.Atoi(bid)
+	return err == nil && bucketID <= 0
+}
+
 func verifyChanValue(oldValue, newValue *big.Int, readLen int) bool {
 	//verify value;： value ?= oldValue + 100
 	readPrice := big.NewInt(utils.READPRICE)
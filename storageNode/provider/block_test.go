@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/address"
+)
+
+func newTestAccount(t *testing.T) (string, string) {
+	sk, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localID, err := address.GetIDFromAddress(crypto.PubkeyToAddress(sk.PublicKey).Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return localID, hex.EncodeToString(crypto.FromECDSA(sk))
+}
+
+func TestVerifyReadToken(t *testing.T) {
+	utils.StartLogger()
+
+	userID, userSk := newTestAccount(t)
+	keeperID, keeperSk := newTestAccount(t)
+	otherID, otherSk := newTestAccount(t)
+	qid := "fs1"
+	reader := "reader"
+	gp := &groupInfo{userID: userID, groupID: qid, keepers: []string{keeperID}}
+	p := &Info{}
+
+	sbID := qid + "_0_0_1"
+	metaID := qid + "_-2_1_0"
+	dataID := qid + "_2_5_3"
+	bids := []string{sbID, metaID, dataID}
+
+	sig, err := role.BuildReadTokenMessage(qid, reader, userID, userSk, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// user reads its meta blocks itself
+	for _, bid := range []string{sbID, metaID} {
+		ok, err := p.verifyReadToken(gp, sig, bid, reader)
+		if !ok || err != nil {
+			t.Fatal("token of user for meta block ", bid, " is refused: ", err)
+		}
+	}
+
+	ok, err := p.verifyReadToken(gp, sig, dataID, reader)
+	if ok || err == nil {
+		t.Fatal("token of user for data block is accepted")
+	}
+
+	sig, err = role.BuildReadTokenMessage(qid, reader, keeperID, keeperSk, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err = p.verifyReadToken(gp, sig, dataID, reader)
+	if !ok || err != nil {
+		t.Fatal("token of keeper for data block is refused: ", err)
+	}
+
+	ok, err = p.verifyReadToken(gp, sig, dataID, "other")
+	if ok || err == nil {
+		t.Fatal("token for another reader is accepted")
+	}
+
+	sig, err = role.BuildReadTokenMessage(qid, reader, otherID, otherSk, bids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err = p.verifyReadToken(gp, sig, metaID, reader)
+	if ok || err == nil {
+		t.Fatal("token of node outside group is accepted")
+	}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/memoio/go-mefs/crypto/pdp"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// key: pid/"ChalBatch"/kid/chaltime
+// handleChallengeBatch answers challenges of many groups with proofs sharing one aggregated delta;
+// groups which cannot be proved are left out and are considered as no proof by keeper
+func (p *Info) handleChallengeBatch(km *metainfo.Key, metaValue []byte, from string) error {
+	utils.MLogger.Info("handle challenge batch: ", km.ToString(), " from: ", from)
+
+	ops := km.GetOptions()
+	if len(ops) < 2 {
+		return role.ErrWrongKey
+	}
+
+	if km.GetMainID() != p.localID || ops[0] != from {
+		return role.ErrWrongKey
+	}
+
+	cb := new(mpb.ChalBatch)
+	err := proto.Unmarshal(metaValue, cb)
+	if err != nil {
+		utils.MLogger.Error("unmarshal challenge batch failed: ", err)
+		return err
+	}
+
+	var crs []*mpb.ChalInfo
+	var proofs []*pdp.ProofV1
+	var vks []pdp.VerifyKey
+	var chals []pdp.Challenge
+	for _, cr := range cb.GetChals() {
+		if cr.GetProviderID() != p.localID || cr.GetPolicy() == role.ChalPolicyPost {
+			continue
+		}
+
+		gp := p.getGroupInfo(cr.GetUserID(), cr.GetQueryID(), true)
+		if gp == nil || !gp.status {
+			continue
+		}
+
+		blskey, err := p.getNewUserConfig(cr.GetUserID(), cr.GetQueryID())
+		if err != nil || blskey == nil || blskey.PublicKey() == nil {
+			utils.MLogger.Warnf("get user %s config failed: %s", cr.GetQueryID(), err)
+			continue
+		}
+
+		var pr pdp.Proof
+		var chal pdp.Challenge
+		switch cr.GetPolicy() {
+		case role.ChalPolicyRandom:
+			pr, chal, err = p.handleChallengeRandom(cr, blskey)
+		default:
+			pr, chal, err = p.handleChallenge(cr, blskey)
+		}
+		if err != nil {
+			utils.MLogger.Warnf("gen proof of %s in batch failed: %s", cr.GetQueryID(), err)
+			continue
+		}
+
+		pf, ok := pr.(*pdp.ProofV1)
+		if !ok {
+			continue
+		}
+
+		crs = append(crs, cr)
+		proofs = append(proofs, pf)
+		vks = append(vks, blskey.VerifyKey())
+		chals = append(chals, chal)
+	}
+
+	if len(crs) == 0 {
+		return role.ErrEmptyData
+	}
+
+	err = pdp.AggregateProofsV1(proofs)
+	if err != nil {
+		return err
+	}
+
+	// 在发送之前检查生成的proof, 所有配对一次计算
+	bv := pdp.NewBatchVerifierV1()
+	for i := range proofs {
+		vk, err := bv.Add(vks[i])
+		if err != nil {
+			return err
+		}
+		_, err = vk.VerifyProof(chals[i], proofs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	boo, err := bv.Result()
+	if err != nil {
+		return err
+	}
+
+	if !boo {
+		return role.ErrWrongState
+	}
+
+	for i, cr := range crs {
+		proof := pdp.ProofWithVersion{
+			Ver:   pdp.PDPV1,
+			Proof: proofs[i],
+		}
+		cr.BlsProof, err = proof.Serialize()
+		if err != nil {
+			return err
+		}
+	}
+
+	cb.Chals = crs
+	retValue, err := proto.Marshal(cb)
+	if err != nil {
+		return err
+	}
+
+	utils.MLogger.Infof("handle challenge batch: %s gen right proof for %d groups", km.ToString(), len(crs))
+
+	_, err = p.ds.SendMetaRequest(p.context, int32(mpb.OpType_Put), km.ToString(), retValue, nil, from)
+	if err != nil {
+		utils.MLogger.Info("send batch proof err: ", err)
+	}
+	return nil
+}
@@ -63,29 +63,42 @@ func (p *Info) handleChallengeBls12(km *metainfo.Key, metaValue []byte, from str
 		return nil
 	}
 
+	if cr.GetPolicy() == role.ChalPolicyPost {
+		return p.handleChallengePost(km, cr, blskey, from)
+	}
+
 	var proof pdp.ProofWithVersion
 	proof.Ver = pdp.PDPV1
 	var faultValue string
+	var chal pdp.Challenge
 	switch cr.GetPolicy() {
-	case "smart", "meta":
-		pr, err := p.handleChallenge(cr, blskey)
+	case role.ChalPolicyRandom:
+		proof.Proof, chal, err = p.handleChallengeRandom(cr, blskey)
 		if err != nil {
 			return err
 		}
-		proof.Proof = pr
-		if len(cr.GetFailMap()) > 0 {
-			faultValue = metainfo.DELIMITER + b58.Encode(cr.GetFailMap())
+		if len(cr.GetFaultBlocks()) > 0 {
+			faultValue = metainfo.DELIMITER + b58.Encode([]byte(strings.Join(cr.GetFaultBlocks(), metainfo.DELIMITER)))
 		}
-	case "random100":
-		pr, err := p.handleChallengeRandom(cr, blskey)
+	default:
+		proof.Proof, chal, err = p.handleChallenge(cr, blskey)
 		if err != nil {
 			return err
 		}
-		proof.Proof = pr
-		if len(cr.GetFaultBlocks()) > 0 {
-			faultValue = metainfo.DELIMITER + b58.Encode([]byte(strings.Join(cr.GetFaultBlocks(), metainfo.DELIMITER)))
+		if len(cr.GetFailMap()) > 0 {
+			faultValue = metainfo.DELIMITER + b58.Encode(cr.GetFailMap())
 		}
-	default:
+	}
+
+	// 在发送之前检查生成的proof
+	boo, err := blskey.VerifyKey().VerifyProof(chal, proof.Proof)
+	if err != nil {
+		utils.MLogger.Errorf("gen proof for blocks: %s failed: %s", chal.GetIndices(), err)
+		return err
+	}
+
+	if !boo {
+		return role.ErrWrongState
 	}
 
 	utils.MLogger.Info("handle challenge: ", km.ToString(), " gen right proof")
@@ -105,7 +118,7 @@ func (p *Info) handleChallengeBls12(km *metainfo.Key, metaValue []byte, from str
 	return nil
 }
 
-func (p *Info) handleChallenge(cr *mpb.ChalInfo, blskey pdp.KeySet) (pdp.Proof, error) {
+func (p *Info) handleChallenge(cr *mpb.ChalInfo, blskey pdp.KeySet) (pdp.Proof, pdp.Challenge, error) {
 	var data, tag [][]byte
 	failchunk := false
 
@@ -115,28 +128,16 @@ func (p *Info) handleChallenge(cr *mpb.ChalInfo, blskey pdp.KeySet) (pdp.Proof,
 	bset := bitset.New(0)
 	err := bset.UnmarshalBinary(cr.GetChunkMap())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	chalNum := bset.Count()
-	meta := false
+	meta := cr.GetPolicy() == role.ChalPolicyMeta
 
 	startPost := uint(chal.R) % bset.Len()
 
-	switch cr.GetPolicy() {
-	case "100":
-		chalNum = 100
-	case "1%":
-		chalNum = chalNum / 100
-	case "smart":
-		if chalNum/100 < 100 {
-			chalNum = 100
-		} else {
-			chalNum = chalNum / 100
-		}
-	case "meta":
-		meta = true
-	default:
+	chalNum, ok := role.GetChalNum(cr.GetPolicy(), bset.Count())
+	if !ok {
+		return nil, nil, role.ErrInvalidInput
 	}
 
 	ctx := p.context
@@ -301,38 +302,27 @@ func (p *Info) handleChallenge(cr *mpb.ChalInfo, blskey pdp.KeySet) (pdp.Proof,
 
 	if len(chal.Indices) == 0 {
 		utils.MLogger.Errorf("GenProof for %s fails due to no available data", cr.GetQueryID())
-		return nil, role.ErrEmptyData
+		return nil, nil, role.ErrEmptyData
 	}
 
 	proof, err := blskey.PublicKey().GenProof(&chal, data, tag, 32)
 	if err != nil {
 		utils.MLogger.Error("GenProof err: ", err)
-		return nil, err
-	}
-
-	// 在发送之前检查生成的proof
-	boo, err := blskey.VerifyKey().VerifyProof(&chal, proof)
-	if err != nil {
-		utils.MLogger.Errorf("gen proof for blocks: %s failed: %s", chal.Indices, err)
-		return nil, err
-	}
-
-	if !boo {
-		return nil, role.ErrWrongState
+		return nil, nil, err
 	}
 
 	if failchunk {
 		failMap, err := bset.MarshalBinary()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		cr.FailMap = failMap
 	}
 
-	return proof, nil
+	return proof, &chal, nil
 }
 
-func (p *Info) handleChallengeRandom(cr *mpb.ChalInfo, blskey pdp.KeySet) (pdp.Proof, error) {
+func (p *Info) handleChallengeRandom(cr *mpb.ChalInfo, blskey pdp.KeySet) (pdp.Proof, pdp.Challenge, error) {
 	var chal pdp.ChallengeV1
 	chal.R = pdp.GenChallengeV1(cr)
 
@@ -397,26 +387,94 @@ func (p *Info) handleChallengeRandom(cr *mpb.ChalInfo, blskey pdp.KeySet) (pdp.P
 
 	if len(chal.Indices) == 0 {
 		utils.MLogger.Errorf("GenProof random for %s fails due to no available data", cr.GetQueryID())
-		return nil, role.ErrEmptyData
+		return nil, nil, role.ErrEmptyData
 	}
 
 	proof, err := blskey.PublicKey().GenProof(&chal, data, tag, 32)
 	if err != nil {
 		utils.MLogger.Error("GenProof err: ", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	boo, err := blskey.VerifyKey().VerifyProof(&chal, proof)
-	if err != nil {
-		utils.MLogger.Errorf("gen proof for blocks: %s failed: %s", chal.Indices, err)
-		return nil, err
+	cr.FaultBlocks = faultBlocks
+
+	return proof, &chal, nil
+}
+
+// handleChallengePost replies raw segments of challenged post blocks,
+// keepers verify them by regenerating post data of this provider
+func (p *Info) handleChallengePost(km *metainfo.Key, cr *mpb.ChalInfo, blskey pdp.KeySet, from string) error {
+	chalR := pdp.GenChallengeV1(cr)
+
+	var segs []byte
+	var faultBlocks []string
+	var electedOffset int
+	var buf, cbuf strings.Builder
+	ctx := p.context
+	for _, index := range cr.Blocks {
+		if len(index) == 0 {
+			continue
+		}
+		buf.Reset()
+		bid, off, err := metainfo.GetBidAndOffset(index)
+		if err != nil {
+			continue
+		}
+		if off < 0 {
+			faultBlocks = append(faultBlocks, index)
+			continue
+		} else if off > 0 {
+			electedOffset = int(chalR) % off
+		} else {
+			electedOffset = 0
+		}
+		buf.WriteString(cr.GetQueryID())
+		buf.WriteString(metainfo.BlockDelimiter)
+		buf.WriteString(bid)
+		blockID := buf.String()
+
+		cbuf.Reset()
+		cbuf.WriteString(blockID)
+		cbuf.WriteString(metainfo.DELIMITER)
+		cbuf.WriteString(strconv.Itoa(int(mpb.KeyType_Block)))
+		cbuf.WriteString(metainfo.DELIMITER)
+		cbuf.WriteString(strconv.Itoa(electedOffset))
+		cbuf.WriteString(metainfo.DELIMITER)
+		cbuf.WriteString("1") // length
+
+		tmpdata, err := p.ds.GetBlock(ctx, cbuf.String(), nil, "local")
+		if err != nil {
+			utils.MLogger.Warnf("get %s data at %d failed: %s", blockID, electedOffset, err)
+			faultBlocks = append(faultBlocks, index)
+			continue
+		}
+
+		tmpseg, _, _, isTrue := df.GetSegAndTag(tmpdata.RawData(), blockID, blskey)
+		if !isTrue {
+			utils.MLogger.Warnf("verify %s data and tag at %d failed", blockID, electedOffset)
+			faultBlocks = append(faultBlocks, index)
+			continue
+		}
+
+		segs = append(segs, tmpseg[0]...)
 	}
 
-	if !boo {
-		return nil, role.ErrWrongState
+	if len(segs) == 0 {
+		utils.MLogger.Errorf("reply post challenge for %s fails due to no available data", cr.GetQueryID())
+		return role.ErrEmptyData
 	}
 
-	cr.FaultBlocks = faultBlocks
+	// raw segments are large, encoding them in base58 is too slow
+	retValue, err := role.EncodePostProof(segs, faultBlocks)
+	if err != nil {
+		return err
+	}
+
+	utils.MLogger.Info("handle post challenge: ", km.ToString(), " reply segments")
 
-	return proof, nil
+	_, err = p.ds.SendMetaRequest(p.context, int32(mpb.OpType_Put), km.ToString(), retValue, nil, from)
+	if err != nil {
+		utils.MLogger.Info("send post proof err: ", err)
+	}
+	return nil
 }
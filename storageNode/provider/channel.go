@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+const (
+	settleChanTime     = 5 * time.Minute
+	settleRetryBackoff = int64(5 * 60)  // 关闭channel失败后，等待此时间乘以重试次数再重试，单位：秒
+	settleRetryMax     = int64(30 * 60) // 重试等待时间的上限，单位：秒
+)
+
+// ChannelCloseMargin is how long before timeout of channel provider closes it
+// with the latest signature of user; unit: second
+var ChannelCloseMargin = int64(60 * 60)
+
+// SetChannelCloseMargin sets ChannelCloseMargin in minutes
+func SetChannelCloseMargin(minutes int64) error {
+	if minutes <= 0 {
+		return role.ErrInvalidInput
+	}
+	ChannelCloseMargin = minutes * 60
+	return nil
+}
+
+// chanSettle records settlement of a read-payment channel
+type chanSettle struct {
+	sync.Mutex
+	userID   string
+	queryID  string
+	deadline int64    // timeout of channel
+	value    *big.Int // latest value signed by user
+	settled  *big.Int // value paid by closing channel
+	retry    int64
+	nextTry  int64
+	lastErr  error
+	closedAt int64
+}
+
+// getChanSettle returns settlement of channel, settled value is loaded from local
+func (p *Info) getChanSettle(cItem *role.ChannelItem) *chanSettle {
+	cs, ok := p.chanSettles.Load(cItem.ChannelID)
+	if ok {
+		return cs.(*chanSettle)
+	}
+
+	ncs := &chanSettle{
+		userID:   cItem.UserID,
+		queryID:  cItem.QueryID,
+		deadline: cItem.StartTime + cItem.Duration,
+		value:    big.NewInt(0),
+	}
+	ncs.settled, ncs.closedAt = p.loadChanSettled(cItem.ChannelID)
+	if ncs.settled != nil {
+		ncs.value.Set(ncs.settled)
+	}
+
+	cs, _ = p.chanSettles.LoadOrStore(cItem.ChannelID, ncs)
+	return cs.(*chanSettle)
+}
+
+// isChanSettled returns true if channel is closed by provider, reads on it are not paid
+func (p *Info) isChanSettled(cItem *role.ChannelItem) bool {
+	cs := p.getChanSettle(cItem)
+	cs.Lock()
+	defer cs.Unlock()
+	return cs.settled != nil
+}
+
+// saveChanSettled persists value and time of closing channel,
+// its value is "value/closedAt"
+func (p *Info) saveChanSettled(chanID string, value *big.Int, closedAt int64) error {
+	km, err := metainfo.NewKey(p.localID, mpb.KeyType_Channel, chanID, "settled")
+	if err != nil {
+		return err
+	}
+
+	val := value.String() + metainfo.DELIMITER + strconv.FormatInt(closedAt, 10)
+	return p.ds.PutKey(p.context, km.ToString(), []byte(val), nil, "local")
+}
+
+func (p *Info) loadChanSettled(chanID string) (*big.Int, int64) {
+	km, err := metainfo.NewKey(p.localID, mpb.KeyType_Channel, chanID, "settled")
+	if err != nil {
+		return nil, 0
+	}
+
+	val, err := p.ds.GetKey(p.context, km.ToString(), "local")
+	if err != nil {
+		return nil, 0
+	}
+
+	splited := strings.Split(string(val), metainfo.DELIMITER)
+	if len(splited) != 2 {
+		return nil, 0
+	}
+
+	value, ok := new(big.Int).SetString(splited[0], 10)
+	if !ok {
+		return nil, 0
+	}
+
+	closedAt, err := strconv.ParseInt(splited[1], 10, 64)
+	if err != nil {
+		return nil, 0
+	}
+
+	return value, closedAt
+}
+
+func (p *Info) settleChannelsRegular(ctx context.Context) {
+	ticker := time.NewTicker(settleChanTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, qu := range p.getGroups() {
+				p.settleChannels(qu.uid, qu.qid)
+			}
+		}
+	}
+}
+
+// settleChannels closes channels of group which are about to time out
+func (p *Info) settleChannels(userID, groupID string) {
+	if userID == groupID {
+		return
+	}
+
+	gp := p.getGroupInfo(userID, groupID, false)
+	if gp == nil {
+		return
+	}
+
+	for _, ch := range gp.getChannels() {
+		ci, ok := gp.channel.Load(ch)
+		if !ok {
+			continue
+		}
+		p.settleChannel(ci.(*role.ChannelItem))
+	}
+}
+
+// settleChannel closes channel with the latest signature of user when it is
+// within ChannelCloseMargin of timeout; failures are retried with backoff
+func (p *Info) settleChannel(cItem *role.ChannelItem) {
+	cs := p.getChanSettle(cItem)
+	cs.Lock()
+	defer cs.Unlock()
+
+	if cItem.Value != nil && cItem.Value.Cmp(cs.value) > 0 {
+		cs.value = new(big.Int).Set(cItem.Value)
+	}
+
+	now := time.Now().Unix()
+	if cs.settled != nil || now < cs.deadline-ChannelCloseMargin || now < cs.nextTry {
+		return
+	}
+
+	if cItem.Money == nil || cItem.Money.Sign() == 0 || cItem.Value == nil || cItem.Value.Sign() == 0 {
+		return
+	}
+
+	if now > cs.deadline {
+		utils.MLogger.Warnf("channel %s of user %s timed out with unsettled value %s", cItem.ChannelID, cItem.UserID, cItem.Value)
+	}
+
+	cSign := new(mpb.ChannelSign)
+	err := proto.Unmarshal(cItem.Sig, cSign)
+	if err == nil {
+		err = role.CloseChannel(cItem.ChannelID, p.sk, cSign.GetSig(), cItem.Value)
+	}
+	if err != nil {
+		cs.retry++
+		cs.lastErr = err
+		wait := settleRetryBackoff * cs.retry
+		if wait > settleRetryMax {
+			wait = settleRetryMax
+		}
+		cs.nextTry = now + wait
+		utils.MLogger.Errorf("close channel %s err: %s, retry %d times in %d seconds", cItem.ChannelID, err, cs.retry, wait)
+		return
+	}
+
+	cs.settled = new(big.Int).Set(cItem.Value)
+	cs.closedAt = now
+	cs.lastErr = nil
+	err = p.saveChanSettled(cItem.ChannelID, cs.settled, now)
+	if err != nil {
+		utils.MLogger.Errorf("save settlement of channel %s err: %s", cItem.ChannelID, err)
+	}
+	utils.MLogger.Infof("close channel %s of user %s with value %s", cItem.ChannelID, cItem.UserID, cItem.Value)
+	role.RecordBill(p.localID, role.BillChannel, cItem.Value, true, cItem.UserID, cItem.ChannelID, "close channel")
+
+	cItem.Money, err = role.QueryBalance(cItem.ChannelID)
+	if err != nil {
+		cItem.Money = big.NewInt(0)
+	}
+}
+
+// ShowChannelIncome reports settled and unsettled read income of channels per user;
+// unsettled value of timed out channels is lost
+func (p *Info) ShowChannelIncome() []string {
+	type income struct {
+		settled, pending, lost *big.Int
+		open, closed, failed   int
+	}
+
+	users := make(map[string]*income)
+	now := time.Now().Unix()
+	p.chanSettles.Range(func(key, value interface{}) bool {
+		cs := value.(*chanSettle)
+		cs.Lock()
+		defer cs.Unlock()
+
+		in, ok := users[cs.userID]
+		if !ok {
+			in = &income{settled: big.NewInt(0), pending: big.NewInt(0), lost: big.NewInt(0)}
+			users[cs.userID] = in
+		}
+
+		switch {
+		case cs.settled != nil:
+			in.closed++
+			in.settled.Add(in.settled, cs.settled)
+		case now > cs.deadline:
+			in.lost.Add(in.lost, cs.value)
+		default:
+			in.open++
+			in.pending.Add(in.pending, cs.value)
+		}
+
+		if cs.lastErr != nil {
+			in.failed++
+		}
+		return true
+	})
+
+	var uids []string
+	for uid := range users {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+
+	var res []string
+	for _, uid := range uids {
+		in := users[uid]
+		res = append(res, fmt.Sprintf("%s: settled %s in %d channels, unsettled %s in %d open channels, lost %s, %d channels failed to close", uid, utils.FormatWei(in.settled), in.closed, utils.FormatWei(in.pending), in.open, utils.FormatWei(in.lost), in.failed))
+	}
+	return res
+}
@@ -18,6 +18,7 @@ import (
 var (
 	ErrGroupNotReady     = errors.New("group is nil")
 	ErrUpkeepingNotReady = errors.New("upkeeping contract is not deployed")
+	ErrChannelSettled    = errors.New("channel is settled")
 )
 
 type GInfoOutput struct {
@@ -104,6 +105,9 @@ func (p *Info) getDiskUsage() (uint64, error) {
 		return 0, err
 	}
 
+	// blocks on disks outside of repo
+	localUsed += p.getOuterDiskUsage(rootpath)
+
 	if used != localUsed {
 		utils.MLogger.Infof("localUsed is %d, while calculate is: %d", localUsed, used)
 	}
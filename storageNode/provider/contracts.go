@@ -2,7 +2,6 @@ package provider
 
 import (
 	"math/big"
-	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/memoio/go-mefs/contracts"
@@ -33,10 +32,15 @@ func (p *Info) loadContracts(capacity, duration, depositSize int64, price *big.I
 				return err
 			}
 
+			pledged := proItem.PledgeMoney
 			proItem, err = role.GetProviderInfo(proID, proID)
 			if err != nil {
 				return err
 			}
+
+			if pledged != nil && proItem.PledgeMoney != nil {
+				role.RecordBill(proID, role.BillPledge, new(big.Int).Sub(proItem.PledgeMoney, pledged), false, "", "", "pledge provider")
+			}
 		}
 
 		p.proContract = &proItem
@@ -115,27 +119,6 @@ func (p *Info) saveChannelValue(userID, groupID, proID string) error {
 				continue
 			}
 			p.ds.PutKey(ctx, km.ToString(), cItem.Sig, nil, "local")
-
-			if time.Now().Unix()-cItem.StartTime > cItem.Duration-int64(60*60) && cItem.Money.Sign() > 0 {
-				cSign := new(mpb.ChannelSign)
-				err = proto.Unmarshal(cItem.Sig, cSign)
-				if err != nil {
-					continue
-				}
-
-				if cItem.Value.Sign() == 0 {
-					continue
-				}
-
-				// need verify value again;
-				err = role.CloseChannel(cItem.ChannelID, p.sk, cSign.GetSig(), cItem.Value)
-				if err != nil {
-					utils.MLogger.Errorf("close channel %s err: %s", cItem.ChannelID, err)
-					continue
-				}
-
-				cItem.Money, err = role.QueryBalance(cItem.ChannelID)
-			}
 		}
 	}
 
@@ -238,26 +221,7 @@ func (p *Info) loadChannelValue(userID, groupID string) error {
 			cItem.Money, _ = role.QueryBalance(cItem.ChannelID)
 
 			// close before timeout
-			if time.Now().Unix()-cItem.StartTime > cItem.Duration-int64(60*60) && cItem.Money.Sign() > 0 {
-				cSign := new(mpb.ChannelSign)
-				err = proto.Unmarshal(cItem.Sig, cSign)
-				if err != nil {
-					continue
-				}
-
-				if cItem.Value.Sign() == 0 {
-					continue
-				}
-
-				// need verify value again;
-				err = role.CloseChannel(cItem.ChannelID, p.sk, cSign.GetSig(), cItem.Value)
-				if err != nil {
-					utils.MLogger.Errorf("close channel %s err: %s", cItem.ChannelID, err)
-					continue
-				}
-
-				cItem.Money, _ = role.QueryBalance(cItem.ChannelID)
-			}
+			p.settleChannel(cItem)
 		}
 	}
 
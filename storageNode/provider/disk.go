@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	multidisk "github.com/memoio/go-mefs/source/go-ds-multidisk"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// block ids sent to keepers in one message
+const lostBlockBatch = 1024
+
+// getLocalSpace gets local storage info; if blocks are stored on multiple
+// disks, it is the sum of healthy disks
+func (p *Info) getLocalSpace() (*utils.DiskStats, error) {
+	mds := multidisk.Opened()
+	if len(mds) == 0 {
+		return role.GetDiskSpaceInfo()
+	}
+
+	res := new(utils.DiskStats)
+	for _, md := range mds {
+		for _, st := range md.Stat() {
+			if st.Failed {
+				continue
+			}
+			res.Total += st.Capacity
+			res.Free += st.Free
+		}
+	}
+	res.Used = res.Total - res.Free
+	return res, nil
+}
+
+// getOuterDiskUsage gets bytes used on disks which are outside of repo
+func (p *Info) getOuterDiskUsage(rootpath string) uint64 {
+	var used uint64
+	for _, md := range multidisk.Opened() {
+		for _, st := range md.Stat() {
+			if st.Failed {
+				continue
+			}
+			rel, err := filepath.Rel(rootpath, st.Path)
+			if err == nil && !strings.HasPrefix(rel, "..") {
+				continue
+			}
+			used += st.Used
+		}
+	}
+	return used
+}
+
+// watchDisks reports blocks lost on failed disks to keepers
+func (p *Info) watchDisks(ctx context.Context) {
+	for _, md := range multidisk.Opened() {
+		go func(md *multidisk.Datastore) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case f := <-md.Failures():
+					p.reportLostBlocks(ctx, f)
+				}
+			}
+		}(md)
+	}
+}
+
+// reportLostBlocks reports blocks lost on a failed disk
+func (p *Info) reportLostBlocks(ctx context.Context, f *multidisk.Failure) {
+	utils.MLogger.Errorf("disk %s is failed: %s, %d blocks are lost", f.Path, f.Err, len(f.Keys))
+
+	blocks := make([]string, 0, len(f.Keys))
+	for _, k := range f.Keys {
+		blocks = append(blocks, strings.TrimPrefix(k.String(), "/"))
+	}
+	p.sendLostBlocks(ctx, blocks)
+
+	// update storage space in keepers
+	err := p.storageSync(ctx)
+	if err != nil {
+		utils.MLogger.Info("storage sync fails: ", err)
+	}
+}
+
+// sendLostBlocks sends ids of lost blocks to keepers of their groups,
+// so that keepers repair only these blocks
+// key: queryID/"LostBlock"/uid, value: blockID1/blockID2/...
+func (p *Info) sendLostBlocks(ctx context.Context, blockIDs []string) {
+	// key is queryID
+	lost := make(map[string][]string)
+	for _, blockID := range blockIDs {
+		// qid_bid_sid_cid
+		bids := strings.SplitN(blockID, metainfo.BlockDelimiter, 2)
+		if len(bids) < 2 {
+			continue
+		}
+		lost[bids[0]] = append(lost[bids[0]], blockID)
+	}
+
+	for qid, blocks := range lost {
+		gp := p.getGroupInfo("", qid, false)
+		if gp == nil {
+			utils.MLogger.Warnf("lost %d blocks of unknown group %s", len(blocks), qid)
+			continue
+		}
+
+		km, err := metainfo.NewKey(qid, mpb.KeyType_LostBlock, gp.userID)
+		if err != nil {
+			continue
+		}
+
+		for i := 0; i < len(blocks); i += lostBlockBatch {
+			end := i + lostBlockBatch
+			if end > len(blocks) {
+				end = len(blocks)
+			}
+			value := strings.Join(blocks[i:end], metainfo.DELIMITER)
+
+			for _, kid := range gp.keepers {
+				_, err := p.ds.SendMetaRequest(ctx, int32(mpb.OpType_Put), km.ToString(), []byte(value), nil, kid)
+				if err != nil {
+					utils.MLogger.Info("lost blocks send to", kid, "error: ", err)
+				}
+			}
+		}
+	}
+}
@@ -33,9 +33,13 @@ func (p *Info) HandleMetaMessage(opType mpb.OpType, metaKey string, metaValue, s
 		if opType == mpb.OpType_Get {
 			go p.handleChallengeBls12(km, metaValue, from)
 		}
+	case mpb.KeyType_ChalBatch:
+		if opType == mpb.OpType_Get {
+			go p.handleChallengeBatch(km, metaValue, from)
+		}
 	case mpb.KeyType_Repair:
 		if opType == mpb.OpType_Get {
-			go p.handleRepair(km, metaValue, from)
+			go p.handleRepair(km, metaValue, sig, from)
 		}
 	case mpb.KeyType_Block:
 		switch opType {
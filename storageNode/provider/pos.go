@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"math/big"
-	"math/rand"
 	"strconv"
 	"strings"
 	"time"
@@ -256,7 +255,7 @@ func (p *Info) doGenerateOrDelete() {
 		inGenerate = 0
 	}()
 
-	lsinfo, err := role.GetDiskSpaceInfo()
+	lsinfo, err := p.getLocalSpace()
 	if err != nil || lsinfo.Total == 0 {
 		return
 	}
@@ -316,12 +315,21 @@ func (p *Info) generatePostBlocks(increaseSpace uint64) {
 		if totalIncreased >= increaseSpace {
 			break
 		}
-		tmpData := make([]byte, pos.DLen)
+		// post data is bound to this provider, each replica is sealed
+		// differently and slow to generate
+		tmpData, err := pos.GenData(p.localID, curSid+1)
+		if err != nil {
+			utils.MLogger.Info("generate post data error: ", err)
+			return
+		}
 		totalIncreased += uint64(10 * len(tmpData))
-		rand.Seed(time.Now().UnixNano())
-		fillRandom(tmpData)
 		curSid++
 
+		sid := curSid
+		opt.SealSegment = func(seg []byte, chunkID, segIndex int) error {
+			return pos.SealSegment(seg, p.localID, sid, chunkID, segIndex)
+		}
+
 		bm.SetSid(strconv.Itoa(curSid))
 		data, offset, err := opt.Encode(tmpData, bm.ToString(3), 0)
 		if err != nil {
@@ -442,16 +450,6 @@ func (p *Info) deletePostBlocks(decreseSpace uint64) {
 	}
 }
 
-func fillRandom(p []byte) {
-	for i := 0; i < len(p); i += 7 {
-		val := rand.Int63()
-		for j := 0; i+j < len(p) && j < 7; j++ {
-			p[i+j] = byte(val)
-			val >>= 8
-		}
-	}
-}
-
 func getPostPreIncome(ukAddrs []common.Address, localAddr common.Address) *big.Int {
 	postPreIncome := big.NewInt(0)
 	localID, err := address.GetIDFromAddress(localAddr.Hex())
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"net"
 	"strconv"
@@ -54,6 +55,8 @@ type Info struct {
 	users             sync.Map // key: userID, value: *uInfo
 	keepers           sync.Map // key: keeperID, value: *kInfo
 	providers         sync.Map // key: proID, value: *kInfo
+	chanSettles       sync.Map // key: channelID, value: *chanSettle
+	scrub             scrubInfo
 	offers            []*role.OfferItem
 	proContract       *role.ProviderItem
 	userConfigs       *lru.ARCCache
@@ -234,7 +237,7 @@ func (p *Info) Start(ctx context.Context, opts interface{}) error {
 
 	p.StorageUsed = usedCapacity
 
-	lsinfo, err := role.GetDiskSpaceInfo()
+	lsinfo, err := p.getLocalSpace()
 	if err != nil {
 		return err
 	}
@@ -263,6 +266,9 @@ func (p *Info) Start(ctx context.Context, opts interface{}) error {
 	go p.getFromChainRegular(ctx)
 	go p.sendStorageRegular(ctx)
 	go p.saveRegular(ctx)
+	go p.settleChannelsRegular(ctx)
+	go p.scrubRegular(ctx)
+	p.watchDisks(ctx)
 
 	p.extAddrSync(ctx)
 	p.GetPublicAddress()
@@ -852,6 +858,7 @@ func (p *Info) getIncome(localAddr common.Address, pBlock int64) (int64, error)
 			}
 
 			p.StorageIncome.Add(p.StorageIncome, sIncome)
+			role.RecordBill(p.localID, role.BillSpaceTime, sIncome, true, "", "", fmt.Sprintf("storage income in blocks %d-%d", storageBlock, endBlock))
 			p.TotalIncome.Add(p.TotalIncome, sIncome)
 			storageBlock = endBlock
 
@@ -884,6 +891,7 @@ func (p *Info) getIncome(localAddr common.Address, pBlock int64) (int64, error)
 			}
 
 			p.PostIncome.Add(p.PostIncome, sIncome)
+			role.RecordBill(p.localID, role.BillSpaceTime, sIncome, true, "", "", fmt.Sprintf("post income in blocks %d-%d", postBlock, endBlock))
 			p.TotalIncome.Add(p.TotalIncome, sIncome)
 			postBlock = endBlock
 
@@ -1121,7 +1129,7 @@ func (p *Info) storageSync(ctx context.Context) error {
 
 	p.ms.storageUsed.Set(float64(actulDataSpace))
 
-	lsinfo, err := role.GetDiskSpaceInfo()
+	lsinfo, err := p.getLocalSpace()
 	if err != nil {
 		return err
 	}
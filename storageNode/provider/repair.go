@@ -12,7 +12,8 @@ import (
 )
 
 //rpids: cid1_pid1/cid2_pid2
-func (p *Info) handleRepair(km *metainfo.Key, rpids []byte, keeper string) error {
+//sig: read token issued by keeper for getting blocks of stripe from other providers
+func (p *Info) handleRepair(km *metainfo.Key, rpids, sig []byte, keeper string) error {
 	utils.MLogger.Info("handleRepair: ", km.ToString(), " from: ", keeper)
 
 	blockID := km.GetMainID()
@@ -66,74 +67,102 @@ func (p *Info) handleRepair(km *metainfo.Key, rpids []byte, keeper string) error
 		}
 	}
 
-	var nbid int
-	sig, err := role.BuildSignMessage()
+	nbid, err := strconv.Atoi(chunkID)
 	if err != nil {
+		utils.MLogger.Info("strconv.Atoi error :", err)
 		return err
 	}
 
 	cpids := strings.Split(string(rpids), metainfo.DELIMITER)
 	stripe := make([][]byte, len(cpids)+1) //用来存放每一个chunkID对应的block数据
-	for _, cpid := range cpids {
-		if len(cpid) > 0 {
-			splitcpid := strings.Split(cpid, metainfo.BlockDelimiter)
-			if len(splitcpid) != 2 { // chunkid pid
-				continue
-			}
-			blkInfo[3] = splitcpid[0] //cid
-			blkid := strings.Join(blkInfo, metainfo.BlockDelimiter)
+	if nbid >= len(stripe) {
+		for j := len(stripe); j <= nbid; j++ {
+			stripe = append(stripe, nil)
+		}
+	}
 
-			bid, err := metainfo.NewKey(blkid, mpb.KeyType_Block, "0", ops[1])
-			if err != nil {
-				continue
-			}
+	fetch := func(cid, pid string) {
+		blkInfo[3] = cid
+		blkid := strings.Join(blkInfo, metainfo.BlockDelimiter)
 
-			if splitcpid[0] == chunkID { //表示待修复block
-				nbid, err = strconv.Atoi(chunkID)
-				if err != nil {
-					utils.MLogger.Info("strconv.Atoi error :", err)
-					return err
-				}
+		bid, err := metainfo.NewKey(blkid, mpb.KeyType_Block, "0", ops[1])
+		if err != nil {
+			return
+		}
 
-				if nbid >= len(stripe) {
-					for j := len(stripe); j <= nbid; j++ {
-						stripe = append(stripe, nil)
-					}
-				}
-				continue
-			}
+		blk, err := p.ds.GetBlock(ctx, bid.ToString(), sig, pid)
+		if err != nil || blk == nil {
+			return
+		}
+		ok, err := df.VerifyBlockLength(blk.RawData(), 0, segNeed)
+		if err != nil || !ok {
+			return
+		}
 
-			pid := splitcpid[1]
-			blk, err := p.ds.GetBlock(ctx, bid.ToString(), sig, pid)
-			if err != nil || blk == nil {
-				continue
-			}
-			ok, err := df.VerifyBlockLength(blk.RawData(), 0, segNeed)
-			if err != nil || !ok {
-				continue
-			}
+		ok = df.VerifyBlock(blk.RawData(), blkid, pubKey)
+		if !ok {
+			return
+		}
+		chNum, err := strconv.Atoi(cid)
+		if err != nil {
+			return
+		}
 
-			ok = df.VerifyBlock(blk.RawData(), blkid, pubKey)
-			if !ok {
-				continue
+		if chNum >= len(stripe) {
+			for j := len(stripe); j <= chNum; j++ {
+				stripe = append(stripe, nil)
 			}
+		}
+		stripe[chNum] = blk.RawData()
+		p.ds.DeleteBlock(ctx, blkid, "local")
+	}
+
+	// with lrc, chunks of local group are fetched first, others are fetched
+	// only if local repair fails
+	var local map[int]bool
+	var rest [][2]string
+	for _, cpid := range cpids {
+		splitcpid := strings.Split(cpid, metainfo.BlockDelimiter)
+		if len(splitcpid) != 2 || splitcpid[0] == chunkID { // chunkid pid
+			continue
+		}
+
+		if local != nil {
 			chNum, err := strconv.Atoi(splitcpid[0])
-			if err != nil {
-				utils.MLogger.Info("strconv.Atoi error :", err)
-				return err
+			if err != nil || !local[chNum] {
+				rest = append(rest, [2]string{splitcpid[0], splitcpid[1]})
+				continue
 			}
+		}
 
-			if chNum >= len(stripe) {
-				for j := len(stripe); j <= chNum; j++ {
-					stripe = append(stripe, nil)
+		fetch(splitcpid[0], splitcpid[1])
+		if local == nil {
+			chNum, err := strconv.Atoi(splitcpid[0])
+			if err == nil && chNum < len(stripe) && stripe[chNum] != nil {
+				for _, i := range df.LocalRepairGroup(stripe[chNum], nbid) {
+					if local == nil {
+						local = make(map[int]bool)
+					}
+					local[i] = true
 				}
 			}
-			stripe[chNum] = blk.RawData()
-			p.ds.DeleteBlock(ctx, blkid, "local")
 		}
 	}
 
-	newstripe, off, err := df.Repair(stripe)
+	newstripe, off, err := df.Repair(append([][]byte{}, stripe...))
+	if len(rest) > 0 {
+		ok := false
+		if err == nil && off == segNeed {
+			ok, _ = df.VerifyBlockLength(newstripe[nbid], 0, segNeed)
+		}
+		if !ok {
+			utils.MLogger.Info("repair ", blockID, " from local group failed, fetch other chunks")
+			for _, cp := range rest {
+				fetch(cp[0], cp[1])
+			}
+			newstripe, off, err = df.Repair(stripe)
+		}
+	}
 	if err != nil {
 		utils.MLogger.Info("repair ", blockID, " failed: ", err)
 		return err
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/memoio/go-mefs/crypto/pdp"
+	df "github.com/memoio/go-mefs/data-format"
+	blocks "github.com/memoio/go-mefs/source/go-block-format"
+	bs "github.com/memoio/go-mefs/source/go-ipfs-blockstore"
+	cid "github.com/memoio/go-mefs/source/go-cid"
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	dsq "github.com/memoio/go-mefs/source/go-datastore/query"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+	"github.com/memoio/go-mefs/utils/pos"
+)
+
+const (
+	// first scrub starts after
+	scrubDelay = 30 * time.Minute
+	// scrub all blocks once in
+	scrubInterval = 24 * time.Hour
+	// bytes read per second in scrub
+	scrubRate = 8 * 1024 * 1024
+	// recent corrupt blocks kept in scrub info
+	scrubRecent = 16
+	// times of reading a block in scrub
+	scrubRetry = 3
+)
+
+var (
+	// interval between reads of a block which has read errors
+	scrubRetryDelay = 10 * time.Second
+	// prefix of blocks in datastore
+	blockPrefix = ds.NewKey("/data")
+	// corrupt blocks are moved under this prefix
+	quarantinePrefix = ds.NewKey("/quarantine")
+)
+
+// ScrubInfo is progress and findings of scrubbing stored blocks
+type ScrubInfo struct {
+	Round      int
+	Running    bool
+	StartTime  time.Time
+	FinishTime time.Time // finish time of last round
+	Total      int       // blocks in this round
+	Scanned    int
+	Corrupt    int      // corrupt blocks found in all rounds
+	Recent     []string // recent corrupt blocks
+}
+
+type scrubInfo struct {
+	sync.RWMutex
+	ScrubInfo
+}
+
+// GetScrubInfo returns progress and findings of scrub
+func (p *Info) GetScrubInfo() ScrubInfo {
+	p.scrub.RLock()
+	defer p.scrub.RUnlock()
+
+	res := p.scrub.ScrubInfo
+	res.Recent = append([]string(nil), p.scrub.Recent...)
+	return res
+}
+
+// scrubRegular verifies stored blocks in background, so that corrupt blocks
+// are repaired before challenges of keepers fail
+func (p *Info) scrubRegular(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(scrubDelay):
+	}
+
+	ticker := time.NewTicker(scrubInterval)
+	defer ticker.Stop()
+	for {
+		p.scrubBlocks(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scrubBlocks verifies all stored blocks once, at most scrubRate bytes
+// are read per second
+func (p *Info) scrubBlocks(ctx context.Context) {
+	blocks, err := p.listBlocks()
+	if err != nil {
+		utils.MLogger.Error("scrub list blocks fails: ", err)
+		return
+	}
+
+	p.scrub.Lock()
+	p.scrub.Round++
+	round := p.scrub.Round
+	p.scrub.Running = true
+	p.scrub.StartTime = time.Now()
+	p.scrub.Total = len(blocks)
+	p.scrub.Scanned = 0
+	p.scrub.Unlock()
+
+	utils.MLogger.Infof("scrub round %d starts, %d blocks", round, len(blocks))
+
+	corrupt := 0
+	for _, blockID := range blocks {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		n, ok := p.scrubBlock(ctx, blockID)
+		if !ok {
+			corrupt++
+		}
+
+		p.scrub.Lock()
+		p.scrub.Scanned++
+		p.scrub.Unlock()
+
+		if n > 0 {
+			time.Sleep(time.Duration(n) * time.Second / scrubRate)
+		}
+	}
+
+	p.scrub.Lock()
+	p.scrub.Running = false
+	p.scrub.FinishTime = time.Now()
+	p.scrub.Unlock()
+
+	utils.MLogger.Infof("scrub round %d finishes, %d corrupt blocks", round, corrupt)
+}
+
+// listBlocks returns ids of stored blocks
+func (p *Info) listBlocks() ([]string, error) {
+	res, err := p.ds.DataStore().Query(dsq.Query{Prefix: blockPrefix.String(), KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var blocks []string
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		k := ds.RawKey(r.Key)
+		if !blockPrefix.IsAncestorOf(k) {
+			continue
+		}
+		blocks = append(blocks, strings.TrimPrefix(r.Key, blockPrefix.String()+"/"))
+	}
+	return blocks, nil
+}
+
+// scrubBlock verifies segments and tags of a block; corrupt block is
+// quarantined and repaired. It returns bytes read and whether block is ok.
+func (p *Info) scrubBlock(ctx context.Context, blockID string) (int, bool) {
+	// qid_bid_sid_cid
+	bids := strings.SplitN(blockID, metainfo.BlockDelimiter, 2)
+	if len(bids) < 2 {
+		return 0, true
+	}
+
+	gp := p.getGroupInfo("", bids[0], false)
+	// post blocks are not repaired by keepers
+	if gp == nil || gp.userID == pos.GetPostId() {
+		return 0, true
+	}
+
+	blskey, err := p.getNewUserConfig(gp.userID, bids[0])
+	if err != nil || blskey == nil || blskey.PublicKey() == nil {
+		return 0, true
+	}
+
+	return p.checkBlock(ctx, blockID, blskey)
+}
+
+// checkBlock reads block and verifies it by blskey; only block whose data
+// fails verifying is quarantined. Block which cannot be read is skipped,
+// read errors are left to disk checking, which reports blocks of failed disks.
+func (p *Info) checkBlock(ctx context.Context, blockID string, blskey pdp.KeySet) (int, bool) {
+	var blk blocks.Block
+	var err error
+	for i := 0; i < scrubRetry; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return 0, true
+			case <-time.After(scrubRetryDelay):
+			}
+		}
+
+		blk, err = p.ds.BlockStore().Get(cid.NewCidV2([]byte(blockID)))
+		if err == nil || err == bs.ErrNotFound {
+			break
+		}
+	}
+
+	switch err {
+	case nil:
+	case bs.ErrNotFound:
+		// deleted after listing
+		return 0, true
+	default:
+		utils.MLogger.Warnf("scrub cannot read block %s: %s", blockID, err)
+		return 0, true
+	}
+
+	data := blk.RawData()
+	if df.VerifyBlock(data, blockID, blskey) {
+		return len(data), true
+	}
+
+	utils.MLogger.Errorf("scrub finds corrupt block %s", blockID)
+	p.quarantine(ctx, blockID, data)
+
+	p.scrub.Lock()
+	p.scrub.Corrupt++
+	p.scrub.Recent = append(p.scrub.Recent, blockID)
+	if len(p.scrub.Recent) > scrubRecent {
+		p.scrub.Recent = p.scrub.Recent[1:]
+	}
+	p.scrub.Unlock()
+
+	p.sendLostBlocks(ctx, []string{blockID})
+
+	return len(data), false
+}
+
+// quarantine moves corrupt block out of blockstore, so that it is not
+// served; its data is kept under quarantinePrefix for checking
+func (p *Info) quarantine(ctx context.Context, blockID string, data []byte) {
+	if len(data) > 0 {
+		err := p.ds.DataStore().Put(quarantinePrefix.ChildString(blockID), data)
+		if err != nil {
+			utils.MLogger.Errorf("quarantine block %s fails: %s", blockID, err)
+		}
+	}
+
+	err := p.ds.DeleteBlock(ctx, blockID, "local")
+	if err != nil {
+		utils.MLogger.Errorf("delete corrupt block %s fails: %s", blockID, err)
+	}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/memoio/go-mefs/crypto/pdp"
+	df "github.com/memoio/go-mefs/data-format"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/source/data"
+	blocks "github.com/memoio/go-mefs/source/go-block-format"
+	cid "github.com/memoio/go-mefs/source/go-cid"
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	bs "github.com/memoio/go-mefs/source/go-ipfs-blockstore"
+	"github.com/memoio/go-mefs/utils"
+)
+
+var errTestRead = errors.New("test read error")
+
+// testService keeps blocks in a map datastore
+type testService struct {
+	data.Service
+	dstore  ds.Batching
+	bstore  bs.Blockstore
+	deleted []string
+}
+
+func newTestService() *testService {
+	dstore := ds.NewMapDatastore()
+	return &testService{
+		dstore: dstore,
+		bstore: bs.NewBlockstore(dstore),
+	}
+}
+
+func (s *testService) BlockStore() bs.Blockstore {
+	return s.bstore
+}
+
+func (s *testService) DataStore() ds.Datastore {
+	return s.dstore
+}
+
+func (s *testService) DeleteBlock(ctx context.Context, key, to string) error {
+	s.deleted = append(s.deleted, key)
+	return s.bstore.DeleteBlock(cid.NewCidV2([]byte(key)))
+}
+
+// errBlockstore fails reading the first fails times
+type errBlockstore struct {
+	bs.Blockstore
+	fails int
+	reads int
+}
+
+func (b *errBlockstore) Get(c cid.Cid) (blocks.Block, error) {
+	b.reads++
+	if b.reads <= b.fails {
+		return nil, errTestRead
+	}
+	return b.Blockstore.Get(c)
+}
+
+// testBlocks returns keyset and encoded blocks of a stripe
+func testBlocks(t *testing.T, queryID string) (pdp.KeySet, map[string][]byte) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyset, err := pdp.GenKeySetV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bo := df.DefaultBucketOptions()
+	coder, err := df.NewDataCoderWithPrefix(keyset, &mpb.BlockOptions{
+		Bopts:   bo,
+		UserID:  queryID,
+		QueryID: queryID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefix := queryID + "_1_0"
+	data, _, err := coder.Encode(make([]byte, 4096), prefix, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := make(map[string][]byte)
+	for i, b := range data {
+		res[prefix+"_"+strconv.Itoa(i)] = b
+	}
+	return keyset, res
+}
+
+func putTestBlock(t *testing.T, s *testService, blockID string, data []byte) {
+	blk, err := blocks.NewBlockWithCid(data, cid.NewCidV2([]byte(blockID)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.bstore.Put(blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckBlock(t *testing.T) {
+	utils.StartLogger()
+	ctx := context.Background()
+
+	queryID := "8MGxCuiT75bje883b7uFb6eMrJt5cQ"
+	keyset, blks := testBlocks(t, queryID)
+
+	s := newTestService()
+	p := &Info{ds: s}
+
+	good, bad := queryID+"_1_0_0", queryID+"_1_0_1"
+	putTestBlock(t, s, good, blks[good])
+	corrupt := append([]byte(nil), blks[bad]...)
+	_, preLen, err := blocks.PrefixDecode(corrupt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// first segment is changed
+	corrupt[preLen]++
+	putTestBlock(t, s, bad, corrupt)
+
+	n, ok := p.checkBlock(ctx, good, keyset)
+	if !ok || n != len(blks[good]) || len(s.deleted) != 0 {
+		t.Fatal("good block is judged corrupt")
+	}
+
+	// deleted after listing
+	n, ok = p.checkBlock(ctx, queryID+"_1_0_2", keyset)
+	if !ok || n != 0 || len(s.deleted) != 0 {
+		t.Fatal("missing block is judged corrupt")
+	}
+
+	n, ok = p.checkBlock(ctx, bad, keyset)
+	if ok || n != len(corrupt) {
+		t.Fatal("corrupt block is not found")
+	}
+
+	// corrupt block is moved to quarantine
+	if len(s.deleted) != 1 || s.deleted[0] != bad {
+		t.Fatal("corrupt block is not deleted: ", s.deleted)
+	}
+
+	qdata, err := s.dstore.Get(quarantinePrefix.ChildString(bad))
+	if err != nil || !bytes.Equal(qdata, corrupt) {
+		t.Fatal("corrupt block is not quarantined: ", err)
+	}
+
+	info := p.GetScrubInfo()
+	if info.Corrupt != 1 || len(info.Recent) != 1 || info.Recent[0] != bad {
+		t.Fatal("wrong scrub info: ", info)
+	}
+}
+
+func TestCheckBlockReadError(t *testing.T) {
+	utils.StartLogger()
+	ctx := context.Background()
+
+	delay := scrubRetryDelay
+	scrubRetryDelay = time.Millisecond
+	defer func() {
+		scrubRetryDelay = delay
+	}()
+
+	queryID := "8MGxCuiT75bje883b7uFb6eMrJt5cQ"
+	keyset, blks := testBlocks(t, queryID)
+
+	s := newTestService()
+	p := &Info{ds: s}
+
+	blockID := queryID + "_1_0_0"
+	putTestBlock(t, s, blockID, blks[blockID])
+
+	// block which cannot be read is skipped, not deleted
+	eb := &errBlockstore{Blockstore: s.bstore, fails: scrubRetry}
+	s.bstore = eb
+
+	n, ok := p.checkBlock(ctx, blockID, keyset)
+	if !ok || n != 0 || eb.reads != scrubRetry {
+		t.Fatal("unreadable block is judged: ", n, ok, eb.reads)
+	}
+
+	if len(s.deleted) != 0 || p.GetScrubInfo().Corrupt != 0 {
+		t.Fatal("unreadable block is quarantined")
+	}
+
+	if has, _ := eb.Blockstore.Has(cid.NewCidV2([]byte(blockID))); !has {
+		t.Fatal("unreadable block is deleted")
+	}
+
+	// block is verified when read is retried
+	eb.reads = 0
+	eb.fails = scrubRetry - 1
+
+	n, ok = p.checkBlock(ctx, blockID, keyset)
+	if !ok || n != len(blks[blockID]) || eb.reads != scrubRetry {
+		t.Fatal("block is not verified after retry: ", n, ok, eb.reads)
+	}
+}
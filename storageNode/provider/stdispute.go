@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// getSTLeaves gets spacetime pay leaves and challenge logs from keeper
+func (p *Info) getSTLeaves(ctx context.Context, gp *groupInfo, kid string, ops ...string) (*mpb.STLeaves, error) {
+	ops = append([]string{gp.userID, p.localID}, ops...)
+	km, err := metainfo.NewKey(gp.groupID, mpb.KeyType_StPayDispute, ops...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), km.ToString(), nil, nil, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	stl := new(mpb.STLeaves)
+	err = proto.Unmarshal(res, stl)
+	if err != nil {
+		return nil, err
+	}
+	return stl, nil
+}
+
+// DisputeSTPay checks spacetime pay proposed by keeper of group: leaves
+// behind its root and their challenge records signed by keepers; if challenge
+// logs show more spacetime than the pay, a counter claim is sent to keepers,
+// who re-verify it before signing the pay.
+func (p *Info) DisputeSTPay(ctx context.Context, userID, groupID string) ([]string, error) {
+	gp := p.getGroupInfo(userID, groupID, false)
+	if gp == nil || gp.upkeeping == nil {
+		return nil, role.ErrNotMyUser
+	}
+
+	price := gp.upkeeping.Price
+
+	var pay *mpb.STValue
+	var leaves []*mpb.STLeaf
+	var master string
+	for _, kid := range gp.keepers {
+		stl, err := p.getSTLeaves(ctx, gp, kid)
+		if err != nil {
+			utils.MLogger.Infof("Get spacetime pay leaves from keeper %s fails: %s", kid, err)
+			continue
+		}
+		if stl.GetPay() != nil {
+			pay = stl.GetPay()
+			leaves = stl.GetLeaves()
+			master = kid
+			break
+		}
+	}
+
+	if pay == nil {
+		return []string{"no spacetime pay is proposed now"}, nil
+	}
+
+	start := pay.GetStart()
+	end := pay.GetStart() + pay.GetLength()
+	value := new(big.Int).SetBytes(pay.GetValue())
+
+	var res []string
+	res = append(res, fmt.Sprintf("spacetime pay from keeper %s: start %d, length %d, value %s", master, start, pay.GetLength(), utils.FormatWei(value)))
+
+	if len(leaves) > 0 {
+		if !bytes.Equal(role.GetSTRoot(leaves), pay.GetRoot()) {
+			res = append(res, "leaves do not match root of pay")
+		}
+		if role.GetSTAmount(price, leaves).Cmp(value) != 0 {
+			res = append(res, "leaves do not match value of pay")
+		}
+	}
+
+	// challenge logs of all keepers in period of pay
+	var logs []*mpb.ChalLog
+	for _, kid := range gp.keepers {
+		stl, err := p.getSTLeaves(ctx, gp, kid, strconv.FormatInt(start, 10), strconv.FormatInt(end, 10))
+		if err != nil {
+			res = append(res, fmt.Sprintf("get challenge logs from keeper %s fails: %s", kid, err))
+			continue
+		}
+		logs = append(logs, stl.GetLogs()...)
+	}
+
+	claim, err := role.BuildSTClaim(logs, gp.keepers, gp.groupID, p.localID, price, start, end)
+	if err != nil {
+		res = append(res, fmt.Sprintf("no enough signed challenge logs: %s", err))
+		return res, nil
+	}
+
+	spaces := make(map[int64]int64)
+	for _, cl := range claim.GetLogs() {
+		if cl.GetSuccessLength() > spaces[cl.GetChalTime()] {
+			spaces[cl.GetChalTime()] = cl.GetSuccessLength()
+		}
+	}
+
+	var unsigned, under int
+	for _, leaf := range leaves {
+		space, ok := spaces[leaf.GetTime()]
+		if !ok {
+			// filled leaf
+			if leaf.GetSpace() > 0 && leaf.GetTime() != start && leaf.GetTime() != end {
+				unsigned++
+			}
+			continue
+		}
+		if space > leaf.GetSpace() {
+			under++
+		}
+	}
+	res = append(res, fmt.Sprintf("%d signed challenge logs, %d leaves without signed log, %d leaves less than signed log", len(claim.GetLogs()), unsigned, under))
+
+	claimValue := new(big.Int).SetBytes(claim.GetValue())
+	if claimValue.Cmp(value) <= 0 {
+		res = append(res, fmt.Sprintf("pay is accepted, signed challenge logs show value %s", utils.FormatWei(claimValue)))
+		return res, nil
+	}
+
+	data, err := proto.Marshal(claim)
+	if err != nil {
+		return res, err
+	}
+
+	km, err := metainfo.NewKey(gp.groupID, mpb.KeyType_StPayDispute, gp.userID, p.localID)
+	if err != nil {
+		return res, err
+	}
+
+	accepted := 0
+	for _, kid := range gp.keepers {
+		ret, err := p.ds.SendMetaRequest(ctx, int32(mpb.OpType_Put), km.ToString(), data, nil, kid)
+		if err != nil || string(ret) != "ok" {
+			res = append(res, fmt.Sprintf("keeper %s rejects counter claim: %v", kid, err))
+			continue
+		}
+		accepted++
+	}
+
+	utils.MLogger.Infof("Counter claim of user %s fsID %s from %d, value %d is accepted by %d keepers", userID, groupID, start, claimValue, accepted)
+	res = append(res, fmt.Sprintf("counter claim with value %s is accepted by %d/%d keepers", utils.FormatWei(claimValue), accepted, len(gp.keepers)))
+	return res, nil
+}
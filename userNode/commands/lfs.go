@@ -3,6 +3,7 @@ package commands
 import (
 	"bufio"
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/gogo/protobuf/proto"
 	cmds "github.com/ipfs/go-ipfs-cmds"
 	files "github.com/ipfs/go-ipfs-files"
 	"github.com/memoio/go-mefs/contracts"
@@ -22,6 +24,7 @@ import (
 	"github.com/memoio/go-mefs/core/commands/e"
 	id "github.com/memoio/go-mefs/crypto/identity"
 	dataformat "github.com/memoio/go-mefs/data-format"
+	mpb "github.com/memoio/go-mefs/pb"
 	"github.com/memoio/go-mefs/repo/fsrepo"
 	"github.com/memoio/go-mefs/role"
 	"github.com/memoio/go-mefs/userNode/user"
@@ -176,27 +179,35 @@ var LfsCmd = &cmds.Command{
 	},
 
 	Subcommands: map[string]*cmds.Command{
-		"start":          lfsStartUserCmd,
-		"kill":           lfsKillUserCmd,
-		"online":         lfsOnlineCmd,
-		"info":           lfsInfoCmd,
-		"fsync":          lfsFsyncCmd,
-		"show_storage":   lfsShowStorageCmd,
-		"head_object":    lfsHeadObjectCmd,
-		"put_object":     lfsPutObjectCmd,
-		"get_object":     lfsGetObjectCmd,
-		"list_objects":   lfsListObjectsCmd,
-		"delete_object":  lfsDeleteObjectCmd,
-		"head_bucket":    lfsHeadBucketCmd,
-		"list_buckets":   lfsListBucketsCmd,
-		"create_bucket":  lfsCreateBucketCmd,
-		"delete_bucket":  lfsDeleteBucketCmd,
-		"list_keepers":   lfsListKeepersCmd,
-		"list_providers": lfsListProviderrsCmd,
-		"list_users":     lfsListUsersCmd,
-		"get_share":      lfsGetShareCmd,
-		"gen_share":      lfsGenShareCmd,
-		"add_provider":   lfsAddProviderCmd,
+		"start":            lfsStartUserCmd,
+		"kill":             lfsKillUserCmd,
+		"online":           lfsOnlineCmd,
+		"info":             lfsInfoCmd,
+		"fsync":            lfsFsyncCmd,
+		"show_storage":     lfsShowStorageCmd,
+		"head_object":      lfsHeadObjectCmd,
+		"put_object":       lfsPutObjectCmd,
+		"get_object":       lfsGetObjectCmd,
+		"list_objects":     lfsListObjectsCmd,
+		"delete_object":    lfsDeleteObjectCmd,
+		"head_bucket":      lfsHeadBucketCmd,
+		"list_buckets":     lfsListBucketsCmd,
+		"create_bucket":    lfsCreateBucketCmd,
+		"transcode_bucket": lfsTranscodeBucketCmd,
+		"delete_bucket":    lfsDeleteBucketCmd,
+		"list_keepers":     lfsListKeepersCmd,
+		"list_providers":   lfsListProviderrsCmd,
+		"list_users":       lfsListUsersCmd,
+		"get_share":        lfsGetShareCmd,
+		"gen_share":        lfsGenShareCmd,
+		"add_provider":     lfsAddProviderCmd,
+		"list_chal_log":    lfsListChalLogCmd,
+		"prove_object":     lfsProveObjectCmd,
+		"verify_object":    lfsVerifyObjectCmd,
+		"renew":            lfsRenewCmd,
+		"resize":           lfsResizeCmd,
+		"export":           lfsExportCmd,
+		"market":           lfsMarketCmd,
 	},
 }
 
@@ -206,6 +217,7 @@ const (
 	Policy       = "policy"
 	DataCount    = "datacount"
 	ParityCount  = "paritycount"
+	LocalCount   = "localcount"
 	ObjectName   = "objectname"
 	AddressID    = "address"
 	Encryption   = "encryption"
@@ -213,6 +225,7 @@ const (
 	AvailTime    = "availTime"
 	OutputPath   = "output"
 	ForceFlush   = "force" //设置这个选项，会强制刷新给Provider，无论是否表示为脏
+	Seal         = "seal"
 )
 
 var errTimeOut = errors.New("Time Out")
@@ -236,6 +249,7 @@ var lfsStartUserCmd = &cmds.Command{
 		cmds.BoolOption("reDeployQuery", "rdo", "reDeploy query contract if user has not deploy upkeeping contract").WithDefault(false),
 		cmds.BoolOption("force", "f", "force user to write mode").WithDefault(false),
 		cmds.IntOption("filesystem", "fs", "which filesystem").WithDefault(0),
+		cmds.StringOption("budget", "Most money user wants to pay for capacity and duration with redundancy, unit is wei; if set, keepers quote the cheapest placement of providerSla providers and spares within it, and its price is used").WithDefault(""),
 	},
 	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
 		node, err := cmdenv.GetNode(env)
@@ -406,12 +420,32 @@ var lfsStartUserCmd = &cmds.Command{
 			}
 		}
 
+		var quote *user.PlacementQuote
+		budgetStr, _ := req.Options["budget"].(string)
+		if len(budgetStr) > 0 {
+			budget, ok := new(big.Int).SetString(budgetStr, 10)
+			if !ok || budget.Sign() <= 0 {
+				fmt.Println("input wrong budget: ", budgetStr, ", budget should be positive integer")
+				return errWrongInput
+			}
+
+			quote, err = userIns.QuotePlacement(req.Context, uid, capacity, duration, ps, budget)
+			if err != nil {
+				return fmt.Errorf("no placement within budget %s: %s", utils.FormatWei(budget), err)
+			}
+			price = quote.Price
+		}
+
 		lfs, err := userIns.NewFS(uid, uid, qid, hexSk, capacity, duration, price, ks, ps, rdo, force)
 		if err != nil {
 			userIns.KillUser(uid)
 			return err
 		}
 
+		if quote != nil {
+			lfs.(*user.LfsInfo).GetGroup().SetPreferredProviders(quote.Providers)
+		}
+
 		err = lfs.Start(req.Context)
 		if err != nil {
 			userIns.KillUser(uid)
@@ -424,6 +458,9 @@ var lfsStartUserCmd = &cmds.Command{
 				"queryID is : " + qid,
 			},
 		}
+		if quote != nil {
+			list.ChildLists = append(list.ChildLists, fmt.Sprintf("price is : %s, estimated cost is : %s", quote.Price, utils.FormatWei(quote.Cost)))
+		}
 		return cmds.EmitOnce(res, list)
 	},
 	Type: StringList{},
@@ -1032,6 +1069,8 @@ var lfsHeadBucketCmd = &cmds.Command{
 	DataCount: 		Data count
 	ParityCount: 	Parity count
 
+With '--seal latin' or '--seal vdf', every replica of multiple backups is
+sealed by its own key, so that providers cannot dedupe replicas.
 `,
 	},
 
@@ -1108,7 +1147,7 @@ var lfsCreateBucketCmd = &cmds.Command{
 	BucketName: 	bucket's name
 	BucketID: 		bucket's ID
 	Ctime: 			Creation Time
-	Policy:			Erasure code or MultiReplication
+	Policy:			Erasure code, MultiReplication or Locally repairable code
 	DataCount: 		Data count
 	ParityCount: 	Parity count
 
@@ -1120,10 +1159,12 @@ var lfsCreateBucketCmd = &cmds.Command{
 	},
 	Options: []cmds.Option{
 		cmds.StringOption(AddressID, "addr", "The practice user's addressid that you want to exec").WithDefault(""),
-		cmds.IntOption(Policy, "pl", "Storage policy, '1' represent erasure code, '2' represent multiple backups, '1' is default").WithDefault(dataformat.RsPolicy),
+		cmds.IntOption(Policy, "pl", "Storage policy, '1' represent erasure code, '2' represent multiple backups, '3' represent locally repairable code, '1' is default").WithDefault(dataformat.RsPolicy),
 		cmds.BoolOption(Encryption, "encryp", "Encrypt the uploaded data or not").WithDefault(true),
 		cmds.IntOption(DataCount, "dc", "data count, dc + pc should not be larger than providers count").WithDefault(3),
 		cmds.IntOption(ParityCount, "pc", "parity count, we suggest parity_count >= 2").WithDefault(2),
+		cmds.IntOption(LocalCount, "lc", "local group count of locally repairable code, local parities are part of parity count").WithDefault(0),
+		cmds.StringOption(Seal, "seal", "Seal replicas by 'latin' or 'vdf', only for multiple backups").WithDefault(""),
 	},
 	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
 		node, err := cmdenv.GetNode(env)
@@ -1148,8 +1189,8 @@ var lfsCreateBucketCmd = &cmds.Command{
 			}
 		}
 		policy, ok := req.Options[Policy].(int)
-		if !ok || (policy != dataformat.MulPolicy && policy != dataformat.RsPolicy) {
-			fmt.Println("input wrong policy, policy should be 1 or 2")
+		if !ok || (policy != dataformat.MulPolicy && policy != dataformat.RsPolicy && policy != dataformat.LrcPolicy) {
+			fmt.Println("input wrong policy, policy should be 1, 2 or 3")
 			return errWrongInput
 		}
 		dataCount, ok := req.Options[DataCount].(int)
@@ -1162,6 +1203,11 @@ var lfsCreateBucketCmd = &cmds.Command{
 			fmt.Println("input wrong parityCount, parityCount should be positive integer")
 			return errWrongInput
 		}
+		localCount, ok := req.Options[LocalCount].(int)
+		if policy == dataformat.LrcPolicy && (!ok || localCount <= 0 || localCount >= parityCount || localCount > dataCount) {
+			fmt.Println("input wrong localCount, localCount should be positive, less than parityCount and no more than dataCount")
+			return errWrongInput
+		}
 		encryption, ok := req.Options[Encryption].(bool)
 		if !ok {
 			fmt.Println("input wrong encryption, encryption should be bool")
@@ -1177,12 +1223,28 @@ var lfsCreateBucketCmd = &cmds.Command{
 		bucketOptions.Policy = int32(policy)
 		bucketOptions.DataCount = int32(dataCount)
 		bucketOptions.ParityCount = int32(parityCount)
+		if policy == dataformat.LrcPolicy {
+			bucketOptions.LocalCount = int32(localCount)
+		}
 		if encryption {
 			bucketOptions.Encryption = 1
 		} else {
 			bucketOptions.Encryption = 0
 		}
 
+		seal, ok := req.Options[Seal].(string)
+		if ok && seal != "" {
+			if policy != dataformat.MulPolicy {
+				fmt.Println("seal is only used in multiple backups")
+				return errWrongInput
+			}
+			bucketOptions.TagFlag, err = dataformat.GetSealTagFlag(seal)
+			if err != nil {
+				fmt.Println("input wrong seal, seal should be 'latin' or 'vdf'")
+				return errWrongInput
+			}
+		}
+
 		bucket, err := lfs.CreateBucket(req.Context, req.Arguments[0], bucketOptions)
 		if err != nil {
 			return err
@@ -1212,6 +1274,122 @@ var lfsCreateBucketCmd = &cmds.Command{
 	},
 }
 
+var lfsTranscodeBucketCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "change redundancy policy of a bucket in lfs.",
+		ShortDescription: `
+'mefs lfs transcode_bucket' re-encodes stripes of a bucket to new policy,
+data count and parity count in background; the bucket is readable and
+writable during transcoding, and old stripes are deleted afterwards:
+
+	
+	Method: 		Transcode Bucket
+	BucketName: 	bucket's name
+	BucketID: 		bucket's ID
+	Ctime: 			Creation Time
+	Policy:			New policy
+	DataCount: 		New data count
+	ParityCount: 	New parity count
+
+`,
+	},
+
+	Arguments: []cmds.Argument{
+		cmds.StringArg("BucketName", true, false, "The bucket you want to transcode").EnableStdin(),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption(AddressID, "addr", "The practice user's addressid that you want to exec").WithDefault(""),
+		cmds.IntOption(Policy, "pl", "Storage policy, '1' represent erasure code, '2' represent multiple backups, '3' represent locally repairable code, '1' is default").WithDefault(dataformat.RsPolicy),
+		cmds.IntOption(DataCount, "dc", "data count, dc + pc should not be larger than providers count").WithDefault(3),
+		cmds.IntOption(ParityCount, "pc", "parity count, we suggest parity_count >= 2").WithDefault(2),
+		cmds.IntOption(LocalCount, "lc", "local group count of locally repairable code, local parities are part of parity count").WithDefault(0),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+		userIns, ok := node.Inst.(*user.Info)
+		if !ok {
+			return ErrNotReady
+		}
+		var userid string
+		addressid, found := req.Options[AddressID].(string)
+		if addressid == "" || !found {
+			userid = node.Identity.Pretty()
+		} else {
+			userid, err = address.GetIDFromAddress(addressid)
+			if err != nil {
+				return err
+			}
+		}
+		policy, ok := req.Options[Policy].(int)
+		if !ok || (policy != dataformat.MulPolicy && policy != dataformat.RsPolicy && policy != dataformat.LrcPolicy) {
+			fmt.Println("input wrong policy, policy should be 1, 2 or 3")
+			return errWrongInput
+		}
+		dataCount, ok := req.Options[DataCount].(int)
+		if !ok || dataCount <= 0 {
+			fmt.Println("input wrong dataCount, dataCount should be positive integer")
+			return errWrongInput
+		}
+		parityCount, ok := req.Options[ParityCount].(int)
+		if !ok || parityCount <= 0 {
+			fmt.Println("input wrong parityCount, parityCount should be positive integer")
+			return errWrongInput
+		}
+		localCount, ok := req.Options[LocalCount].(int)
+		if policy == dataformat.LrcPolicy && (!ok || localCount <= 0 || localCount >= parityCount || localCount > dataCount) {
+			fmt.Println("input wrong localCount, localCount should be positive, less than parityCount and no more than dataCount")
+			return errWrongInput
+		}
+
+		lfs := userIns.GetUser(userid)
+		if lfs == nil || !lfs.Online() {
+			return errLfsServiceNotReady
+		}
+
+		bucketOptions := &mpb.BucketOptions{
+			Policy:      int32(policy),
+			DataCount:   int32(dataCount),
+			ParityCount: int32(parityCount),
+		}
+		if policy == dataformat.LrcPolicy {
+			bucketOptions.LocalCount = int32(localCount)
+		}
+
+		bucket, err := lfs.TranscodeBucket(req.Context, req.Arguments[0], bucketOptions)
+		if err != nil {
+			return err
+		}
+
+		ctime := time.Unix(bucket.GetCTime(), 0).In(time.Local)
+		bucketStat := BucketStat{
+			Name:        bucket.Name,
+			BucketID:    bucket.BucketID,
+			Ctime:       ctime.Format(utils.SHOWTIME),
+			Policy:      bucket.BOpts.Policy,
+			DataCount:   bucket.BOpts.DataCount,
+			ParityCount: bucket.BOpts.ParityCount,
+			Encryption:  bucket.BOpts.Encryption,
+		}
+		return cmds.EmitOnce(res, &Buckets{
+			Method:  "Transcode Bucket",
+			Buckets: []BucketStat{bucketStat},
+		})
+	},
+	Type: Buckets{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, bus *Buckets) error {
+			_, err := fmt.Fprintf(w, "%s", bus)
+			return err
+		}),
+	},
+}
+
 var lfsListBucketsCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "List buckets in lfs.",
@@ -1754,6 +1932,7 @@ type ukInfo struct {
 	Price         string
 	TotalBytes    string
 	UsedBytes     string
+	Warning       string
 }
 
 type keeperInfo struct {
@@ -1897,12 +2076,13 @@ var lfsInfoCmd = &cmds.Command{
 			UpKeepingAddr: ukaddr.String(),
 			StartTime:     time.Unix(uk.StartTime, 0).In(time.Local).Format(utils.SHOWTIME),
 			EndTime:       time.Unix(uk.EndTime, 0).In(time.Local).Format(utils.SHOWTIME),
-			TotalBytes:    utils.FormatBytes(uk.Capacity * 1024 * 1024),
+			TotalBytes:    utils.FormatBytes(gp.GetCapacity() * 1024 * 1024),
 			Duration:      utils.FormatSecond(uk.Duration),
 			Price:         utils.FormatStorePrice(uk.Price),
 			UkBalance:     utils.FormatWei(uk.Money),
 			NeedPay:       utils.FormatWei(uk.NeedPay),
 			UsedBytes:     utils.FormatBytes(int64(storageSize)),
+			Warning:       gp.GetExpireWarning(),
 		}
 
 		output := &infoOutput{
@@ -2269,3 +2449,567 @@ var lfsAddProviderCmd = &cmds.Command{
 		}),
 	},
 }
+
+var lfsListChalLogCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List challenge logs of lfs.",
+		ShortDescription: `
+'mefs-user lfs list_chal_log' is a plumbing command for printing the audit logs of
+challenges on user's data in recent days, which are collected from keepers and verified
+by keepers' signatures.
+`,
+	},
+
+	Arguments: []cmds.Argument{},
+	Options: []cmds.Option{
+		cmds.StringOption(AddressID, "addr", "The practice user's addressid that you want to exec").WithDefault(""),
+		cmds.StringOption("provider", "pro", "Only list logs of this provider").WithDefault(""),
+		cmds.IntOption("days", "d", "List logs in recent days").WithDefault(1),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+		userIns, ok := node.Inst.(*user.Info)
+		if !ok {
+			return ErrNotReady
+		}
+		var userid string
+		addressid, found := req.Options[AddressID].(string)
+		if addressid == "" || !found {
+			userid = node.Identity.Pretty()
+		} else {
+			userid, err = address.GetIDFromAddress(addressid)
+			if err != nil {
+				return err
+			}
+		}
+
+		lfs := userIns.GetUser(userid)
+		lfsIns, ok := lfs.(*user.LfsInfo)
+		if !ok || !lfsIns.Online() {
+			return errLfsServiceNotReady
+		}
+
+		proID, _ := req.Options["provider"].(string)
+		days, _ := req.Options["days"].(int)
+		end := time.Now().Unix()
+		start := end - int64(days)*24*60*60
+
+		cls, err := lfsIns.GetChalLogs(req.Context, proID, start, end)
+		if err != nil {
+			return err
+		}
+
+		stringList := make([]string, 0, len(cls))
+		for _, cl := range cls {
+			stringList = append(stringList, role.FormatChalLog(cl))
+		}
+
+		list := &StringList{
+			ChildLists: stringList,
+		}
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, fl *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", fl)
+			return err
+		}),
+	},
+}
+
+// ObjectProofStat is proof of an object and what it proves
+type ObjectProofStat struct {
+	Name     string
+	Size     int64
+	MD5      string
+	Ctime    string
+	RootTime string
+	Deletion bool
+	Proof    string // hex of marshaled proof
+}
+
+func (ops ObjectProofStat) String() string {
+	return fmt.Sprintf("Name: %s\nSize: %d\nMD5: %s\nCtime: %s\nRootTime: %s\nDeletion: %t\nProof: %s\n",
+		ops.Name, ops.Size, ops.MD5, ops.Ctime, ops.RootTime, ops.Deletion, ops.Proof)
+}
+
+func newObjectProofStat(oi *mpb.ObjectInfo, pf *mpb.ObjectProof) (*ObjectProofStat, error) {
+	pbyte, err := proto.Marshal(pf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectProofStat{
+		Name:     oi.GetInfo().GetName(),
+		Size:     oi.GetLength(),
+		MD5:      oi.GetETag(),
+		Ctime:    time.Unix(oi.GetCTime(), 0).In(time.Local).Format(utils.SHOWTIME),
+		RootTime: time.Unix(pf.GetCTime(), 0).In(time.Local).Format(utils.SHOWTIME),
+		Deletion: oi.GetDeletion(),
+		Proof:    hex.EncodeToString(pbyte),
+	}, nil
+}
+
+var lfsProveObjectCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Prove existence and etag of a lfs object.",
+		ShortDescription: `
+'mefs-user lfs prove_object' is a plumbing command for printing a proof of the object,
+which links its op records to the bucket root, the lfs root and the merkle root kept in
+root contract at RootTime. Anyone can check the proof with 'mefs-user lfs verify_object'.
+`,
+	},
+
+	Arguments: []cmds.Argument{
+		cmds.StringArg("BucketName", true, false, "The Bucket's name that object in."),
+		cmds.StringArg("ObjectName", true, false, "The Object's Name"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption(AddressID, "addr", "The practice user's addressid that you want to exec").WithDefault(""),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+		userIns, ok := node.Inst.(*user.Info)
+		if !ok {
+			return ErrNotReady
+		}
+		var userid string
+		addressid, found := req.Options[AddressID].(string)
+		if addressid == "" || !found {
+			userid = node.Identity.Pretty()
+		} else {
+			userid, err = address.GetIDFromAddress(addressid)
+			if err != nil {
+				return err
+			}
+		}
+
+		lfs := userIns.GetUser(userid)
+		lfsIns, ok := lfs.(*user.LfsInfo)
+		if !ok {
+			return errLfsServiceNotReady
+		}
+
+		pf, err := lfsIns.ProveObject(req.Context, req.Arguments[0], req.Arguments[1])
+		if err != nil {
+			return err
+		}
+
+		// check it before output
+		oi, err := user.VerifyObjectProof(pf)
+		if err != nil {
+			return err
+		}
+
+		ops, err := newObjectProofStat(oi, pf)
+		if err != nil {
+			return err
+		}
+		return cmds.EmitOnce(res, ops)
+	},
+	Type: ObjectProofStat{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, ops *ObjectProofStat) error {
+			_, err := fmt.Fprintf(w, "%s", ops)
+			return err
+		}),
+	},
+}
+
+var lfsVerifyObjectCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Verify a proof of lfs object.",
+		ShortDescription: `
+'mefs-user lfs verify_object' is a plumbing command for checking a proof printed by
+'mefs-user lfs prove_object' against the chain only; it prints the proved object.
+`,
+	},
+
+	Arguments: []cmds.Argument{
+		cmds.StringArg("Proof", true, false, "The proof in hex"),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		pbyte, err := hex.DecodeString(req.Arguments[0])
+		if err != nil {
+			return errWrongInput
+		}
+
+		pf := new(mpb.ObjectProof)
+		err = proto.Unmarshal(pbyte, pf)
+		if err != nil {
+			return errWrongInput
+		}
+
+		oi, err := user.VerifyObjectProof(pf)
+		if err != nil {
+			return err
+		}
+
+		ops, err := newObjectProofStat(oi, pf)
+		if err != nil {
+			return err
+		}
+		return cmds.EmitOnce(res, ops)
+	},
+	Type: ObjectProofStat{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, ops *ObjectProofStat) error {
+			_, err := fmt.Fprintf(w, "%s", ops)
+			return err
+		}),
+	},
+}
+
+var lfsRenewCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Renew upkeeping of lfs.",
+		ShortDescription: `
+'mefs-user lfs renew' is a plumbing command for topping up upkeeping and extending its
+end time by days. With '--auto=true', lfs renews itself by days before upkeeping expires
+until lfs is killed; '--auto=false' turns it off.
+`,
+	},
+
+	Arguments: []cmds.Argument{
+		cmds.StringArg("Days", true, false, "Days to extend"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption(AddressID, "addr", "The practice user's addressid that you want to exec").WithDefault(""),
+		cmds.StringOption("auto", "Renew automatically before expiry, 'true' or 'false'; renew now if not set").WithDefault(""),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+		userIns, ok := node.Inst.(*user.Info)
+		if !ok {
+			return ErrNotReady
+		}
+		var userid string
+		addressid, found := req.Options[AddressID].(string)
+		if addressid == "" || !found {
+			userid = node.Identity.Pretty()
+		} else {
+			userid, err = address.GetIDFromAddress(addressid)
+			if err != nil {
+				return err
+			}
+		}
+
+		days, err := strconv.ParseInt(req.Arguments[0], 10, 64)
+		if err != nil || days <= 0 {
+			return errWrongInput
+		}
+
+		lfs := userIns.GetUser(userid)
+		if lfs == nil || !lfs.Online() {
+			return errLfsServiceNotReady
+		}
+
+		lfsIns, ok := lfs.(*user.LfsInfo)
+		if !ok {
+			return errLfsServiceNotReady
+		}
+
+		gp := lfsIns.GetGroup()
+		if gp == nil {
+			return errLfsServiceNotReady
+		}
+
+		var msg string
+		auto, _ := req.Options["auto"].(string)
+		switch auto {
+		case "true":
+			err = gp.SetAutoRenew(req.Context, true, days)
+			if err != nil {
+				return err
+			}
+			msg = fmt.Sprintf("lfs will be renewed %d days automatically before expiry", days)
+		case "false":
+			err = gp.SetAutoRenew(req.Context, false, 0)
+			if err != nil {
+				return err
+			}
+			msg = "auto renew is turned off"
+		case "":
+			err = gp.Renew(req.Context, days)
+			if err != nil {
+				return err
+			}
+			msg = fmt.Sprintf("upkeeping is extended to %s", time.Unix(gp.GetUk().EndTime, 0).In(time.Local).Format(utils.SHOWTIME))
+		default:
+			return errWrongInput
+		}
+
+		list := &StringList{
+			ChildLists: []string{msg},
+		}
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, fl *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", fl)
+			return err
+		}),
+	},
+}
+
+var lfsResizeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Upgrade capacity of lfs.",
+		ShortDescription: `
+'mefs-user lfs resize' is a plumbing command for upgrading capacity of lfs to size(MB),
+which tops up current upkeeping with cost of the added capacity until its end time.
+`,
+	},
+
+	Arguments: []cmds.Argument{
+		cmds.StringArg("Size", true, false, "New capacity in MB"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption(AddressID, "addr", "The practice user's addressid that you want to exec").WithDefault(""),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+		userIns, ok := node.Inst.(*user.Info)
+		if !ok {
+			return ErrNotReady
+		}
+		var userid string
+		addressid, found := req.Options[AddressID].(string)
+		if addressid == "" || !found {
+			userid = node.Identity.Pretty()
+		} else {
+			userid, err = address.GetIDFromAddress(addressid)
+			if err != nil {
+				return err
+			}
+		}
+
+		size, err := strconv.ParseInt(req.Arguments[0], 10, 64)
+		if err != nil || size <= 0 {
+			return errWrongInput
+		}
+
+		lfs := userIns.GetUser(userid)
+		if lfs == nil || !lfs.Online() {
+			return errLfsServiceNotReady
+		}
+
+		lfsIns, ok := lfs.(*user.LfsInfo)
+		if !ok {
+			return errLfsServiceNotReady
+		}
+
+		gp := lfsIns.GetGroup()
+		if gp == nil {
+			return errLfsServiceNotReady
+		}
+
+		err = gp.Resize(req.Context, size)
+		if err != nil {
+			return err
+		}
+
+		uk := gp.GetUk()
+		ukaddr, err := address.GetAddressFromID(uk.UpKeepingID)
+		if err != nil {
+			return err
+		}
+
+		list := &StringList{
+			ChildLists: []string{fmt.Sprintf("capacity is %s in upkeeping %s", utils.FormatBytes(gp.GetCapacity()*1024*1024), ukaddr.String())},
+		}
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, fl *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", fl)
+			return err
+		}),
+	},
+}
+
+var lfsExportCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Export all buckets of lfs.",
+		ShortDescription: `
+'mefs-user lfs export' is a plumbing command for copying objects of all buckets to
+Dir/BucketName/ObjectName on the node running daemon, or into another lfs started
+on this node by option target; objects already exported are skipped. Use it to move
+data out before upkeeping ends and keepers delete data after grace period.
+`,
+	},
+
+	Arguments: []cmds.Argument{
+		cmds.StringArg("Dir", false, false, "Local directory to export to"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption(AddressID, "addr", "The practice user's addressid that you want to exec").WithDefault(""),
+		cmds.StringOption("target", "The addressid of lfs which objects are exported to").WithDefault(""),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+		userIns, ok := node.Inst.(*user.Info)
+		if !ok {
+			return ErrNotReady
+		}
+		var userid string
+		addressid, found := req.Options[AddressID].(string)
+		if addressid == "" || !found {
+			userid = node.Identity.Pretty()
+		} else {
+			userid, err = address.GetIDFromAddress(addressid)
+			if err != nil {
+				return err
+			}
+		}
+
+		dir := ""
+		if len(req.Arguments) > 0 {
+			dir = req.Arguments[0]
+		}
+
+		var target *user.LfsInfo
+		targetAddr, _ := req.Options["target"].(string)
+		if targetAddr != "" {
+			targetID, err := address.GetIDFromAddress(targetAddr)
+			if err != nil {
+				return err
+			}
+			tlfs := userIns.GetUser(targetID)
+			if tlfs == nil || !tlfs.Online() {
+				return errLfsServiceNotReady
+			}
+			target = tlfs.(*user.LfsInfo)
+		}
+
+		if (dir == "") == (target == nil) {
+			return errWrongInput
+		}
+
+		lfs := userIns.GetUser(userid)
+		if lfs == nil || !lfs.Online() {
+			return errLfsServiceNotReady
+		}
+
+		stat, err := lfs.(*user.LfsInfo).Export(req.Context, dir, target)
+		if err != nil {
+			return err
+		}
+
+		list := &StringList{
+			ChildLists: []string{fmt.Sprintf("exported %d buckets, %d objects, %s; skipped %d objects, failed %d objects", stat.Buckets, stat.Objects, utils.FormatBytes(stat.Size), stat.Skipped, stat.Failed)},
+		}
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, fl *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", fl)
+			return err
+		}),
+	},
+}
+
+var lfsMarketCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List offers of providers.",
+		ShortDescription: `
+'mefs-user lfs market' is a plumbing command for listing the latest offers of providers
+known by keepers, with capacity, duration, price, credit and location, cheapest first.
+If capacity and providerSla are set, keepers also quote the cheapest placement and its
+cost, which is used by 'mefs-user lfs start --budget'.
+`,
+	},
+
+	Options: []cmds.Option{
+		cmds.StringOption(AddressID, "addr", "The practice user's addressid that you want to exec").WithDefault(""),
+		cmds.Int64Option("capacity", "cap", "Size user wants to store, unit is MB").WithDefault(0),
+		cmds.Int64Option("duration", "dur", "Time user wants to store, unit is day").WithDefault(utils.DefaultDuration),
+		cmds.IntOption("providerSla", "ps", "How many providers user needs").WithDefault(0),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		node, err := cmdenv.GetNode(env)
+		if err != nil {
+			return err
+		}
+		if !node.OnlineMode() {
+			return ErrNotOnline
+		}
+		userIns, ok := node.Inst.(*user.Info)
+		if !ok {
+			return ErrNotReady
+		}
+		var userid string
+		addressid, found := req.Options[AddressID].(string)
+		if addressid == "" || !found {
+			userid = node.Identity.Pretty()
+		} else {
+			userid, err = address.GetIDFromAddress(addressid)
+			if err != nil {
+				return err
+			}
+		}
+
+		offers, err := userIns.GetMarket(req.Context, userid)
+		if err != nil {
+			return err
+		}
+
+		list := &StringList{}
+		for _, offer := range offers {
+			list.ChildLists = append(list.ChildLists, fmt.Sprintf("%s: price %s, capacity %s, duration %d days, credit %d, online %t, location %s, known by %d keepers", offer.ProviderID, offer.Price, utils.FormatBytes(offer.Capacity*1024*1024), offer.Duration/86400, offer.Credit, offer.Online, offer.Location, offer.Keepers))
+		}
+
+		capacity, _ := req.Options["capacity"].(int64)
+		duration, _ := req.Options["duration"].(int64)
+		ps, _ := req.Options["providerSla"].(int)
+		if capacity > 0 && ps > 0 {
+			quote, err := userIns.QuotePlacement(req.Context, userid, capacity, duration, ps, nil)
+			if err != nil {
+				return err
+			}
+			list.ChildLists = append(list.ChildLists, fmt.Sprintf("cheapest placement from keeper %s: price %s, estimated cost %s, providers %v", quote.Keeper, quote.Price, utils.FormatWei(quote.Cost), quote.Providers))
+		}
+		return cmds.EmitOnce(res, list)
+	},
+	Type: StringList{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, fl *StringList) error {
+			_, err := fmt.Fprintf(w, "%s", fl)
+			return err
+		}),
+	},
+}
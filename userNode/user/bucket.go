@@ -46,34 +46,17 @@ func (l *LfsInfo) CreateBucket(ctx context.Context, bucketName string, options *
 		return nil, ErrLfsReadOnly
 	}
 
-	// 多副本策略
-	switch options.Policy {
-	case dataformat.MulPolicy:
-		Sum := options.DataCount + options.ParityCount
-		options.DataCount = 1
-		options.ParityCount = Sum - 1
-	case dataformat.RsPolicy:
-	default:
-		return nil, ErrPolicy
-	}
-
-	if options.DataCount < 1 || options.ParityCount < 1 {
-		return nil, ErrWrongParameters
-	}
-
-	// datacount + parityCount should <= providerSLA
-	if options.DataCount+options.ParityCount > int32(l.gInfo.providerSLA) {
-		utils.MLogger.Errorf("data and parity count are %d, should not larger than provider number %d", options.DataCount+options.ParityCount, l.gInfo.providerSLA)
-		return nil, ErrPolicy
+	err := l.checkBucketOptions(options)
+	if err != nil {
+		return nil, err
 	}
 
-	// tagCount决定了最大的segmentSize
-	if int64(options.GetSegmentSize()) > 32*l.keySet.PublicKey().GetCount() {
-		utils.MLogger.Errorf("segmentSize is set larger than: %d", 32*l.keySet.PublicKey().GetCount())
-		return nil, ErrPolicy
+	err = l.setSealIDs(ctx, options)
+	if err != nil {
+		return nil, err
 	}
 
-	err := checkBucketName(bucketName)
+	err = checkBucketName(bucketName)
 	if err != nil {
 		utils.MLogger.Errorf("bucketName %s is not valid %s", bucketName, err)
 		return nil, ErrBucketNameInvalid
@@ -104,7 +87,7 @@ func (l *LfsInfo) CreateBucket(ctx context.Context, bucketName string, options *
 	bucket := newsuperBucket(binfo, true)
 
 	bucket.mtree.SetIndex(0)
-	bucket.mtree.Push([]byte(l.fsID + strconv.FormatInt(bucketID, 10)))
+	bucket.mtree.Push(bucketInitLeaf(l.fsID, bucketID))
 
 	//将此Bucket信息添加到LFS中
 	l.meta.sb.NextBucketID++
@@ -125,6 +108,66 @@ func (l *LfsInfo) CreateBucket(ctx context.Context, bucketName string, options *
 	return &bucket.BucketInfo, nil
 }
 
+// checkBucketOptions verifies options of bucket, and converts counts of
+// multiple replicas
+func (l *LfsInfo) checkBucketOptions(options *mpb.BucketOptions) error {
+	// 多副本策略
+	switch options.Policy {
+	case dataformat.MulPolicy:
+		Sum := options.DataCount + options.ParityCount
+		options.DataCount = 1
+		options.ParityCount = Sum - 1
+	case dataformat.RsPolicy:
+	case dataformat.LrcPolicy:
+		// local parities are part of parity count, at least one is global
+		if options.LocalCount < 1 || options.LocalCount >= options.ParityCount || options.LocalCount > options.DataCount {
+			return ErrWrongParameters
+		}
+	default:
+		return ErrPolicy
+	}
+
+	if options.DataCount < 1 || options.ParityCount < 1 {
+		return ErrWrongParameters
+	}
+
+	// 密封副本仅用于多副本策略
+	if dataformat.IsSealed(options.TagFlag) && options.Policy != dataformat.MulPolicy {
+		return ErrPolicy
+	}
+
+	// datacount + parityCount should <= providerSLA
+	if options.DataCount+options.ParityCount > int32(l.gInfo.providerSLA) {
+		utils.MLogger.Errorf("data and parity count are %d, should not larger than provider number %d", options.DataCount+options.ParityCount, l.gInfo.providerSLA)
+		return ErrPolicy
+	}
+
+	// tagCount决定了最大的segmentSize
+	if int64(options.GetSegmentSize()) > 32*l.keySet.PublicKey().GetCount() {
+		utils.MLogger.Errorf("segmentSize is set larger than: %d", 32*l.keySet.PublicKey().GetCount())
+		return ErrPolicy
+	}
+
+	return nil
+}
+
+// setSealIDs binds sealed replicas of bucket to providers, chunk i of each
+// stripe is sealed for and stored on the ith of them
+func (l *LfsInfo) setSealIDs(ctx context.Context, options *mpb.BucketOptions) error {
+	options.SealIDs = nil
+	if !dataformat.IsSealed(options.TagFlag) {
+		return nil
+	}
+
+	pros, _, err := l.gInfo.GetProviders(ctx, int(options.DataCount+options.ParityCount))
+	if err != nil {
+		return err
+	}
+
+	options.SealIDs = pros
+	return nil
+}
+
 // DeleteBucket deletes a bucket from a specified LFSservice
 func (l *LfsInfo) DeleteBucket(ctx context.Context, bucketName string) (*mpb.BucketInfo, error) {
 	//操作需要1资源
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// GetChalLogs gets challenge logs between start and end from all keepers;
+// logs not signed by the keeper which returns them are dropped
+func (l *LfsInfo) GetChalLogs(ctx context.Context, proID string, start, end int64) ([]*mpb.ChalLog, error) {
+	gp := l.gInfo
+	conkeepers, _, err := gp.GetKeepers(ctx, -1)
+	if err != nil {
+		return nil, err
+	}
+	if len(conkeepers) == 0 {
+		return nil, ErrNoKeepers
+	}
+
+	// key: qid/"ChalLog"/uid/start/end
+	km, err := metainfo.NewKey(gp.groupID, mpb.KeyType_ChalLog, gp.userID, utils.UnixToString(start), utils.UnixToString(end))
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*mpb.ChalLog
+	for _, keeper := range conkeepers {
+		val, err := l.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), km.ToString(), nil, nil, keeper)
+		if err != nil {
+			utils.MLogger.Warnf("get challenge logs from keeper %s fails: %s", keeper, err)
+			continue
+		}
+
+		cll := new(mpb.ChalLogList)
+		err = proto.Unmarshal(val, cll)
+		if err != nil {
+			continue
+		}
+
+		for _, cl := range cll.GetLogs() {
+			if cl.GetKeeperID() != keeper || cl.GetUserID() != gp.userID || cl.GetQueryID() != gp.groupID {
+				continue
+			}
+
+			if proID != "" && cl.GetProviderID() != proID {
+				continue
+			}
+
+			if role.VerifyChalLog(cl) != nil {
+				utils.MLogger.Warnf("challenge log at %d from keeper %s has wrong sign", cl.GetChalTime(), keeper)
+				continue
+			}
+
+			res = append(res, cl)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].GetChalTime() < res[j].GetChalTime()
+	})
+
+	return res, nil
+}
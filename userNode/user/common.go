@@ -41,6 +41,7 @@ var (
 	ErrBucketAlreadyExist = errors.New("bucket already exists")
 	ErrBucketNotEmpty     = errors.New("bucket is not empty")
 	ErrBucketNameInvalid  = errors.New("bucket name is invalid")
+	ErrBucketTranscoding  = errors.New("bucket is transcoding")
 
 	ErrObjectNotExist       = errors.New("object not exist")
 	ErrObjectAlreadyExist   = errors.New("object already exist")
@@ -18,6 +18,7 @@ import (
 	dataformat "github.com/memoio/go-mefs/data-format"
 	mpb "github.com/memoio/go-mefs/pb"
 	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/source/data"
 	bf "github.com/memoio/go-mefs/source/go-block-format"
 	"github.com/memoio/go-mefs/utils"
 	"github.com/memoio/go-mefs/utils/metainfo"
@@ -101,22 +102,9 @@ func (l *LfsInfo) GetObject(ctx context.Context, bucketName, objectName string,
 
 	bo := bucket.BOpts
 
-	bopt := &mpb.BlockOptions{
-		Bopts:   bo,
-		Start:   0,
-		UserID:  l.userID,
-		QueryID: l.fsID,
-	}
-
-	decoder, err := dataformat.NewDataCoderWithPrefix(l.keySet, bopt)
-	if err != nil {
-		return err
-	}
-
 	dl := &downloadTask{
 		bucketID:     bucket.BucketID,
 		group:        l.gInfo,
-		decoder:      decoder,
 		startTime:    time.Now(),
 		length:       length,
 		writer:       writer,
@@ -137,12 +125,29 @@ func (l *LfsInfo) GetObject(ctx context.Context, bucketName, objectName string,
 			}
 			return ErrObjectOptionsInvalid
 		}
+		// parts may be in stripes of different options during transcoding
+		bopt := &mpb.BlockOptions{
+			Bopts:   bucket.partOptions(object.Parts[i]),
+			Start:   0,
+			UserID:  l.userID,
+			QueryID: l.fsID,
+		}
+
+		decoder, err := dataformat.NewDataCoderWithPrefix(l.keySet, bopt)
+		if err != nil {
+			for _, f := range completeFuncs {
+				f(err)
+			}
+			return err
+		}
+		dl.decoder = decoder
+
 		dl.start = opStart + object.Parts[i].GetStart()
 		dl.length = object.Parts[i].GetLength() - opStart
 		if length-readLen < dl.length {
 			dl.length = length - readLen
 		}
-		err := dl.Start(ctx)
+		err = dl.Start(ctx)
 		if err != nil {
 			for _, f := range completeFuncs {
 				f(err)
@@ -314,7 +319,7 @@ func (do *downloadTask) rangeRead(ctx context.Context, start, length int64) ([]b
 		return nil, 0, err
 	}
 
-	eachLen := preLen + segNeed*(int(segSize)+int(2+(parityCount-1)/dataCount)*tagSize)
+	eachLen := preLen + segNeed*(int(segSize)+dataformat.TagCount(do.decoder.Prefix.Bopts)*tagSize)
 
 	bm, err := metainfo.NewBlockMeta(do.group.groupID, strconv.Itoa(int(do.bucketID)), strconv.Itoa(int(curStripe)), "")
 	if err != nil {
@@ -322,6 +327,13 @@ func (do *downloadTask) rangeRead(ctx context.Context, start, length int64) ([]b
 		return nil, 0, err
 	}
 
+	// decoded segments of hot stripes are cached
+	rc := do.group.ds.ReadCache()
+	cgroup := bm.ToString(3)
+	if data, ok := do.getCachedSegs(rc, cgroup, segStart, segNeed); ok {
+		return data, int64(len(data)), nil
+	}
+
 	needRepair := false //是否需要修复
 	datas := make([][]byte, blockCount)
 
@@ -390,7 +402,7 @@ func (do *downloadTask) rangeRead(ctx context.Context, start, length int64) ([]b
 			pinfo.Lock()
 
 			//user给channel合约签名，发给provider
-			mes, money, err := do.getChannelSign(pinfo, eachLen)
+			mes, money, err := do.group.getChannelSign(pinfo, eachLen)
 			if err != nil {
 				if do.group.userID != do.group.groupID {
 					utils.MLogger.Warnf("get channel fails: %s", err)
@@ -481,22 +493,44 @@ func (do *downloadTask) rangeRead(ctx context.Context, start, length int64) ([]b
 
 	utils.MLogger.Debugf("Download get length: %d, need %d, from %d", len(data), length, start)
 
+	for i := 0; int64(i+1)*segStripeSize <= int64(len(data)); i++ {
+		rc.Add(cgroup, do.segCacheKey(segStart+i), data[int64(i)*segStripeSize:int64(i+1)*segStripeSize])
+	}
+
 	return data, int64(len(data)), nil
 }
 
-func (do *downloadTask) getChannelSign(pInfo *providerInfo, readLen int) ([]byte, *big.Int, error) {
+// segCacheKey returns key of decoded segment in read cache; segments of
+// stripes in other options, e.g. during transcoding, have other keys
+func (do *downloadTask) segCacheKey(seg int) string {
+	bo := do.decoder.Prefix.Bopts
+	return strconv.Itoa(int(bo.DataCount)) + metainfo.BlockDelimiter + strconv.Itoa(int(bo.SegmentSize)) +
+		metainfo.BlockDelimiter + strconv.Itoa(int(bo.SegmentCount)) + metainfo.BlockDelimiter + strconv.Itoa(seg)
+}
+
+// getCachedSegs returns decoded segNeed segments from segStart of stripe,
+// if all of them are in read cache
+func (do *downloadTask) getCachedSegs(rc *data.ReadCache, cgroup string, segStart, segNeed int) ([]byte, bool) {
+	var res []byte
+	for i := segStart; i < segStart+segNeed; i++ {
+		seg, ok := rc.Get(cgroup, do.segCacheKey(i))
+		if !ok {
+			return nil, false
+		}
+		res = append(res, seg...)
+	}
+	return res, true
+}
+
+// getChannelSign signs channel of provider with value added by cost of reading
+// readLen bytes; channel is redeployed if its money is not enough
+func (g *groupInfo) getChannelSign(pInfo *providerInfo, readLen int) ([]byte, *big.Int, error) {
 	cItem := pInfo.chanItem
-	hexSK := do.group.privKey
-	channelID := do.group.groupID
+	hexSK := g.privKey
+	channelID := g.groupID
 
 	if cItem != nil {
-		readPrice := big.NewInt(utils.READPRICE)
-		weiRPrice := new(big.Float).SetInt64(utils.READPRICE)
-		weiRPrice.Quo(weiRPrice, contracts.GetMemoPrice())
-		weiRPrice.Int(readPrice)
-
-		readPrice.Mul(readPrice, big.NewInt(int64(readLen)))
-		readPrice.Quo(readPrice, big.NewInt(1024*1024))
+		readPrice := readCost(readLen)
 
 		newValue := new(big.Int).Add(readPrice, cItem.Value)
 		if newValue.Cmp(cItem.Money) > 0 {
@@ -504,7 +538,7 @@ func (do *downloadTask) getChannelSign(pInfo *providerInfo, readLen int) ([]byte
 
 			oldChanID := cItem.ChannelID
 
-			chanID, err := role.DeployChannel(do.group.shareToID, do.group.groupID, pInfo.providerID, do.group.privKey, do.group.storeDays, do.group.storeSize/int64(do.group.providerSLA), true)
+			chanID, err := role.DeployChannel(g.shareToID, g.groupID, pInfo.providerID, g.privKey, g.storeDays, g.storeSize/int64(g.providerSLA), true)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -514,7 +548,7 @@ func (do *downloadTask) getChannelSign(pInfo *providerInfo, readLen int) ([]byte
 				return nil, nil, role.ErrEmptyData
 			}
 
-			gotItem, err := role.GetChannelInfo(do.group.shareToID, chanID)
+			gotItem, err := role.GetChannelInfo(g.shareToID, chanID)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -542,3 +576,15 @@ func (do *downloadTask) getChannelSign(pInfo *providerInfo, readLen int) ([]byte
 	}
 	return nil, nil, role.ErrTestUser
 }
+
+// readCost returns money(wei) for reading readLen bytes from provider
+func readCost(readLen int) *big.Int {
+	readPrice := big.NewInt(utils.READPRICE)
+	weiRPrice := new(big.Float).SetInt64(utils.READPRICE)
+	weiRPrice.Quo(weiRPrice, contracts.GetMemoPrice())
+	weiRPrice.Int(readPrice)
+
+	readPrice.Mul(readPrice, big.NewInt(int64(readLen)))
+	readPrice.Quo(readPrice, big.NewInt(1024*1024))
+	return readPrice
+}
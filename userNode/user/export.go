@@ -0,0 +1,172 @@
+package user
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/memoio/go-mefs/utils"
+)
+
+// ExportStat summarizes an export of lfs
+type ExportStat struct {
+	Buckets int
+	Objects int
+	Skipped int // objects already in destination
+	Failed  int
+	Size    int64
+}
+
+// Export copies objects of all buckets to dir/bucketName/objectName, or into
+// buckets of the same names in target if it is not nil; objects already in
+// destination are skipped, so an interrupted export can be run again.
+// It is used to move data out before upkeeping ends and the lfs is destructed.
+func (l *LfsInfo) Export(ctx context.Context, dir string, target *LfsInfo) (*ExportStat, error) {
+	if !l.Online() || l.meta.buckets == nil {
+		return nil, ErrLfsServiceNotReady
+	}
+
+	if target != nil && (target == l || !target.Online()) {
+		return nil, ErrLfsServiceNotReady
+	}
+
+	var names []string
+	for name, bucket := range l.meta.buckets {
+		if bucket == nil || bucket.Deletion {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stat := new(ExportStat)
+	for _, bucketName := range names {
+		bucket := l.meta.buckets[bucketName]
+		if target != nil {
+			_, err := target.HeadBucket(ctx, bucketName)
+			if err == ErrBucketNotExist {
+				_, err = target.CreateBucket(ctx, bucketName, bucket.BOpts)
+			}
+			if err != nil {
+				return stat, err
+			}
+		} else {
+			bpath, err := exportPath(dir, bucketName)
+			if err != nil {
+				return stat, ErrBucketNameInvalid
+			}
+
+			err = os.MkdirAll(bpath, 0755)
+			if err != nil {
+				return stat, err
+			}
+		}
+
+		var objects []*ObjectInfo
+		bucket.RLock()
+		objectIter := bucket.Objects.Iterator()
+		for objectIter != nil {
+			object := objectIter.Value.(*ObjectInfo)
+			if !object.GetDeletion() {
+				objects = append(objects, object)
+			}
+			objectIter = objectIter.Next()
+		}
+		bucket.RUnlock()
+
+		for _, object := range objects {
+			objectName := object.GetInfo().GetName()
+			var err error
+			var skip bool
+			if target != nil {
+				skip, err = l.exportToLfs(ctx, bucketName, objectName, target)
+			} else {
+				var fpath string
+				fpath, err = exportPath(dir, bucketName, filepath.FromSlash(objectName))
+				if err == nil {
+					skip, err = l.exportToFile(ctx, bucketName, objectName, fpath, object.GetLength())
+				}
+			}
+
+			switch {
+			case err != nil:
+				utils.MLogger.Errorf("Export object %s in bucket %s fails: %s", objectName, bucketName, err)
+				stat.Failed++
+			case skip:
+				stat.Skipped++
+			default:
+				stat.Objects++
+				stat.Size += object.GetLength()
+			}
+		}
+		stat.Buckets++
+	}
+
+	utils.MLogger.Infof("Export lfs %s: %d buckets, %d objects, %d skipped, %d failed, size %d", l.fsID, stat.Buckets, stat.Objects, stat.Skipped, stat.Failed, stat.Size)
+	return stat, nil
+}
+
+// exportPath joins names to dir, names with "../" or absolute ones
+// cannot get out of dir
+func exportPath(dir string, names ...string) (string, error) {
+	base := filepath.Clean(dir)
+	fpath := filepath.Join(append([]string{base}, names...)...)
+	rel, err := filepath.Rel(base, fpath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", ErrObjectNameInvalid
+	}
+	return fpath, nil
+}
+
+func (l *LfsInfo) exportToFile(ctx context.Context, bucketName, objectName, fpath string, length int64) (bool, error) {
+	fi, err := os.Stat(fpath)
+	if err == nil && fi.Size() == length {
+		return true, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(fpath), 0755)
+	if err != nil {
+		return false, err
+	}
+
+	// write to a temp file, so that a partial file is not taken as exported
+	tmpPath := fpath + ".part"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return false, err
+	}
+
+	bufw := bufio.NewWriterSize(f, DefaultBufSize)
+	err = l.GetObject(ctx, bucketName, objectName, bufw, nil, DefaultDownloadOption())
+	if err == nil {
+		err = bufw.Flush()
+	}
+	f.Close()
+	if err != nil {
+		os.Remove(tmpPath)
+		return false, err
+	}
+
+	return false, os.Rename(tmpPath, fpath)
+}
+
+func (l *LfsInfo) exportToLfs(ctx context.Context, bucketName, objectName string, target *LfsInfo) (bool, error) {
+	_, err := target.HeadObject(ctx, bucketName, objectName)
+	if err == nil {
+		return true, nil
+	}
+
+	piper, pipew := io.Pipe()
+	go func() {
+		err := l.GetObject(ctx, bucketName, objectName, pipew, nil, DefaultDownloadOption())
+		pipew.CloseWithError(err)
+	}()
+
+	_, err = target.PutObject(ctx, bucketName, objectName, piper, DefaultUploadOption())
+	piper.CloseWithError(err)
+	return false, err
+}
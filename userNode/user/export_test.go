@@ -0,0 +1,42 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExportPath(t *testing.T) {
+	dir := filepath.Join("tmp", "export")
+
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{[]string{"bucket"}, filepath.Join(dir, "bucket")},
+		{[]string{"bucket", "a/b/c"}, filepath.Join(dir, "bucket", "a", "b", "c")},
+		{[]string{"bucket", "a/../b"}, filepath.Join(dir, "bucket", "b")},
+		{[]string{"bucket", "/a"}, filepath.Join(dir, "bucket", "a")},
+		{[]string{"bucket", "../../a"}, ""},
+		{[]string{"bucket", "a/../../../a"}, ""},
+		{[]string{".."}, ""},
+		{[]string{"."}, ""},
+	}
+
+	for _, tt := range tests {
+		for i := range tt.names {
+			tt.names[i] = filepath.FromSlash(tt.names[i])
+		}
+
+		got, err := exportPath(dir, tt.names...)
+		if tt.want == "" {
+			if err != ErrObjectNameInvalid {
+				t.Fatal(tt.names, " gets out of export dir: ", got)
+			}
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Fatal(tt.names, " is exported to ", got, ", want ", tt.want, ": ", err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package user
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	dataformat "github.com/memoio/go-mefs/data-format"
+	mpb "github.com/memoio/go-mefs/pb"
+	bf "github.com/memoio/go-mefs/source/go-block-format"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// blockReadLen returns length of a block in a full stripe of bo, which is the
+// most paid for reading a block
+func blockReadLen(bo *mpb.BucketOptions, userID, queryID string) (int, error) {
+	fieldSize, err := dataformat.FieldSize(bo)
+	if err != nil {
+		return 0, err
+	}
+
+	_, preLen, err := bf.PrefixEncode(&mpb.BlockOptions{
+		Bopts:   bo,
+		UserID:  userID,
+		QueryID: queryID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return preLen + int(bo.GetSegmentCount())*fieldSize, nil
+}
+
+// GetBlockFrom gets block of lfs from provider, which is paid by channel of provider
+func (u *Info) GetBlockFrom(ctx context.Context, blockID, proID string) ([]byte, error) {
+	bids := strings.SplitN(blockID, metainfo.BlockDelimiter, 2)
+	fs, ok := u.fsMap.Load(bids[0])
+	if !ok {
+		return nil, ErrLfsServiceNotReady
+	}
+
+	return fs.(*LfsInfo).GetBlockFrom(ctx, blockID, proID)
+}
+
+// GetBlockFrom gets block from provider; a whole block is paid by channel of
+// provider as downloading does
+func (l *LfsInfo) GetBlockFrom(ctx context.Context, blockID, proID string) ([]byte, error) {
+	if !l.Online() || l.meta.buckets == nil {
+		return nil, ErrLfsServiceNotReady
+	}
+
+	bids := strings.Split(blockID, metainfo.BlockDelimiter)
+	if len(bids) < 4 || bids[0] != l.fsID {
+		return nil, ErrWrongParameters
+	}
+
+	bucketID, err := strconv.ParseInt(bids[1], 10, 64)
+	if err != nil {
+		return nil, ErrWrongParameters
+	}
+
+	readLen := 0
+	for _, bucket := range l.meta.buckets {
+		if bucket == nil || bucket.BucketID != bucketID {
+			continue
+		}
+
+		// stripes may be in old options during transcoding
+		for _, bo := range []*mpb.BucketOptions{bucket.GetBOpts(), bucket.GetOldOpts()} {
+			if bo == nil {
+				continue
+			}
+			blen, err := blockReadLen(bo, l.userID, l.fsID)
+			if err != nil {
+				return nil, err
+			}
+			if blen > readLen {
+				readLen = blen
+			}
+		}
+	}
+
+	if readLen == 0 {
+		return nil, ErrBucketNotExist
+	}
+
+	g := l.gInfo
+	pInfo, ok := g.providers[proID]
+	if !ok {
+		return nil, ErrNoProviders
+	}
+
+	pInfo.Lock()
+	defer pInfo.Unlock()
+
+	mes, money, err := g.getChannelSign(pInfo, readLen)
+	if err != nil {
+		return nil, err
+	}
+
+	km, err := metainfo.NewKey(blockID, mpb.KeyType_Block)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := g.ds.GetBlock(ctx, km.ToString(), mes, proID)
+	if err != nil {
+		return nil, err
+	}
+
+	pInfo.chanItem.Value = money
+	pInfo.chanItem.Sig = mes
+	pInfo.chanItem.Dirty = true
+	utils.MLogger.Info("Get block success, change channel.value: ", pInfo.chanItem.ChannelID, " to: ", money.String())
+
+	key, err := metainfo.NewKey(proID, mpb.KeyType_Channel, pInfo.chanItem.ChannelID)
+	if err == nil {
+		g.ds.PutKey(ctx, key.ToString(), mes, nil, "local")
+	}
+
+	return b.RawData(), nil
+}
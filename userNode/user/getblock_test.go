@@ -0,0 +1,121 @@
+package user
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	id "github.com/memoio/go-mefs/crypto/identity"
+	"github.com/memoio/go-mefs/crypto/pdp"
+	dataformat "github.com/memoio/go-mefs/data-format"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils/address"
+)
+
+// TestGetBlockSign checks sign for getting a block is accepted by providers
+// outside test build and pays for the whole block
+func TestGetBlockSign(t *testing.T) {
+	err := pdp.Init(pdp.BLS12_381)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyset, err := pdp.GenKeySetV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userID := "8MGxCuiT75bje883b7uFb6eMrJt5cU"
+	queryID := "8MGxCuiT75bje883b7uFb6eMrJt5cQ"
+	bo := dataformat.DefaultBucketOptions()
+
+	coder, err := dataformat.NewDataCoderWithPrefix(keyset, &mpb.BlockOptions{
+		Bopts:   bo,
+		UserID:  userID,
+		QueryID: queryID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks, _, err := coder.Encode(make([]byte, stripeSize(bo)), queryID+"_1_0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readLen, err := blockReadLen(bo, userID, queryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, b := range blocks {
+		if len(b) != readLen {
+			t.Fatal("block ", i, " of full stripe has length ", len(b), ", paid for ", readLen)
+		}
+	}
+
+	sk, err := id.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chanID, err := address.GetIDFromAddress("0x5eF5fD065210CBd62Dfe86cC68a0E1AB2C5CBA50")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := &groupInfo{
+		userID:  userID,
+		groupID: queryID,
+		privKey: id.ECDSAByteToString(id.ToECDSAByte(sk)),
+	}
+
+	old := big.NewInt(100)
+	pInfo := &providerInfo{
+		chanItem: &role.ChannelItem{
+			ChannelID: chanID,
+			Value:     old,
+			Money:     big.NewInt(1e18),
+		},
+	}
+
+	mes, value, err := g.getChannelSign(pInfo, readLen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cSign := new(mpb.ChannelSign)
+	err = proto.Unmarshal(mes, cSign)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !role.VerifyChannelSign(cSign) || cSign.GetChannelID() != chanID {
+		t.Fatal("channel sign is not verified")
+	}
+
+	if new(big.Int).SetBytes(cSign.GetValue()).Cmp(value) != 0 {
+		t.Fatal("signed value ", new(big.Int).SetBytes(cSign.GetValue()), " is not ", value)
+	}
+
+	need := new(big.Int).Add(old, readCost(len(blocks[0])))
+	if value.Cmp(need) < 0 {
+		t.Fatal("signed value ", value, " is less than ", need)
+	}
+
+	// test sign is only taken in test build
+	tmes, err := role.BuildSignMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = proto.Unmarshal(tmes, cSign)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if role.VerifyChannelSign(cSign) {
+		t.Fatal("test sign is verified as channel sign")
+	}
+}
@@ -72,11 +72,15 @@ type groupInfo struct {
 	force         bool
 	tempKeepers   []string // for seletcting during init phase
 	tempProviders []string
+	preferred     []string // providers of placement quote, cheapest first
 
 	sessionID uuid.UUID
 
 	upKeepingItem *role.UpKeepingItem
 	queryItem     *role.QueryItem
+
+	renewLock sync.Mutex          // serializes renewing and resizing of upkeeping
+	ukRecord  *mpb.UpKeepingRecord // 续期策略和进度，保存在本地; guarded by RWMutex
 }
 
 func newGroup(uid, shareTo, sk string, capacity, duration int64, price *big.Int, ks, ps int, d data.Service) *groupInfo {
@@ -139,6 +143,9 @@ func (g *groupInfo) start(ctx context.Context) (bool, error) {
 		g.storePrice = uItem.Price
 		g.storeSize = uItem.Capacity
 		g.stPayCycle = uItem.Cycle
+		if _, err := g.getUkRecord(ctx); err == nil {
+			g.storeSize = g.GetCapacity()
+		}
 		g.state = deployDone
 		err := g.connect(ctx)
 		if err != nil {
@@ -421,6 +428,19 @@ func (g *groupInfo) connect(ctx context.Context) error {
 	g.loadContracts(ctx, "")
 
 	g.state = groupStarted
+
+	// keepers may miss capacity resized in place; grace period is set by
+	// user, so that all keepers of group keep data for the same time
+	rec, err := g.getUkRecord(ctx)
+	if err == nil {
+		if rec.GetGracePeriod() != role.GracePeriod {
+			rec.GracePeriod = role.GracePeriod
+			err = g.saveUkRecord(ctx, rec)
+		}
+		if err == nil {
+			go g.putUkRecord(ctx, rec)
+		}
+	}
 	return nil
 }
 
@@ -562,6 +582,36 @@ func (g *groupInfo) collect(ctx context.Context) bool {
 	return true
 }
 
+// SetPreferredProviders sets providers of placement quote, which are chosen
+// first during init
+func (g *groupInfo) SetPreferredProviders(pids []string) {
+	g.Lock()
+	defer g.Unlock()
+	g.preferred = pids
+}
+
+// orderProviders puts preferred providers first in their order, others are disordered
+func (g *groupInfo) orderProviders(pids []string) []string {
+	if len(g.preferred) == 0 {
+		return utils.DisorderArray(pids)
+	}
+
+	res := make([]string, 0, len(pids))
+	for _, pid := range g.preferred {
+		if !utils.CheckDup(pids, pid) && utils.CheckDup(res, pid) {
+			res = append(res, pid)
+		}
+	}
+
+	var others []string
+	for _, pid := range pids {
+		if utils.CheckDup(res, pid) {
+			others = append(others, pid)
+		}
+	}
+	return append(res, utils.DisorderArray(others)...)
+}
+
 // key: queryID/"UserNotify"/userID/kc/pc
 func (g *groupInfo) notify(ctx context.Context) {
 	// in case other change temp
@@ -596,7 +646,7 @@ func (g *groupInfo) notify(ctx context.Context) {
 		return
 	}
 
-	g.tempProviders = utils.DisorderArray(g.tempProviders)
+	g.tempProviders = g.orderProviders(g.tempProviders)
 	i = 0
 	for _, pidStr := range g.tempProviders {
 		if i >= g.providerSLA {
@@ -145,6 +145,8 @@ func (l *LfsInfo) startLfs() error {
 	go l.persistMetaBlock(l.context)
 	go l.persistRoot(l.context)
 	go l.sendHeartBeat(l.context)
+	go l.checkExpire(l.context)
+	l.resumeTranscode()
 	return nil
 }
 
@@ -306,6 +308,26 @@ func (l *LfsInfo) persistRoot(ctx context.Context) error {
 	}
 }
 
+// calcLfsRoot returns root of lfs tree, whose leaves are fsID, ctime and roots of buckets
+func calcLfsRoot(fsID string, lr *mpb.LfsRoot) []byte {
+	mtree := mt.New(sha256.New())
+	mtree.SetIndex(0)
+
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(lr.GetCTime()))
+	mtree.Push([]byte(fsID))
+	mtree.Push(buf)
+
+	for _, br := range lr.GetBRoots() {
+		if br == nil {
+			continue
+		}
+		mtree.Push(br.GetRoot())
+	}
+
+	return mtree.Root()
+}
+
 func (l *LfsInfo) genRoot() {
 	if !l.meta.dirty {
 		return
@@ -356,22 +378,7 @@ func (l *LfsInfo) genRoot() {
 
 	l.meta.sb.RUnlock()
 
-	mtree := mt.New(sha256.New())
-	mtree.SetIndex(0)
-
-	buf := make([]byte, 8)
-	binary.LittleEndian.PutUint64(buf, uint64(ctime))
-	mtree.Push([]byte(l.fsID))
-	mtree.Push(buf)
-
-	for i := 0; i < int(bucketNum); i++ {
-		if lr.BRoots[i] == nil {
-			continue
-		}
-		mtree.Push(lr.BRoots[i].Root)
-	}
-
-	lr.Root = mtree.Root()
+	lr.Root = calcLfsRoot(l.fsID, lr)
 
 	l.meta.sb.Lock()
 	l.meta.sb.LRoot = append(l.meta.sb.LRoot, lr)
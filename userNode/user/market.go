@@ -0,0 +1,191 @@
+package user
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	dataformat "github.com/memoio/go-mefs/data-format"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// MarketOffer is the latest offer of a provider, with its reputation and
+// location reported by keepers
+type MarketOffer struct {
+	ProviderID string
+	OfferID    string
+	Capacity   int64    // MB
+	Duration   int64    // second
+	Price      *big.Int // wei/(MB*h)
+	Credit     int64    // average credit given by keepers
+	Location   string   // external address
+	Online     bool
+	Keepers    int // number of keepers which know this provider
+}
+
+// PlacementQuote is the cheapest eligible placement of a new lfs given by a keeper
+type PlacementQuote struct {
+	Keeper    string
+	Providers []string // cheapest first, spares included
+	Price     *big.Int // price of lfs, accepted by all providers
+	Cost      *big.Int // estimated cost of storing data with its redundancy
+}
+
+// getMarketKeepers returns connected keepers on chain
+func (u *Info) getMarketKeepers(ctx context.Context, userID string) ([]string, error) {
+	kItems, _, err := role.GetAllKeepers(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var keepers []string
+	for _, kItem := range kItems {
+		if _, ok := u.ds.Connect(ctx, kItem.KeeperID); ok {
+			keepers = append(keepers, kItem.KeeperID)
+		}
+	}
+
+	if len(keepers) == 0 {
+		return nil, role.ErrNotConnectd
+	}
+	return keepers, nil
+}
+
+// GetMarket aggregates offers of providers known by keepers, and refreshes
+// them with the latest offers on chain; result is sorted by price
+func (u *Info) GetMarket(ctx context.Context, userID string) ([]*MarketOffer, error) {
+	keepers, err := u.getMarketKeepers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	km, err := metainfo.NewKey(userID, mpb.KeyType_Market, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	offers := make(map[string]*MarketOffer)
+	for _, kid := range keepers {
+		res, err := u.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), km.ToString(), nil, nil, kid)
+		if err != nil {
+			utils.MLogger.Infof("Get market from keeper %s fails: %s", kid, err)
+			continue
+		}
+
+		market := new(mpb.Market)
+		err = proto.Unmarshal(res, market)
+		if err != nil {
+			utils.MLogger.Infof("Get market from keeper %s fails: %s", kid, err)
+			continue
+		}
+
+		for _, mo := range market.GetOffers() {
+			offer, ok := offers[mo.GetProviderID()]
+			if !ok {
+				offer = &MarketOffer{
+					ProviderID: mo.GetProviderID(),
+					OfferID:    mo.GetOfferID(),
+					Capacity:   mo.GetCapacity(),
+					Duration:   mo.GetDuration(),
+					Price:      new(big.Int).SetBytes(mo.GetPrice()),
+				}
+				offers[mo.GetProviderID()] = offer
+			}
+			offer.Keepers++
+			offer.Credit += mo.GetCredit()
+			offer.Online = offer.Online || mo.GetOnline()
+			if offer.Location == "" {
+				offer.Location = mo.GetLocation()
+			}
+		}
+	}
+
+	res := make([]*MarketOffer, 0, len(offers))
+	for _, offer := range offers {
+		offer.Credit /= int64(offer.Keepers)
+		oItem, err := role.GetLatestOffer(userID, offer.ProviderID)
+		if err == nil {
+			offer.OfferID = oItem.OfferID
+			offer.Capacity = oItem.Capacity
+			offer.Duration = oItem.Duration
+			offer.Price = oItem.Price
+		}
+		res = append(res, offer)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		c := res[i].Price.Cmp(res[j].Price)
+		if c == 0 {
+			return res[i].Credit > res[j].Credit
+		}
+		return c < 0
+	})
+	return res, nil
+}
+
+// QuotePlacement asks keepers for the cheapest placement of ps providers
+// storing capacity(MB) for duration(day) with redundancy of default buckets
+// within budget(wei, nil is unlimited), and returns the cheapest quote
+func (u *Info) QuotePlacement(ctx context.Context, userID string, capacity, duration int64, ps int, budget *big.Int) (*PlacementQuote, error) {
+	if capacity <= 0 || duration <= 0 || ps <= 0 || (budget != nil && budget.Sign() <= 0) {
+		return nil, ErrWrongParameters
+	}
+
+	keepers, err := u.getMarketKeepers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := "0"
+	if budget != nil {
+		limit = budget.String()
+	}
+
+	bo := dataformat.DefaultBucketOptions()
+	km, err := metainfo.NewKey(userID, mpb.KeyType_Market, userID, strconv.FormatInt(capacity, 10), strconv.FormatInt(duration, 10), strconv.Itoa(ps), strconv.Itoa(int(bo.DataCount)), strconv.Itoa(int(bo.ParityCount)), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *PlacementQuote
+	for _, kid := range keepers {
+		res, err := u.ds.SendMetaRequest(ctx, int32(mpb.OpType_Get), km.ToString(), nil, nil, kid)
+		if err != nil {
+			utils.MLogger.Infof("Get quote from keeper %s fails: %s", kid, err)
+			continue
+		}
+
+		mq := new(mpb.MarketQuote)
+		err = proto.Unmarshal(res, mq)
+		if err != nil || len(mq.GetProviders()) < ps {
+			utils.MLogger.Infof("Get quote from keeper %s fails: %v", kid, err)
+			continue
+		}
+
+		quote := &PlacementQuote{
+			Keeper:    kid,
+			Providers: mq.GetProviders(),
+			Price:     new(big.Int).SetBytes(mq.GetPrice()),
+			Cost:      new(big.Int).SetBytes(mq.GetCost()),
+		}
+		if budget != nil && quote.Cost.Cmp(budget) > 0 {
+			utils.MLogger.Infof("Quote from keeper %s costs %d, more than budget %d", kid, quote.Cost, budget)
+			continue
+		}
+		if best == nil || quote.Cost.Cmp(best.Cost) < 0 {
+			best = quote
+		}
+	}
+
+	if best == nil {
+		return nil, role.ErrNotEnoughProvider
+	}
+
+	utils.MLogger.Infof("Cheapest placement for %d providers is from keeper %s: price %d, cost %d", ps, best.Keeper, best.Price, best.Cost)
+	return best, nil
+}
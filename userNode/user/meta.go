@@ -284,7 +284,7 @@ func (l *LfsInfo) loadSingleBucketInfo(bucketID int64, update bool) error {
 		}
 		tsb = newsuperBucket(localbucket, false)
 		tsb.mtree.SetIndex(0)
-		tsb.mtree.Push([]byte(l.fsID + idName))
+		tsb.mtree.Push(bucketInitLeaf(l.fsID, bucketID))
 		tsb.Root = tsb.mtree.Root()
 	} else {
 		bname, ok := l.meta.bucketIDToName[bucketID]
@@ -317,7 +317,7 @@ func (l *LfsInfo) loadSingleBucketInfo(bucketID int64, update bool) error {
 			if tsb.GetNextOpID() < remotebucket.GetNextOpID() || (tsb.GetName() != remotebucket.GetName() && remotebucket.GetName() != idName) {
 				tsb = newsuperBucket(remotebucket, false)
 				tsb.mtree.SetIndex(0)
-				tsb.mtree.Push([]byte(l.fsID + strconv.FormatInt(bucketID, 10)))
+				tsb.mtree.Push(bucketInitLeaf(l.fsID, bucketID))
 				tsb.Root = tsb.mtree.Root()
 				tsb.dirty = true
 				utils.MLogger.Infof("remote has newer ops %d for bucket %d", remotebucket.GetNextOpID()-1, tsb.GetBucketID())
@@ -532,6 +532,26 @@ func applyOp(bucket *superBucket, op *mpb.OpRecord) error {
 		ob.MTime = part.GetCTime()
 		ob.Unlock()
 		bucket.applyOpID = op.GetOpID()
+	case mpb.LfsOp_OpTranscode:
+		part := mpb.ObjectPart{}
+		err = proto.Unmarshal(payload, &part)
+		if err != nil {
+			utils.MLogger.Error("OpTranscode payload parse failed, bucket: ", bucket.GetName())
+			return err
+		}
+		ob, ok := bucket.Objects.Find(MetaName(part.GetName())).(*ObjectInfo)
+		if !ok || ob == nil {
+			utils.MLogger.Error("Transcode Object Part: ", part.GetPartID(), " of an inexistent object: ", part.GetName())
+			return ErrObjectNotExist
+		}
+
+		ob.Lock()
+		err = replacePart(&ob.ObjectInfo, &part)
+		ob.Unlock()
+		if err != nil {
+			return err
+		}
+		bucket.applyOpID = op.GetOpID()
 	case mpb.LfsOp_OpDelete:
 		mes := mpb.DeleteObject{}
 		err = proto.Unmarshal(payload, &mes)
@@ -698,7 +718,14 @@ func (l *LfsInfo) getDataFromBlock(metaBackupCount int, buc, stripe string) ([][
 		return nil, err
 	}
 
-	sig, err := role.BuildSignMessage()
+	// meta blocks are read by user itself without paying
+	bids := make([]string, 0, metaBackupCount)
+	for j := 0; j < metaBackupCount; j++ {
+		bm.SetCid(strconv.Itoa(j))
+		bids = append(bids, bm.ToString())
+	}
+
+	sig, err := role.BuildReadTokenMessage(l.fsID, l.ds.GetNetID(), l.userID, l.privateKey, bids)
 	if err != nil {
 		return nil, err
 	}
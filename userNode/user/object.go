@@ -294,9 +294,9 @@ func (l *LfsInfo) GetObjectAvailTime(ctx context.Context, object *mpb.ObjectInfo
 		return latestTime.Format(utils.BASETIME), ErrBucketNotExist
 	}
 
-	blockCount := bucket.BOpts.DataCount + bucket.BOpts.ParityCount
+	bo := bucket.partOptions(object.Parts[0])
 
-	bo := bucket.BOpts
+	blockCount := bo.DataCount + bo.ParityCount
 
 	stripeID := object.Parts[0].GetStart() / int64(bo.SegmentCount*bo.SegmentSize*bo.DataCount)
 
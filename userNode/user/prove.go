@@ -0,0 +1,361 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strconv"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	"github.com/memoio/go-mefs/utils"
+	mt "gitlab.com/NebulousLabs/merkletree"
+)
+
+// lfs tree has fsID and ctime ahead of bucket roots
+const lfsRootPrefixLeaves = 2
+
+var (
+	ErrNoRootContract = errors.New("lfs has no root contract")
+	ErrObjectNotRoot  = errors.New("object is not in lfs root yet")
+	ErrInvalidProof   = errors.New("object proof is invalid")
+)
+
+// ProveObject returns proof of object against the latest lfs root set in root contract;
+// the proof contains op records of the object, which give its name and etag.
+func (l *LfsInfo) ProveObject(ctx context.Context, bucketName, objectName string) (*mpb.ObjectProof, error) {
+	ok := l.Sm.TryAcquire(1)
+	if !ok {
+		return nil, ErrResourceUnavailable
+	}
+	defer l.Sm.Release(1)
+
+	if l.meta.buckets == nil {
+		return nil, ErrLfsServiceNotReady
+	}
+
+	if l.gInfo.rootID == "" || l.gInfo.rootID == l.gInfo.userID {
+		return nil, ErrNoRootContract
+	}
+
+	bucket, ok := l.meta.buckets[bucketName]
+	if !ok || bucket == nil || bucket.Deletion {
+		return nil, ErrBucketNotExist
+	}
+
+	if bucket.Objects == nil {
+		return nil, ErrObjectNotExist
+	}
+
+	object, ok := bucket.Objects.Find(MetaName(objectName)).(*ObjectInfo)
+	if !ok || object == nil || object.GetDeletion() {
+		return nil, ErrObjectNotExist
+	}
+
+	// make sure latest ops are in lfs root on chain
+	l.genRoot()
+
+	l.meta.sb.RLock()
+	var lr *mpb.LfsRoot
+	if len(l.meta.sb.LRoot) > 0 {
+		lr = l.meta.sb.LRoot[len(l.meta.sb.LRoot)-1]
+	}
+	l.meta.sb.RUnlock()
+
+	if lr == nil {
+		return nil, ErrObjectNotRoot
+	}
+
+	bucket.RLock()
+	ops, err := l.getBucketOps(bucket)
+	bucket.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	pf, err := newObjectProof(l.fsID, lr, bucket.BucketID, ops, object.GetInfo().GetObjectID())
+	if err != nil {
+		return nil, err
+	}
+	pf.UserID = l.userID
+	pf.RootID = l.gInfo.rootID
+
+	utils.MLogger.Infof("prove object %s in bucket %s with %d ops at root %d", objectName, bucketName, len(pf.Ops), lr.GetCTime())
+	return pf, nil
+}
+
+// bucketInitLeaf is the first leaf of bucket tree, which is followed by op records
+func bucketInitLeaf(fsID string, bucketID int64) []byte {
+	return []byte(fsID + strconv.FormatInt(bucketID, 10))
+}
+
+// newObjectProof proves ops of object in bucket tree, and the bucket root in lfs root lr
+func newObjectProof(fsID string, lr *mpb.LfsRoot, bucketID int64, ops [][]byte, objectID int64) (*mpb.ObjectProof, error) {
+	if len(lr.GetBRoots()) < int(bucketID) || bucketID < 1 {
+		return nil, ErrObjectNotRoot
+	}
+
+	broot := lr.GetBRoots()[bucketID-1]
+	if broot == nil {
+		return nil, ErrObjectNotRoot
+	}
+
+	if int64(len(ops)) < broot.GetOpCount() {
+		utils.MLogger.Errorf("bucket %d has %d ops, but root has %d", bucketID, len(ops), broot.GetOpCount())
+		return nil, ErrCannotLoadMetaBlock
+	}
+
+	// bucket tree: init leaf, ops
+	leaves := make([][]byte, 0, broot.GetOpCount()+1)
+	leaves = append(leaves, bucketInitLeaf(fsID, bucketID))
+	leaves = append(leaves, ops[:broot.GetOpCount()]...)
+
+	pf := &mpb.ObjectProof{
+		FsID:       fsID,
+		CTime:      lr.GetCTime(),
+		BucketID:   bucketID,
+		OpCount:    broot.GetOpCount(),
+		BucketRoot: broot.GetRoot(),
+	}
+
+	for i := 1; i < len(leaves); i++ {
+		if opObjectID(leaves[i]) != objectID {
+			continue
+		}
+
+		lp, err := proveLeaf(leaves, i)
+		if err != nil {
+			return nil, err
+		}
+		pf.Ops = append(pf.Ops, lp)
+	}
+
+	if len(pf.Ops) == 0 {
+		return nil, ErrObjectNotRoot
+	}
+
+	// lfs tree: fsID, ctime, roots of buckets
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(lr.GetCTime()))
+	leaves = [][]byte{[]byte(fsID), buf}
+	bIndex := -1
+	for _, br := range lr.GetBRoots() {
+		if br == nil {
+			continue
+		}
+		if br.GetBucketID() == bucketID {
+			bIndex = len(leaves)
+		}
+		leaves = append(leaves, br.GetRoot())
+	}
+
+	if bIndex < 0 {
+		return nil, ErrObjectNotRoot
+	}
+
+	var err error
+	pf.LfsLeaves = int64(len(leaves))
+	pf.BucketProof, err = proveLeaf(leaves, bIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return pf, nil
+}
+
+// getBucketOps returns marshaled op records of bucket in order, which are leaves of bucket tree
+func (l *LfsInfo) getBucketOps(bucket *superBucket) ([][]byte, error) {
+	data, err := readFromMeta(l.fsID, strconv.FormatInt(bucket.BucketID, 10)+".object")
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, bucket.obMetaCache[:bucket.obCacheSize]...)
+
+	var ops [][]byte
+	var op mpb.OpRecord
+	odReader := ggio.NewDelimitedReader(bytes.NewBuffer(data), len(data))
+	for {
+		err := odReader.ReadMsg(&op)
+		if err != nil {
+			break
+		}
+
+		if op.GetOpType() == mpb.LfsOp_OpErr {
+			continue
+		}
+
+		tag, err := proto.Marshal(&op)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, tag)
+	}
+	return ops, nil
+}
+
+// opObjectID returns objectID which op is applied to, or -1
+func opObjectID(leaf []byte) int64 {
+	op := new(mpb.OpRecord)
+	err := proto.Unmarshal(leaf, op)
+	if err != nil {
+		return -1
+	}
+
+	switch op.GetOpType() {
+	case mpb.LfsOp_OpAdd:
+		ob := new(mpb.Object)
+		if proto.Unmarshal(op.GetPayload(), ob) == nil {
+			return ob.GetObjectID()
+		}
+	case mpb.LfsOp_OpAppend, mpb.LfsOp_OpTranscode:
+		part := new(mpb.ObjectPart)
+		if proto.Unmarshal(op.GetPayload(), part) == nil {
+			return part.GetObjectID()
+		}
+	case mpb.LfsOp_OpDelete:
+		do := new(mpb.DeleteObject)
+		if proto.Unmarshal(op.GetPayload(), do) == nil {
+			return do.GetObjectID()
+		}
+	}
+	return -1
+}
+
+func proveLeaf(leaves [][]byte, index int) (*mpb.LeafProof, error) {
+	mtree := mt.New(sha256.New())
+	err := mtree.SetIndex(uint64(index))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, leaf := range leaves {
+		mtree.Push(leaf)
+	}
+
+	_, proofSet, _, _ := mtree.Prove()
+	return &mpb.LeafProof{
+		Index: int64(index),
+		Proof: proofSet,
+	}, nil
+}
+
+// VerifyObjectProof checks proof against root contract of the user, and returns
+// the object rebuilt from op records in proof. It proves that the object is
+// stored by the user before CTime; ops omitted from proof, e.g. a later delete,
+// cannot be detected.
+func VerifyObjectProof(pf *mpb.ObjectProof) (*mpb.ObjectInfo, error) {
+	if pf == nil || len(pf.GetOps()) == 0 || pf.GetBucketProof() == nil {
+		return nil, ErrInvalidProof
+	}
+
+	// root contract belongs to user's lfs
+	rootID, err := role.GetRoot(pf.GetUserID(), pf.GetFsID())
+	if err != nil {
+		return nil, err
+	}
+	if rootID != pf.GetRootID() {
+		return nil, ErrInvalidProof
+	}
+
+	val, err := role.GetMerkleRoot(pf.GetRootID(), pf.GetCTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return verifyObjectProof(pf, val[:])
+}
+
+// verifyObjectProof checks proof against lfs root, and rebuilds the object
+func verifyObjectProof(pf *mpb.ObjectProof, lroot []byte) (*mpb.ObjectInfo, error) {
+	bp := pf.GetBucketProof()
+	if bp.GetIndex() < lfsRootPrefixLeaves || len(bp.GetProof()) == 0 || !bytes.Equal(bp.GetProof()[0], pf.GetBucketRoot()) {
+		return nil, ErrInvalidProof
+	}
+
+	if !mt.VerifyProof(sha256.New(), lroot, bp.GetProof(), uint64(bp.GetIndex()), uint64(pf.GetLfsLeaves())) {
+		return nil, ErrInvalidProof
+	}
+
+	// leaf 0 of bucket tree is its init leaf, ops follow it
+	oi := new(mpb.ObjectInfo)
+	lastIndex := int64(0)
+	for _, lp := range pf.GetOps() {
+		if lp.GetIndex() <= lastIndex || len(lp.GetProof()) == 0 {
+			return nil, ErrInvalidProof
+		}
+		lastIndex = lp.GetIndex()
+
+		if !mt.VerifyProof(sha256.New(), pf.GetBucketRoot(), lp.GetProof(), uint64(lp.GetIndex()), uint64(pf.GetOpCount()+1)) {
+			return nil, ErrInvalidProof
+		}
+
+		op := new(mpb.OpRecord)
+		err := proto.Unmarshal(lp.GetProof()[0], op)
+		if err != nil {
+			return nil, ErrInvalidProof
+		}
+
+		err = applyProvedOp(oi, op)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if oi.GetInfo() == nil || oi.GetInfo().GetBucketID() != pf.GetBucketID() {
+		return nil, ErrInvalidProof
+	}
+
+	return oi, nil
+}
+
+// applyProvedOp rebuilds object by op; the first op must create it
+func applyProvedOp(oi *mpb.ObjectInfo, op *mpb.OpRecord) error {
+	switch op.GetOpType() {
+	case mpb.LfsOp_OpAdd:
+		if oi.GetInfo() != nil {
+			return ErrInvalidProof
+		}
+		ob := new(mpb.Object)
+		err := proto.Unmarshal(op.GetPayload(), ob)
+		if err != nil {
+			return ErrInvalidProof
+		}
+		oi.Info = ob
+		oi.CTime = ob.GetCTime()
+		oi.MTime = ob.GetCTime()
+	case mpb.LfsOp_OpAppend:
+		part := new(mpb.ObjectPart)
+		err := proto.Unmarshal(op.GetPayload(), part)
+		if err != nil || oi.GetInfo() == nil || part.GetObjectID() != oi.GetInfo().GetObjectID() {
+			return ErrInvalidProof
+		}
+		oi.Parts = append(oi.Parts, part)
+		oi.PartCount++
+		oi.Length += part.GetLength()
+		oi.ETag = calculateETagForNewPart(oi.ETag, part.GetETag())
+		oi.MTime = part.GetCTime()
+	case mpb.LfsOp_OpTranscode:
+		part := new(mpb.ObjectPart)
+		err := proto.Unmarshal(op.GetPayload(), part)
+		if err != nil || oi.GetInfo() == nil || part.GetObjectID() != oi.GetInfo().GetObjectID() {
+			return ErrInvalidProof
+		}
+		if replacePart(oi, part) != nil {
+			return ErrInvalidProof
+		}
+	case mpb.LfsOp_OpDelete:
+		do := new(mpb.DeleteObject)
+		err := proto.Unmarshal(op.GetPayload(), do)
+		if err != nil || oi.GetInfo() == nil || do.GetObjectID() != oi.GetInfo().GetObjectID() {
+			return ErrInvalidProof
+		}
+		oi.Deletion = true
+	default:
+		return ErrInvalidProof
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+)
+
+func testOp(t *testing.T, opType mpb.LfsOp, opID int64, payload proto.Message) []byte {
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag, err := proto.Marshal(&mpb.OpRecord{
+		OpType:  opType,
+		OpID:    opID,
+		Payload: data,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tag
+}
+
+func TestObjectProof(t *testing.T) {
+	fsID := "8MGxCuiT75bje883b7uFb6eMrJt5cQ"
+	bucketID := int64(2)
+
+	ops := [][]byte{
+		testOp(t, mpb.LfsOp_OpAdd, 0, &mpb.Object{Name: "a", ObjectID: 0, BucketID: bucketID}),
+		testOp(t, mpb.LfsOp_OpAdd, 1, &mpb.Object{Name: "b", ObjectID: 1, BucketID: bucketID}),
+		testOp(t, mpb.LfsOp_OpAppend, 2, &mpb.ObjectPart{ObjectID: 0, Length: 100, ETag: "0a0b0c"}),
+	}
+
+	// bucket tree is built as it is on creating bucket and adding ops
+	bucket := newsuperBucket(mpb.BucketInfo{BucketID: bucketID}, true)
+	bucket.mtree.SetIndex(0)
+	bucket.mtree.Push(bucketInitLeaf(fsID, bucketID))
+	for _, op := range ops {
+		bucket.mtree.Push(op)
+	}
+
+	other := newsuperBucket(mpb.BucketInfo{BucketID: 1}, true)
+	other.mtree.SetIndex(0)
+	other.mtree.Push(bucketInitLeaf(fsID, 1))
+
+	lr := &mpb.LfsRoot{
+		BRoots: []*mpb.BucketRoot{
+			{BucketID: 1, Root: other.mtree.Root(), OpCount: 0},
+			{BucketID: bucketID, Root: bucket.mtree.Root(), OpCount: int64(len(ops))},
+		},
+		CTime: 1600000000,
+	}
+	lroot := calcLfsRoot(fsID, lr)
+
+	pf, err := newObjectProof(fsID, lr, bucketID, ops, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pf.GetOps()) != 2 || pf.GetOps()[0].GetIndex() != 1 || pf.GetOps()[1].GetIndex() != 3 {
+		t.Fatal("wrong op leaves in proof: ", pf.GetOps())
+	}
+
+	oi, err := verifyObjectProof(pf, lroot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if oi.GetInfo().GetName() != "a" || oi.GetPartCount() != 1 || oi.GetLength() != 100 || oi.GetETag() != "0a0b0c" {
+		t.Fatal("wrong object from proof: ", oi)
+	}
+
+	pf, err = newObjectProof(fsID, lr, bucketID, ops, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oi, err = verifyObjectProof(pf, lroot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if oi.GetInfo().GetName() != "b" || oi.GetPartCount() != 0 {
+		t.Fatal("wrong object from proof: ", oi)
+	}
+
+	// proof fails against other lfs root
+	lr.CTime++
+	_, err = verifyObjectProof(pf, calcLfsRoot(fsID, lr))
+	if err != ErrInvalidProof {
+		t.Fatal("proof is verified against wrong root: ", err)
+	}
+	lr.CTime--
+
+	// proof fails when op is modified
+	pf.GetOps()[0].GetProof()[0] = testOp(t, mpb.LfsOp_OpAdd, 1, &mpb.Object{Name: "c", ObjectID: 1, BucketID: bucketID})
+	_, err = verifyObjectProof(pf, lroot)
+	if err != ErrInvalidProof {
+		t.Fatal("proof with modified op is verified: ", err)
+	}
+
+	// ops not in root cannot be proved
+	_, err = newObjectProof(fsID, lr, bucketID, ops, 2)
+	if err != ErrObjectNotRoot {
+		t.Fatal("object not in root is proved: ", err)
+	}
+}
@@ -0,0 +1,349 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/role"
+	ds "github.com/memoio/go-mefs/source/go-datastore"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+const (
+	expireWarnTime = 7 * 24 * 60 * 60 // upkeeping到期前此时间内提示续期，单位：秒
+	renewAheadTime = 3 * 24 * 60 * 60 // 自动续期在到期前此时间内进行，单位：秒
+	checkExpireDur = time.Hour
+)
+
+// Renew tops up upkeeping and extends it by addDays; the topped up end time is
+// recorded locally, so if extending fails, a retry extends to it without topping
+// up again instead of starting a new renewing
+func (g *groupInfo) Renew(ctx context.Context, addDays int64) error {
+	if g.userID == g.groupID {
+		return role.ErrNoContract
+	}
+
+	if addDays <= 0 {
+		return role.ErrInvalidInput
+	}
+
+	g.renewLock.Lock()
+	defer g.renewLock.Unlock()
+
+	err := g.updateUpKeepingItem(ctx)
+	if err != nil {
+		return err
+	}
+	uk := g.GetUk()
+
+	rec, err := g.getUkRecord(ctx)
+	if err != nil {
+		return err
+	}
+
+	addTime := addDays * 24 * 60 * 60
+	if rec.RenewEnd > uk.EndTime {
+		addTime = rec.RenewEnd - uk.EndTime
+		utils.MLogger.Infof("Resume renewing lfs %s, which is topped up, to %d", g.groupID, rec.RenewEnd)
+	} else {
+		money := role.GetStoreCost(uk.Price, g.GetCapacity(), addDays)
+		err = role.TopUpUpKeeping(g.userID, g.groupID, g.privKey, money, "renew upkeeping")
+		if err != nil {
+			return err
+		}
+
+		rec.RenewEnd = uk.EndTime + addTime
+		err = g.saveUkRecord(ctx, rec)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = role.ExtendUpKeeping(g.userID, g.groupID, g.privKey, addTime)
+	if err != nil {
+		return err
+	}
+
+	rec.RenewEnd = 0
+	err = g.saveUkRecord(ctx, rec)
+	if err != nil {
+		return err
+	}
+
+	return g.updateUpKeepingItem(ctx)
+}
+
+// Resize upgrades capacity of upkeeping to storeSize(MB) in place: upkeeping is
+// topped up with cost of the added capacity until its end time, and the new
+// capacity is recorded locally and sent to keepers, which check it is paid and
+// use it instead of capacity on chain, e.g. choosing new providers; spacetime is
+// paid by data stored, so keepers and providers go on with the same upkeeping.
+func (g *groupInfo) Resize(ctx context.Context, storeSize int64) error {
+	if g.userID == g.groupID {
+		return role.ErrNoContract
+	}
+
+	g.renewLock.Lock()
+	defer g.renewLock.Unlock()
+
+	err := g.updateUpKeepingItem(ctx)
+	if err != nil {
+		return err
+	}
+	uk := g.GetUk()
+
+	rec, err := g.getUkRecord(ctx)
+	if err != nil {
+		return err
+	}
+
+	capacity := g.GetCapacity()
+	if storeSize <= capacity {
+		return role.ErrInvalidInput
+	}
+
+	// topped up renewing is paid at old capacity too
+	end := uk.EndTime
+	if rec.RenewEnd > end {
+		end = rec.RenewEnd
+	}
+
+	remain := end - time.Now().Unix()
+	if remain <= 0 {
+		return role.ErrUkExpire
+	}
+	storeDays := (remain + 24*60*60 - 1) / (24 * 60 * 60)
+
+	utils.MLogger.Infof("Begin to resize lfs %s from %d MB to %d MB for %d days", g.groupID, capacity, storeSize, storeDays)
+
+	money := role.GetStoreCost(uk.Price, storeSize-capacity, storeDays)
+	err = role.TopUpUpKeeping(g.userID, g.groupID, g.privKey, money, "resize upkeeping")
+	if err != nil {
+		return err
+	}
+
+	rec.Capacity = storeSize
+	err = g.saveUkRecord(ctx, rec)
+	if err != nil {
+		return err
+	}
+
+	g.Lock()
+	g.storeSize = storeSize
+	g.Unlock()
+
+	// capacity of upkeeping on chain cannot be changed, keepers use the resized one
+	err = g.putUkRecord(ctx, rec)
+	if err != nil {
+		utils.MLogger.Warnf("Send resized capacity of lfs %s to keepers fails: %s, resend when it starts", g.groupID, err)
+	}
+
+	utils.MLogger.Infof("Resize lfs %s to %d MB in upkeeping %s", g.groupID, storeSize, uk.UpKeepingID)
+	return nil
+}
+
+// putUkRecord sends renew record to keepers, which read capacity resized in place
+// and grace period of group from it
+func (g *groupInfo) putUkRecord(ctx context.Context, rec *mpb.UpKeepingRecord) error {
+	km, err := metainfo.NewKey(g.groupID, mpb.KeyType_Renew, g.userID)
+	if err != nil {
+		return err
+	}
+
+	val, err := proto.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	return g.putDataToKeepers(ctx, km.ToString(), val)
+}
+
+// SetAutoRenew sets policy of renewing addDays before upkeeping expires
+func (g *groupInfo) SetAutoRenew(ctx context.Context, auto bool, addDays int64) error {
+	if g.userID == g.groupID {
+		return role.ErrNoContract
+	}
+
+	g.renewLock.Lock()
+	defer g.renewLock.Unlock()
+
+	rec, err := g.getUkRecord(ctx)
+	if err != nil {
+		return err
+	}
+
+	rec.AutoRenew = auto
+	rec.RenewDays = addDays
+	return g.saveUkRecord(ctx, rec)
+}
+
+// GetCapacity returns capacity(MB) of upkeeping, including capacity resized in place
+func (g *groupInfo) GetCapacity() int64 {
+	uk := g.GetUk()
+	if uk == nil {
+		return 0
+	}
+
+	g.RLock()
+	defer g.RUnlock()
+	if g.ukRecord != nil && g.ukRecord.GetUpKeepingID() == uk.UpKeepingID && g.ukRecord.GetCapacity() > uk.Capacity {
+		return g.ukRecord.GetCapacity()
+	}
+	return uk.Capacity
+}
+
+// getRenewPolicy returns whether and how many days to renew before expiry
+func (g *groupInfo) getRenewPolicy() (bool, int64) {
+	g.RLock()
+	defer g.RUnlock()
+	if g.ukRecord == nil {
+		return false, 0
+	}
+	return g.ukRecord.GetAutoRenew(), g.ukRecord.GetRenewDays()
+}
+
+// getGracePeriod returns seconds of keeping data after upkeeping ends, which is
+// sent to keepers in renew record
+func (g *groupInfo) getGracePeriod() int64 {
+	g.RLock()
+	defer g.RUnlock()
+	if g.ukRecord == nil || g.ukRecord.GetGracePeriod() <= 0 {
+		return role.GracePeriod
+	}
+	return g.ukRecord.GetGracePeriod()
+}
+
+// getUkRecord returns a copy of renew record, which is loaded from local if not;
+// capacity and renewing of an old upkeeping are dropped
+func (g *groupInfo) getUkRecord(ctx context.Context) (*mpb.UpKeepingRecord, error) {
+	uk := g.GetUk()
+	if uk == nil {
+		return nil, role.ErrEmptyData
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	if g.ukRecord == nil {
+		km, err := metainfo.NewKey(g.groupID, mpb.KeyType_Renew, g.userID)
+		if err != nil {
+			return nil, err
+		}
+
+		rec := new(mpb.UpKeepingRecord)
+		val, err := g.ds.GetKey(ctx, km.ToString(), "local")
+		if err == nil {
+			err = proto.Unmarshal(val, rec)
+			if err != nil {
+				return nil, err
+			}
+		} else if err != ds.ErrNotFound {
+			return nil, err
+		}
+		g.ukRecord = rec
+	}
+
+	rec := &mpb.UpKeepingRecord{
+		UpKeepingID: uk.UpKeepingID,
+		AutoRenew:   g.ukRecord.GetAutoRenew(),
+		RenewDays:   g.ukRecord.GetRenewDays(),
+		GracePeriod: g.ukRecord.GetGracePeriod(),
+	}
+
+	if g.ukRecord.GetUpKeepingID() == uk.UpKeepingID {
+		rec.Capacity = g.ukRecord.GetCapacity()
+		rec.RenewEnd = g.ukRecord.GetRenewEnd()
+	}
+
+	return rec, nil
+}
+
+// saveUkRecord persists renew record to local before using it
+func (g *groupInfo) saveUkRecord(ctx context.Context, rec *mpb.UpKeepingRecord) error {
+	km, err := metainfo.NewKey(g.groupID, mpb.KeyType_Renew, g.userID)
+	if err != nil {
+		return err
+	}
+
+	val, err := proto.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	err = g.ds.PutKey(ctx, km.ToString(), val, nil, "local")
+	if err != nil {
+		return err
+	}
+
+	g.Lock()
+	g.ukRecord = rec
+	g.Unlock()
+	return nil
+}
+
+// GetExpireWarning returns warning if upkeeping expires soon
+func (g *groupInfo) GetExpireWarning() string {
+	uk := g.GetUk()
+	if uk == nil {
+		return ""
+	}
+
+	left := uk.EndTime - time.Now().Unix()
+	if left <= 0 {
+		left += g.getGracePeriod()
+		if left <= 0 {
+			return "upkeeping has expired and its grace period is over, data will be deleted by keepers"
+		}
+		return fmt.Sprintf("upkeeping has expired, data is not challenged and repaired any more and will be deleted in %s, please renew or export it", utils.FormatSecond(left))
+	}
+
+	if left < expireWarnTime {
+		auto := ""
+		if ok, days := g.getRenewPolicy(); ok {
+			auto = fmt.Sprintf(", it will be renewed %d days automatically", days)
+		}
+		return fmt.Sprintf("upkeeping expires in %s%s", utils.FormatSecond(left), auto)
+	}
+
+	return ""
+}
+
+// checkExpire warns and renews upkeeping if policy is set
+func (l *LfsInfo) checkExpire(ctx context.Context) {
+	tick := time.NewTicker(checkExpireDur)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			g := l.gInfo
+			if g == nil || g.state < groupStarted {
+				continue
+			}
+
+			warn := g.GetExpireWarning()
+			if warn == "" {
+				continue
+			}
+			utils.MLogger.Warnf("Lfs %s of user %s: %s", l.fsID, l.userID, warn)
+
+			auto, days := g.getRenewPolicy()
+
+			if !auto || days <= 0 || g.GetUk().EndTime-time.Now().Unix() > renewAheadTime {
+				continue
+			}
+
+			err := g.Renew(ctx, days)
+			if err != nil {
+				utils.MLogger.Error("Auto renew lfs ", l.fsID, " fails: ", err)
+				continue
+			}
+			utils.MLogger.Infof("Auto renew lfs %s for %d days", l.fsID, days)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -56,6 +56,7 @@ type FileSyetem interface {
 	CreateBucket(ctx context.Context, bucketName string, options *mpb.BucketOptions) (*mpb.BucketInfo, error)
 	HeadBucket(ctx context.Context, bucketName string) (*mpb.BucketInfo, error)
 	DeleteBucket(ctx context.Context, bucketName string) (*mpb.BucketInfo, error)
+	TranscodeBucket(ctx context.Context, bucketName string, options *mpb.BucketOptions) (*mpb.BucketInfo, error)
 
 	ListObjects(ctx context.Context, bucketName, prefix string, opts ListObjectsOptions) ([]*mpb.ObjectInfo, error)
 
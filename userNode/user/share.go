@@ -43,6 +43,11 @@ func (l *LfsInfo) GenShareObject(ctx context.Context, bucketName, objectName str
 
 	for i := 0; i < int(object.GetPartCount()); i++ {
 		sl.OParts[i] = object.Parts[i]
+		if bucket.GetOldOpts() != nil && sl.OParts[i].GetBOpts() == nil {
+			op := proto.Clone(object.Parts[i]).(*mpb.ObjectPart)
+			op.BOpts = bucket.partOptions(object.Parts[i])
+			sl.OParts[i] = op
+		}
 	}
 
 	if bucket.BOpts.Encryption == 1 {
@@ -119,22 +124,9 @@ func (u *Info) GetShareObject(ctx context.Context, writer io.Writer, completeFun
 
 	bo := sl.BOpts
 
-	bopt := &mpb.BlockOptions{
-		Bopts:   bo,
-		Start:   0,
-		UserID:  sl.UserID,
-		QueryID: sl.QueryID,
-	}
-
-	decoder, err := dataformat.NewDataCoderWithPrefix(sul.keySet, bopt)
-	if err != nil {
-		return err
-	}
-
 	dl := &downloadTask{
 		bucketID:     sl.BucketID,
 		group:        su.(*LfsInfo).gInfo,
-		decoder:      decoder,
 		startTime:    time.Now(),
 		encrypt:      bo.Encryption,
 		writer:       writer,
@@ -149,12 +141,30 @@ func (u *Info) GetShareObject(ctx context.Context, writer io.Writer, completeFun
 	pStart := int64(0)
 	length := int64(0)
 	for i := 0; i < len(sl.GetOParts()); i++ {
+		pbo := sl.OParts[i].GetBOpts()
+		if pbo == nil {
+			pbo = bo
+		}
+
+		bopt := &mpb.BlockOptions{
+			Bopts:   pbo,
+			Start:   0,
+			UserID:  sl.UserID,
+			QueryID: sl.QueryID,
+		}
+
+		decoder, err := dataformat.NewDataCoderWithPrefix(sul.keySet, bopt)
+		if err != nil {
+			return err
+		}
+		dl.decoder = decoder
+
 		dl.start = sl.OParts[i].GetStart() + pStart
 		dl.length = sl.OParts[i].GetLength() - pStart
 		if length > 0 && length-readLen < dl.length {
 			dl.length = length - readLen
 		}
-		err := dl.Start(ctx)
+		err = dl.Start(ctx)
 		if err != nil {
 			return err
 		}
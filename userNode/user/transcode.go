@@ -0,0 +1,438 @@
+package user
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/memoio/go-mefs/crypto/aes"
+	dataformat "github.com/memoio/go-mefs/data-format"
+	mpb "github.com/memoio/go-mefs/pb"
+	"github.com/memoio/go-mefs/utils"
+	"github.com/memoio/go-mefs/utils/metainfo"
+)
+
+// stripeSize returns length of data in a stripe of bo
+func stripeSize(bo *mpb.BucketOptions) int64 {
+	return int64(bo.GetSegmentCount()) * int64(bo.GetSegmentSize()) * int64(bo.GetDataCount())
+}
+
+// partOptions returns options of stripes which part is stored in; during
+// transcoding, parts without options are in old stripes
+func (b *superBucket) partOptions(part *mpb.ObjectPart) *mpb.BucketOptions {
+	if part.GetBOpts() != nil {
+		return part.GetBOpts()
+	}
+	if b.GetOldOpts() != nil {
+		return b.GetOldOpts()
+	}
+	return b.GetBOpts()
+}
+
+// inOldStripes reports whether part is stored in stripes before transcoding
+func (b *superBucket) inOldStripes(part *mpb.ObjectPart) bool {
+	if b.GetOldOpts() == nil {
+		return false
+	}
+	size := stripeSize(b.partOptions(part))
+	return size > 0 && part.GetStart()/size < b.GetTranscodeStripe()
+}
+
+// replacePart replaces part of object by the re-encoded one
+func replacePart(oi *mpb.ObjectInfo, part *mpb.ObjectPart) error {
+	for i, p := range oi.GetParts() {
+		if p.GetPartID() == part.GetPartID() {
+			oi.Parts[i] = part
+			return nil
+		}
+	}
+	return ErrObjectNotExist
+}
+
+// TranscodeBucket changes policy, data, parity and local count of bucket to
+// those in options; existing stripes are re-encoded in background and deleted
+// afterwards. Segment size, segment count, tag flag and encryption are kept.
+func (l *LfsInfo) TranscodeBucket(ctx context.Context, bucketName string, options *mpb.BucketOptions) (*mpb.BucketInfo, error) {
+	//操作需要1资源
+	ok := l.Sm.TryAcquire(1)
+	if !ok {
+		return nil, ErrResourceUnavailable
+	}
+	defer l.Sm.Release(1)
+
+	bucket, err := l.getBucketInfo(bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket.Lock()
+	defer bucket.Unlock()
+
+	if bucket.GetOldOpts() != nil {
+		return nil, ErrBucketTranscoding
+	}
+
+	old := bucket.BOpts
+	bo := proto.Clone(old).(*mpb.BucketOptions)
+	bo.Policy = options.GetPolicy()
+	bo.DataCount = options.GetDataCount()
+	bo.ParityCount = options.GetParityCount()
+	bo.LocalCount = 0
+	if bo.Policy == dataformat.LrcPolicy {
+		bo.LocalCount = options.GetLocalCount()
+	}
+
+	err = l.checkBucketOptions(bo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.setSealIDs(ctx, bo)
+	if err != nil {
+		return nil, err
+	}
+
+	if proto.Equal(bo, old) {
+		return nil, ErrWrongParameters
+	}
+
+	// new stripes follow old ones
+	size := stripeSize(old)
+	first := (bucket.GetLength() + size - 1) / size
+
+	bucket.OldOpts = old
+	bucket.TranscodeStripe = first
+	bucket.BOpts = bo
+	bucket.Length = first * stripeSize(bo)
+	bucket.MTime = time.Now().Unix()
+	bucket.dirty = true
+	l.meta.dirty = true
+
+	utils.MLogger.Infof("transcode bucket %s from stripe %d, policy: %d, data count: %d, parity count: %d", bucketName, first, bo.Policy, bo.DataCount, bo.ParityCount)
+
+	l.putBucketToKeepers(ctx, bucket)
+
+	go l.transcode(l.context, bucket)
+
+	return &bucket.BucketInfo, nil
+}
+
+func (l *LfsInfo) putBucketToKeepers(ctx context.Context, bucket *superBucket) {
+	bk, _ := metainfo.NewKey(l.fsID, mpb.KeyType_Bucket, l.userID, strconv.FormatInt(bucket.GetBucketID(), 10))
+
+	val, err := proto.Marshal(&bucket.BucketInfo)
+	if err == nil {
+		l.gInfo.putDataToKeepers(ctx, bk.ToString(), val)
+	}
+}
+
+// transcode re-encodes parts in old stripes of bucket one by one, then
+// deletes old stripes
+func (l *LfsInfo) transcode(ctx context.Context, bucket *superBucket) {
+	bucket.RLock()
+	old := bucket.GetOldOpts()
+	first := bucket.GetTranscodeStripe()
+	bucket.RUnlock()
+	if old == nil {
+		return
+	}
+
+	utils.MLogger.Infof("transcode bucket %s begins", bucket.GetName())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if bucket.Deletion {
+			return
+		}
+
+		object, part := nextOldPart(bucket)
+		if part == nil {
+			break
+		}
+
+		err := l.transcodePart(ctx, bucket, object, part)
+		if err != nil {
+			utils.MLogger.Warnf("transcode part %d of object %s in bucket %s fails: %s", part.GetPartID(), part.GetName(), bucket.GetName(), err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Minute):
+			}
+		}
+	}
+
+	// all parts are in new stripes
+	bc := int(old.GetDataCount() + old.GetParityCount())
+	bid := strconv.FormatInt(bucket.GetBucketID(), 10)
+	for sid := int64(0); sid < first; sid++ {
+		for cid := 0; cid < bc; cid++ {
+			bm, err := metainfo.NewBlockMeta(l.fsID, bid, strconv.FormatInt(sid, 10), strconv.Itoa(cid))
+			if err != nil {
+				continue
+			}
+			err = l.gInfo.deleteBlocksFromProvider(ctx, bm.ToString(), false)
+			if err != nil {
+				utils.MLogger.Warnf("delete old block %s fails: %s", bm.ToString(), err)
+			}
+		}
+	}
+
+	bucket.Lock()
+	bucket.OldOpts = nil
+	bucket.TranscodeStripe = 0
+	bucket.MTime = time.Now().Unix()
+	bucket.dirty = true
+	l.meta.dirty = true
+	bucket.Unlock()
+
+	l.putBucketToKeepers(ctx, bucket)
+
+	utils.MLogger.Infof("transcode bucket %s ends", bucket.GetName())
+}
+
+// resumeTranscode continues transcoding of buckets after restart
+func (l *LfsInfo) resumeTranscode() {
+	if !l.writable {
+		return
+	}
+
+	for _, bucket := range l.meta.buckets {
+		if bucket.GetOldOpts() != nil && !bucket.Deletion {
+			go l.transcode(l.context, bucket)
+		}
+	}
+}
+
+// nextOldPart finds a part in old stripes
+func nextOldPart(bucket *superBucket) (*ObjectInfo, *mpb.ObjectPart) {
+	bucket.RLock()
+	defer bucket.RUnlock()
+
+	objectIter := bucket.Objects.Iterator()
+	for objectIter != nil {
+		object := objectIter.Value.(*ObjectInfo)
+		objectIter = objectIter.Next()
+		if object.Deletion {
+			continue
+		}
+
+		object.RLock()
+		for _, part := range object.GetParts() {
+			if bucket.inOldStripes(part) {
+				object.RUnlock()
+				return object, part
+			}
+		}
+		object.RUnlock()
+	}
+	return nil, nil
+}
+
+// transcodeLen returns length of stripes taken by part of length which
+// begins at start in stripes of bo; stripes after are left to later uploads
+func transcodeLen(bo *mpb.BucketOptions, start, length int64) int64 {
+	if length <= 0 {
+		return 0
+	}
+
+	size := stripeSize(bo)
+	end := start + length
+	if end%size != 0 {
+		end += size - end%size
+	}
+	return end - start
+}
+
+// reservePart takes space for re-encoded part at end of bucket, so uploads
+// during transcoding do not write to it; it returns nil if part needs not
+// transcoding
+func (b *superBucket) reservePart(object *ObjectInfo, part *mpb.ObjectPart) (*mpb.ObjectPart, int64) {
+	b.Lock()
+	defer b.Unlock()
+	object.RLock()
+	defer object.RUnlock()
+
+	if object.Deletion || !b.inOldStripes(part) {
+		return nil, 0
+	}
+
+	// padding of encryption is stored too
+	length := part.GetLength()
+	if b.partOptions(part).GetEncryption() == 1 && length%aes.BlockSize != 0 {
+		length += aes.BlockSize - length%aes.BlockSize
+	}
+
+	np := proto.Clone(part).(*mpb.ObjectPart)
+	np.Start = b.GetLength()
+	np.BOpts = b.BOpts
+
+	b.Length += transcodeLen(b.BOpts, np.Start, length)
+	b.MTime = time.Now().Unix()
+	b.dirty = true
+	return np, length
+}
+
+// releasePart gives back space reserved for np, if nothing is uploaded after
+func (b *superBucket) releasePart(np *mpb.ObjectPart, length int64) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.GetLength() == np.GetStart()+transcodeLen(np.GetBOpts(), np.GetStart(), length) {
+		b.Length = np.GetStart()
+		b.dirty = true
+	}
+}
+
+// swapPart replaces part in object by re-encoded np and adds op of it to
+// bucket tree; caller holds locks of bucket and object. It returns nil if
+// part is deleted or replaced during transcoding.
+func (b *superBucket) swapPart(object *ObjectInfo, part, np *mpb.ObjectPart) (*mpb.OpRecord, error) {
+	if object.Deletion || !b.inOldStripes(part) {
+		return nil, nil
+	}
+
+	found := false
+	for _, p := range object.GetParts() {
+		if p == part {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	payload, err := proto.Marshal(np)
+	if err != nil {
+		return nil, err
+	}
+	op := &mpb.OpRecord{
+		OpType:  mpb.LfsOp_OpTranscode,
+		OpID:    b.GetNextOpID(),
+		Payload: payload,
+	}
+
+	// leaf is OpID + PayLoad
+	tag, err := proto.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+
+	err = replacePart(&object.ObjectInfo, np)
+	if err != nil {
+		return nil, err
+	}
+
+	b.applyOpID = op.GetOpID()
+	b.NextOpID++
+	b.mtree.Push(tag)
+	b.Root = b.mtree.Root()
+	b.MTime = time.Now().Unix()
+	b.dirty = true
+	return op, nil
+}
+
+// transcodePart reads stored data of part from old stripes, and writes it
+// to new stripes without decryption. Data is moved without locks of bucket
+// and object, so reading and uploading go on; locks are only taken to
+// swap part meta.
+func (l *LfsInfo) transcodePart(ctx context.Context, bucket *superBucket, object *ObjectInfo, part *mpb.ObjectPart) error {
+	np, length := bucket.reservePart(object, part)
+	if np == nil {
+		return nil
+	}
+
+	if length > 0 {
+		err := l.moveStripes(ctx, bucket, part, np, length)
+		if err != nil {
+			bucket.releasePart(np, length)
+			return err
+		}
+	}
+
+	bucket.Lock()
+	defer bucket.Unlock()
+	object.Lock()
+	defer object.Unlock()
+
+	op, err := bucket.swapPart(object, part, np)
+	if err != nil || op == nil {
+		return err
+	}
+
+	l.flushObjectMeta(bucket, false, op)
+	l.meta.dirty = true
+
+	utils.MLogger.Infof("transcode part %d of object %s in bucket %s, new start is %d", np.GetPartID(), np.GetName(), bucket.GetName(), np.GetStart())
+	return nil
+}
+
+// moveStripes downloads length of stored data of part, and uploads it to
+// stripes of np
+func (l *LfsInfo) moveStripes(ctx context.Context, bucket *superBucket, part, np *mpb.ObjectPart, length int64) error {
+	bucket.RLock()
+	old := bucket.partOptions(part)
+	bucket.RUnlock()
+
+	decoder, err := dataformat.NewDataCoderWithPrefix(l.keySet, &mpb.BlockOptions{
+		Bopts:   old,
+		UserID:  l.userID,
+		QueryID: l.fsID,
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder, err := dataformat.NewDataCoderWithPrefix(l.keySet, &mpb.BlockOptions{
+		Bopts:   np.GetBOpts(),
+		UserID:  l.userID,
+		QueryID: l.fsID,
+	})
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	dl := &downloadTask{
+		bucketID:  bucket.BucketID,
+		group:     l.gInfo,
+		decoder:   decoder,
+		startTime: time.Now(),
+		start:     part.GetStart(),
+		length:    length,
+		writer:    pw,
+	}
+
+	go func() {
+		pw.CloseWithError(dl.Start(ctx))
+	}()
+
+	ul := &uploadTask{
+		startTime:       time.Now(),
+		reader:          pr,
+		gInfo:           l.gInfo,
+		bucketID:        bucket.BucketID,
+		begin:           np.GetStart(),
+		encoder:         encoder,
+		taskWorkerCount: defaultTaskWorkerCount,
+	}
+
+	err = ul.Start(ctx)
+	pr.CloseWithError(err)
+	if err != nil {
+		return err
+	}
+
+	if ul.length != length || ul.sucLen != length {
+		utils.MLogger.Infof("transcode %d, but success %d", length, ul.sucLen)
+		return ErrUpload
+	}
+	return nil
+}
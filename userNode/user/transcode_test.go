@@ -0,0 +1,167 @@
+package user
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	dataformat "github.com/memoio/go-mefs/data-format"
+	mpb "github.com/memoio/go-mefs/pb"
+)
+
+func TestTranscodeLen(t *testing.T) {
+	bo := dataformat.DefaultBucketOptions()
+	size := stripeSize(bo)
+
+	if transcodeLen(bo, size, 0) != 0 {
+		t.Fatal("empty part takes stripes")
+	}
+
+	if transcodeLen(bo, size, 100) != size || transcodeLen(bo, size, size) != size || transcodeLen(bo, size, size+1) != 2*size {
+		t.Fatal("part does not take whole stripes")
+	}
+
+	// part begins in middle of a stripe
+	if transcodeLen(bo, size+100, 100) != size-100 {
+		t.Fatal("part in middle of stripe takes ", transcodeLen(bo, size+100, 100))
+	}
+}
+
+// testTranscodeBucket returns bucket transcoding from 3+2 to 4+2 at stripe 2,
+// with an object of one part in old stripes and one part in new stripes
+func testTranscodeBucket() (*superBucket, *ObjectInfo) {
+	old := dataformat.DefaultBucketOptions()
+	bo := dataformat.DefaultBucketOptions()
+	bo.DataCount = 4
+
+	first := int64(2)
+	bucket := newsuperBucket(mpb.BucketInfo{
+		BucketID:        1,
+		BOpts:           bo,
+		OldOpts:         old,
+		TranscodeStripe: first,
+		Length:          first * stripeSize(bo),
+		NextOpID:        3,
+	}, true)
+
+	object := &ObjectInfo{
+		ObjectInfo: mpb.ObjectInfo{
+			Info: &mpb.Object{Name: "a", BucketID: 1},
+			Parts: []*mpb.ObjectPart{
+				{Name: "a", PartID: 0, Start: 0, Length: 100},
+				{Name: "a", PartID: 1, Start: first * stripeSize(bo), Length: 100, BOpts: bo},
+			},
+		},
+	}
+	bucket.Length += stripeSize(bo)
+
+	return bucket, object
+}
+
+// unlocked checks locks of bucket and object can be taken for reading
+func unlocked(bucket *superBucket, object *ObjectInfo) bool {
+	done := make(chan struct{})
+	go func() {
+		bucket.RLock()
+		object.RLock()
+		object.RUnlock()
+		bucket.RUnlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(time.Second):
+		return false
+	}
+}
+
+func TestReservePart(t *testing.T) {
+	bucket, object := testTranscodeBucket()
+	part := object.GetParts()[0]
+	begin := bucket.GetLength()
+
+	np, length := bucket.reservePart(object, part)
+	if np == nil {
+		t.Fatal("part in old stripes is not reserved")
+	}
+
+	// padding of encryption is moved too
+	if length != 112 || np.GetStart() != begin || np.GetBOpts() != bucket.BOpts {
+		t.Fatal("wrong reserved part: ", np, length)
+	}
+
+	if bucket.GetLength() != begin+stripeSize(bucket.BOpts) {
+		t.Fatal("bucket length is ", bucket.GetLength(), " after reserving")
+	}
+
+	// data is moved without locks
+	if !unlocked(bucket, object) {
+		t.Fatal("locks are held after reserving")
+	}
+
+	if p, _ := bucket.reservePart(object, object.GetParts()[1]); p != nil {
+		t.Fatal("part in new stripes is reserved")
+	}
+
+	// space is given back only if nothing is uploaded after
+	bucket.releasePart(np, length)
+	if bucket.GetLength() != begin {
+		t.Fatal("reserved space is not released")
+	}
+
+	np, length = bucket.reservePart(object, part)
+	bucket.Length += 100
+	bucket.releasePart(np, length)
+	if bucket.GetLength() != begin+stripeSize(bucket.BOpts)+100 {
+		t.Fatal("space uploaded after is released")
+	}
+
+	object.Deletion = true
+	if p, _ := bucket.reservePart(object, part); p != nil {
+		t.Fatal("part of deleted object is reserved")
+	}
+}
+
+func TestSwapPart(t *testing.T) {
+	bucket, object := testTranscodeBucket()
+	part := object.GetParts()[0]
+
+	np, _ := bucket.reservePart(object, part)
+	root := bucket.mtree.Root()
+
+	op, err := bucket.swapPart(object, part, np)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if op == nil || op.GetOpType() != mpb.LfsOp_OpTranscode || op.GetOpID() != 3 || bucket.GetNextOpID() != 4 {
+		t.Fatal("wrong op of swapping: ", op)
+	}
+
+	if object.GetParts()[0] != np || bucket.inOldStripes(object.GetParts()[0]) {
+		t.Fatal("part is not swapped")
+	}
+
+	if bytes.Equal(bucket.mtree.Root(), root) || !bytes.Equal(bucket.GetRoot(), bucket.mtree.Root()) {
+		t.Fatal("op is not added to bucket tree")
+	}
+
+	// part swapped already is skipped
+	op, err = bucket.swapPart(object, part, np)
+	if err != nil || op != nil || bucket.GetNextOpID() != 4 {
+		t.Fatal("replaced part is swapped again: ", op, err)
+	}
+
+	// object deleted during transcoding is skipped
+	bucket, object = testTranscodeBucket()
+	part = object.GetParts()[0]
+	np, _ = bucket.reservePart(object, part)
+	object.Deletion = true
+
+	op, err = bucket.swapPart(object, part, np)
+	if err != nil || op != nil || object.GetParts()[0] != part {
+		t.Fatal("part of deleted object is swapped: ", op, err)
+	}
+}
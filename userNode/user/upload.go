@@ -285,6 +285,11 @@ func (l *LfsInfo) addObjectData(ctx context.Context, bucket *superBucket, object
 		CTime:    time.Now().Unix(),
 	}
 
+	// old parts use old options during transcoding
+	if bucket.GetOldOpts() != nil {
+		opart.BOpts = bucket.BOpts
+	}
+
 	ul := &uploadTask{
 		startTime:       time.Now(), // for queue?
 		reader:          reader,
@@ -485,6 +490,9 @@ func (u *uploadTask) Start(ctx context.Context) error {
 
 			// transfer to different providers
 			newpro := utils.DisorderArray(pros)
+			if sealIDs := enc.Prefix.Bopts.GetSealIDs(); len(sealIDs) > 0 {
+				newpro = sealedPlacement(sealIDs, pros, u.gInfo.groupID)
+			}
 
 			bm, _ := metainfo.NewBlockMeta(u.gInfo.groupID, strconv.Itoa(int(u.bucketID)), strconv.Itoa(curStripe), "0")
 
@@ -607,6 +615,21 @@ func (u *uploadTask) Start(ctx context.Context) error {
 	return errrt
 }
 
+// sealedPlacement places chunk i on SealIDs[i], which its replica is sealed
+// for; chunks of offline providers are not placed on other providers, which
+// cannot prove them, but on placeholder
+func sealedPlacement(sealIDs, pros []string, placeholder string) []string {
+	res := make([]string, len(sealIDs))
+	for i, id := range sealIDs {
+		if !utils.CheckDup(pros, id) {
+			res[i] = id
+		} else {
+			res[i] = placeholder
+		}
+	}
+	return res
+}
+
 func calculateETag(ob *ObjectInfo) string {
 	if len(ob.GetParts()) == 0 {
 		return ""
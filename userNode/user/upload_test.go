@@ -0,0 +1,20 @@
+package user
+
+import "testing"
+
+func TestSealedPlacement(t *testing.T) {
+	sealIDs := []string{"pro0", "pro1", "pro2"}
+
+	res := sealedPlacement(sealIDs, []string{"pro2", "pro0", "pro1"}, "group")
+	for i, id := range sealIDs {
+		if res[i] != id {
+			t.Fatal("chunk ", i, " is placed on ", res[i])
+		}
+	}
+
+	// chunk of offline provider is not moved to a spare provider
+	res = sealedPlacement(sealIDs, []string{"pro0", "pro2", "pro3"}, "group")
+	if res[0] != "pro0" || res[1] != "group" || res[2] != "pro2" {
+		t.Fatal("wrong placement: ", res)
+	}
+}
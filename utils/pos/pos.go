@@ -2,7 +2,6 @@ package pos
 
 import (
 	"encoding/hex"
-	"log"
 	"math/big"
 
 	"github.com/memoio/go-mefs/utils"
@@ -37,6 +36,5 @@ func GetPostSeed() []byte {
 	if err != nil {
 		return nil
 	}
-	log.Println("post seed is: ", hex.EncodeToString(seed[:]))
 	return seed
 }
@@ -0,0 +1,168 @@
+package pos
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"strconv"
+	"sync"
+
+	"github.com/memoio/go-mefs/crypto/vdf"
+)
+
+// Post data of a provider is derived from its ID: the plain data of stripe sid
+// is an AES-CTR stream keyed by postSeed, proID and sid, which is copied to Reps
+// replicas; every segment of each replica is then sealed by vdf word by word
+// with a key of its chunkID, and each word's key is chained with the sealed
+// word before it, so replicas differ and each one must be stored. Sealing a
+// segment is slow and sequential, while unsealing any word is fast, so keepers
+// can verify the sampled words of a segment without storing post data.
+//
+// Segments are sealed independently, so a provider which regenerates them on
+// the fly does it in parallel; SealRound is sized so that regenerating
+// PostChalNum segments of a challenge on PostAttackCores cores takes twice of
+// PostProofTimeout (in role):
+//
+//	128 * SealRound * 11.8ms / 16 >= 2 * 3s
+//
+// Sealing a segment takes about 0.75s on one core (BenchmarkSealSegment), so
+// filling a stripe of Reps replicas takes about 24000s of cpu, the fill rate
+// is about 43KB/s per core (BenchmarkFill).
+const (
+	SealRound = 64 // vdf rounds for sealing a word
+	WordSize  = vdf.SealWordSize
+)
+
+var (
+	vdfOnce  sync.Once
+	vdfCodec *vdf.VDFCodec
+)
+
+func getVDFCodec() *vdf.VDFCodec {
+	vdfOnce.Do(func() {
+		vdfCodec = vdf.NewVDF(127)
+	})
+	return vdfCodec
+}
+
+func getStreamKey(proID string, sid int) []byte {
+	h := sha256.New()
+	h.Write(GetPostSeed())
+	h.Write([]byte(proID))
+	h.Write([]byte(strconv.Itoa(sid)))
+	return h.Sum(nil)
+}
+
+func getSealKey(proID string, sid, chunkID, segIndex int) []byte {
+	h := sha256.New()
+	h.Write([]byte(proID))
+	h.Write([]byte(strconv.Itoa(sid)))
+	h.Write([]byte(strconv.Itoa(chunkID)))
+	h.Write([]byte(strconv.Itoa(segIndex)))
+	return h.Sum(nil)[:WordSize]
+}
+
+// GenPlainSegment fills seg with plain post data of proID at stripe sid from segment segIndex
+func GenPlainSegment(seg []byte, proID string, sid, segIndex int) error {
+	block, err := aes.NewCipher(getStreamKey(proID, sid))
+	if err != nil {
+		return err
+	}
+
+	// counter starts at the first word of segIndex
+	iv := make([]byte, aes.BlockSize)
+	binary.BigEndian.PutUint64(iv[8:], uint64(segIndex)*uint64(SegSize/aes.BlockSize))
+
+	for i := range seg {
+		seg[i] = 0
+	}
+	cipher.NewCTR(block, iv).XORKeyStream(seg, seg)
+	return nil
+}
+
+// chainKey returns key of the word after prev
+func chainKey(key, prev []byte) []byte {
+	k := make([]byte, WordSize)
+	for i := range k {
+		k[i] = key[i] ^ prev[i]
+	}
+	return k
+}
+
+// SealSegment seals plain segment segIndex of replica chunkID at stripe sid in place
+func SealSegment(seg []byte, proID string, sid, chunkID, segIndex int) error {
+	if len(seg)%WordSize != 0 {
+		return vdf.ErrInvalidSealInput
+	}
+
+	codec := getVDFCodec()
+	key := getSealKey(proID, sid, chunkID, segIndex)
+	prev := make([]byte, WordSize)
+	for i := 0; i < len(seg); i += WordSize {
+		word := seg[i : i+WordSize]
+		err := codec.Seal(word, chainKey(key, prev), SealRound)
+		if err != nil {
+			return err
+		}
+		prev = word
+	}
+	return nil
+}
+
+// GenData generates plain post data of proID at stripe sid, each replica of
+// it is sealed by SealSegment
+func GenData(proID string, sid int) ([]byte, error) {
+	data := make([]byte, DLen)
+	err := GenPlainSegment(data, proID, sid, 0)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// VerifySegment checks words random sampled from sealed segment segIndex of
+// replica chunkID at stripe sid; all words are checked if words <= 0
+func VerifySegment(seg []byte, proID string, sid, chunkID, segIndex, words int) bool {
+	if len(seg) != SegSize {
+		return false
+	}
+
+	plain := make([]byte, SegSize)
+	err := GenPlainSegment(plain, proID, sid, segIndex)
+	if err != nil {
+		return false
+	}
+
+	wordNum := SegSize / WordSize
+	if words <= 0 || words > wordNum {
+		words = wordNum
+	}
+
+	codec := getVDFCodec()
+	key := getSealKey(proID, sid, chunkID, segIndex)
+	prev := make([]byte, WordSize)
+	word := make([]byte, WordSize)
+	for _, j := range rand.Perm(wordNum)[:words] {
+		for n := range prev {
+			prev[n] = 0
+		}
+		if j > 0 {
+			copy(prev, seg[(j-1)*WordSize:j*WordSize])
+		}
+
+		copy(word, seg[j*WordSize:(j+1)*WordSize])
+		err := codec.Unseal(word, chainKey(key, prev), SealRound)
+		if err != nil {
+			return false
+		}
+
+		if !bytes.Equal(word, plain[j*WordSize:(j+1)*WordSize]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,108 @@
+package pos
+
+import (
+	"bytes"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSealSegment(t *testing.T) {
+	proID := "8MGxCuiT75bje883b7uFb6eMrJt5cP"
+	seg := make([]byte, SegSize)
+	err := GenPlainSegment(seg, proID, 1, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rep := append([]byte(nil), seg...)
+
+	err = SealSegment(seg, proID, 1, 0, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !VerifySegment(seg, proID, 1, 0, 7, 0) {
+		t.Fatal("verify sealed segment fails")
+	}
+
+	if VerifySegment(seg, proID, 1, 0, 8, 0) {
+		t.Fatal("sealed segment is verified at wrong index")
+	}
+
+	if VerifySegment(seg, "8MGxCuiT75bje883b7uFb6eMrJt5cQ", 1, 0, 7, 0) {
+		t.Fatal("sealed segment is verified for wrong provider")
+	}
+
+	// each replica is sealed differently
+	err = SealSegment(rep, proID, 1, 1, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(seg, rep) || VerifySegment(seg, proID, 1, 1, 7, 0) || !VerifySegment(rep, proID, 1, 1, 7, 0) {
+		t.Fatal("replicas are sealed with same key")
+	}
+
+	seg[100] ^= 1
+	if VerifySegment(seg, proID, 1, 0, 7, 0) {
+		t.Fatal("modified segment is verified")
+	}
+}
+
+func TestGenData(t *testing.T) {
+	proID := "8MGxCuiT75bje883b7uFb6eMrJt5cP"
+	data, err := GenData(proID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seg := make([]byte, SegSize)
+	for _, i := range []int{0, 1, SegCount - 1} {
+		err := GenPlainSegment(seg, proID, 1, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(seg, data[i*SegSize:(i+1)*SegSize]) {
+			t.Fatal("segment ", i, " differs with plain data")
+		}
+	}
+}
+
+// BenchmarkSealSegment seals a post segment in SealRound rounds
+func BenchmarkSealSegment(b *testing.B) {
+	proID := "8MGxCuiT75bje883b7uFb6eMrJt5cP"
+	seg := make([]byte, SegSize)
+	for i := 0; i < b.N; i++ {
+		err := GenPlainSegment(seg, proID, 1, i)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		err = SealSegment(seg, proID, 1, 0, i)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFill reports rate of filling post data on all cpus, which is also
+// rate of regenerating challenged segments in parallel
+func BenchmarkFill(b *testing.B) {
+	proID := "8MGxCuiT75bje883b7uFb6eMrJt5cP"
+	var next int64
+	b.SetBytes(SegSize)
+	b.RunParallel(func(pb *testing.PB) {
+		seg := make([]byte, SegSize)
+		for pb.Next() {
+			i := int(atomic.AddInt64(&next, 1))
+			err := GenPlainSegment(seg, proID, 1, i%SegCount)
+			if err == nil {
+				err = SealSegment(seg, proID, 1, i/SegCount%Reps, i%SegCount)
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -66,6 +66,10 @@ const (
 	DefaultDuration int64 = 100 // day
 	// DefaultCycle is default cycle: 1 day
 	DefaultCycle = 24 * 60 * 60 // seconds
+	// DefaultGracePeriod is default days of keeping data after upkeeping ends： 7 days
+	DefaultGracePeriod int64 = 7 // day
+	// MaxGracePeriod is max days of keeping data after upkeeping ends： 30 days
+	MaxGracePeriod int64 = 30 // day
 
 	// offer options
 